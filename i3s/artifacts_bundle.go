@@ -143,12 +143,7 @@ func (c *I3SClient) GetArtifactsBundles(filter string, sort string) (ArtifactsBu
 	}
 
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	// Setup query
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
 	if err != nil {
 		return artifactsBundles, err
 	}
@@ -106,12 +106,7 @@ func (c *I3SClient) GetDeploymentPlans(count string, filter string, query string
 	}
 
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	// Setup query
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
 	if err != nil {
 		return deploymentPlans, err
 	}
@@ -96,12 +96,7 @@ func (c *I3SClient) GetGoldenImages(filter string, sort string) (GoldenImageList
 	}
 
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	// Setup query
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
 	if err != nil {
 		return goldenImages, err
 	}
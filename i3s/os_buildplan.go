@@ -91,12 +91,7 @@ func (c *I3SClient) GetOSBuildPlans(filter string, sort string) (OSBuildPlanList
 	}
 
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	// Setup query
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
 	if err != nil {
 		return osBuildPlans, err
 	}
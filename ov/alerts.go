@@ -0,0 +1,175 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/docker/machine/libmachine/log"
+)
+
+// alert states accepted in Alert.AlertState
+const (
+	ALERT_STATE_ACTIVE  = "Active"
+	ALERT_STATE_LOCKED  = "Locked"
+	ALERT_STATE_CLEARED = "Cleared"
+)
+
+// Alert alert raised by the appliance against a resource
+type Alert struct {
+	ActivityUri          utils.Nstring `json:"activityUri,omitempty"`          // "activityUri": "/rest/alerts/1",
+	AlertState           string        `json:"alertState,omitempty"`           // "alertState": "Active",
+	AlertTypeID          string        `json:"alertTypeID,omitempty"`          // "alertTypeID": "Trap.cpqHe3FltTolPowerSupplyDegraded",
+	AssignedToUser       string        `json:"assignedToUser,omitempty"`       // "assignedToUser": "administrator",
+	Category             string        `json:"category,omitempty"`             // "category": "alerts",
+	ClearedByUser        string        `json:"clearedByUser,omitempty"`        // "clearedByUser": null,
+	ClearedTime          string        `json:"clearedTime,omitempty"`          // "clearedTime": null,
+	CorrectiveAction     string        `json:"correctiveAction,omitempty"`     // "correctiveAction": "Replace the power supply.",
+	Created              string        `json:"created,omitempty"`              // "created": "2021-03-10T10:20:11.000Z",
+	Description          string        `json:"description,omitempty"`          // "description": "The power supply is degraded.",
+	ETAG                 string        `json:"eTag,omitempty"`                 // "eTag": "2021-03-10T10:20:11.000Z",
+	HealthCategory       string        `json:"healthCategory,omitempty"`       // "healthCategory": "Power",
+	LifeCycle            bool          `json:"lifeCycle,omitempty"`            // "lifeCycle": false,
+	Modified             string        `json:"modified,omitempty"`             // "modified": "2021-03-10T10:20:11.000Z",
+	PhysicalResourceType string        `json:"physicalResourceType,omitempty"` // "physicalResourceType": "server-hardware",
+	ResourceUri          utils.Nstring `json:"resourceUri,omitempty"`          // "resourceUri": "/rest/server-hardware/1",
+	Severity             string        `json:"severity,omitempty"`             // "severity": "Warning",
+	Type                 string        `json:"type,omitempty"`                 // "type": "AlertResourceV3",
+	URI                  utils.Nstring `json:"uri,omitempty"`                  // "uri": "/rest/alerts/1"
+}
+
+// AlertList list of alerts
+type AlertList struct {
+	Total       int           `json:"total,omitempty"`       // "total": 1,
+	Count       int           `json:"count,omitempty"`       // "count": 1,
+	Start       int           `json:"start,omitempty"`       // "start": 0,
+	PrevPageURI utils.Nstring `json:"prevPageUri,omitempty"` // "prevPageUri": null,
+	NextPageURI utils.Nstring `json:"nextPageUri,omitempty"` // "nextPageUri": null,
+	URI         utils.Nstring `json:"uri,omitempty"`         // "uri": "/rest/alerts?start=0&count=10",
+	Members     []Alert       `json:"members,omitempty"`     // "members":[]
+}
+
+// GetAlerts gets every alert matching the filter
+func (c *OVClient) GetAlerts(filter string, sort string) (AlertList, error) {
+	var (
+		alerts AlertList
+		q      = make(map[string]interface{})
+	)
+	if filter != "" {
+		q["filter"] = filter
+	}
+	if sort != "" {
+		q["sort"] = sort
+	}
+	err := c.Iterate("/rest/alerts", q, func(raw json.RawMessage) error {
+		var alert Alert
+		if err := json.Unmarshal(raw, &alert); err != nil {
+			return err
+		}
+		alerts.Members = append(alerts.Members, alert)
+		return nil
+	})
+	if err != nil {
+		return alerts, err
+	}
+	alerts.Total = len(alerts.Members)
+	alerts.Count = len(alerts.Members)
+	return alerts, nil
+}
+
+// GetAlertsByResource gets the alerts raised against a resource, newest first
+func (c *OVClient) GetAlertsByResource(resourceURI utils.Nstring) (AlertList, error) {
+	if resourceURI.IsNil() {
+		return AlertList{}, errors.New("Error getting alerts, no resource uri provided")
+	}
+	return c.GetAlerts(fmt.Sprintf("resourceUri='%s'", resourceURI), "created:descending")
+}
+
+// updateAlert sends a partial update of an alert, the appliance answers with the updated alert
+func (c *OVClient) updateAlert(alertURI utils.Nstring, update map[string]string) error {
+	if alertURI.IsNil() {
+		return errors.New("Error updating alert, no alert uri provided")
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	log.Debugf("REST : %s \n %+v\n", alertURI, update)
+	data, err := c.RestAPICall(rest.PUT, alertURI.String(), update)
+	if err != nil {
+		log.Errorf("Error submitting update alert request: %s", err)
+		return err
+	}
+	log.Debugf("Response update alert %s", data)
+	return nil
+}
+
+// AcknowledgeAlert clears an alert so it no longer counts against the health of its resource
+func (c *OVClient) AcknowledgeAlert(alertURI utils.Nstring) error {
+	return c.updateAlert(alertURI, map[string]string{"alertState": ALERT_STATE_CLEARED})
+}
+
+// AssignAlert assigns an alert to a user
+func (c *OVClient) AssignAlert(alertURI utils.Nstring, assignedToUser string) error {
+	if assignedToUser == "" {
+		return errors.New("Error assigning alert, no user provided")
+	}
+	return c.updateAlert(alertURI, map[string]string{"assignedToUser": assignedToUser})
+}
+
+// DeleteAlert deletes an alert, waiting for the task when one is returned
+func (c *OVClient) DeleteAlert(alertURI utils.Nstring) error {
+	var (
+		t *Task
+	)
+	if alertURI.IsNil() {
+		return errors.New("Error deleting alert, no alert uri provided")
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	t = t.NewProfileTask(c)
+	t.ResetTask()
+	log.Debugf("REST : %s \n", alertURI)
+	data, err := c.RestAPICall(rest.DELETE, alertURI.String(), nil)
+	if err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error submitting delete alert request: %s", err)
+		return err
+	}
+
+	log.Debugf("Response delete alert %s", data)
+	if len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, &t); err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with task un-marshal: %s", err)
+		return err
+	}
+	if !strings.HasPrefix(t.URI.String(), "/rest/tasks/") {
+		return nil
+	}
+	return t.Wait()
+}
@@ -52,6 +52,38 @@ func (c *OVClient) GetAPIVersion() (APIVersion, error) {
 	return apiversion, err
 }
 
+// GetAPIVersionRange - returns the api versions supported by the OneView server, the server
+// reports no maximum apart from its current version so max is always current
+func (c *OVClient) GetAPIVersionRange() (min int, current int, max int, err error) {
+	v, err := c.GetAPIVersion()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return v.MinimumVersion, v.CurrentVersion, v.CurrentVersion, nil
+}
+
+// ForAPIVersion - returns a copy of the client sending X-API-Version version, for the calls to a
+// resource not supporting the version of the client. The copy starts with the session of the client.
+func (c *OVClient) ForAPIVersion(version int) *OVClient {
+	token, expiry := c.GetSessionToken()
+	v := &OVClient{Client: rest.Client{
+		User:        c.User,
+		Password:    c.Password,
+		Domain:      c.Domain,
+		APIVersion:  version,
+		SSLVerify:   c.SSLVerify,
+		Endpoint:    c.Endpoint,
+		IfMatch:     c.IfMatch,
+		Retry:       c.Retry,
+		ProxyURL:    c.ProxyURL,
+		CACertPool:  c.CACertPool,
+		Timeout:     c.Timeout,
+		DialTimeout: c.DialTimeout,
+	}, ProfileDescriptionSeparator: c.ProfileDescriptionSeparator, ScopeAssignmentBatchSize: c.ScopeAssignmentBatchSize}
+	v.SetSessionToken(token, expiry)
+	return v
+}
+
 // RefreshVersion - refresh the max api Version for the client
 func (c *OVClient) RefreshVersion() error {
 	var v APIVersion
@@ -2,6 +2,7 @@ package ov
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/HewlettPackard/oneview-golang/rest"
@@ -40,12 +41,44 @@ type SNMPv3UserList struct {
 	URI         utils.Nstring `json:"uri,omitempty"`
 }
 
+// security levels accepted in SNMPv3User.SecurityLevel
+const (
+	SNMP_SECURITY_NONE         = "None"
+	SNMP_SECURITY_AUTH         = "Authentication"
+	SNMP_SECURITY_AUTH_PRIVACY = "Authentication and privacy"
+)
+
+// Validate checks the protocols and passphrases required by the security level,
+// privacy needs both an authentication and a privacy protocol
+func (u SNMPv3User) Validate() error {
+	if u.UserName == "" {
+		return errors.New("Error SNMPv3 user has no user name")
+	}
+	switch u.SecurityLevel {
+	case "", SNMP_SECURITY_NONE:
+		return nil
+	case SNMP_SECURITY_AUTH, SNMP_SECURITY_AUTH_PRIVACY:
+	default:
+		return fmt.Errorf("Error SNMPv3 user %s has unknown security level %q", u.UserName, u.SecurityLevel)
+	}
+	if u.AuthenticationProtocol == "" || u.AuthenticationPassphrase == "" {
+		return fmt.Errorf("Error SNMPv3 user %s requires an authentication protocol and passphrase with security level %s", u.UserName, u.SecurityLevel)
+	}
+	if u.SecurityLevel == SNMP_SECURITY_AUTH_PRIVACY && (u.PrivacyProtocol == "" || u.PrivacyPassphrase == "") {
+		return fmt.Errorf("Error SNMPv3 user %s requires a privacy protocol and passphrase with security level %s", u.UserName, u.SecurityLevel)
+	}
+	return nil
+}
+
 func (c *OVClient) CreateSNMPv3Users(snmpv3User SNMPv3User) (SNMPv3User, error) {
 	log.Infof("Initializing creation of  USM user for %s.", snmpv3User.UserName)
 	var (
 		uri = "/rest/appliance/snmpv3-trap-forwarding/users"
 		t   = (&Task{}).NewProfileTask(c)
 	)
+	if err := snmpv3User.Validate(); err != nil {
+		return snmpv3User, err
+	}
 	// refresh login
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
@@ -99,10 +132,7 @@ func (c *OVClient) GetSNMPv3Users(start string, count string, filter string, sor
 	if count != "" {
 		q["count"] = count
 	}
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
 	if err != nil {
 		return snmpv3userlist, err
 	}
@@ -155,6 +185,9 @@ func (c *OVClient) UpdateSNMPv3User(updateOption SNMPv3User, id string) (SNMPv3U
 		uri            = "/rest/appliance/snmpv3-trap-forwarding/users/" + id
 		updateResponse SNMPv3User
 	)
+	if err := updateOption.Validate(); err != nil {
+		return updateResponse, err
+	}
 
 	// refresh login
 	c.RefreshLogin()
@@ -224,10 +257,7 @@ func (c *OVClient) DeleteSNMPv3UserByName(username string) error {
 		q["filter"] = filter
 	}
 
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-	data, err := c.RestAPICall(rest.DELETE, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.DELETE, uri, q, nil)
 	if err != nil {
 		log.Errorf("Error submitting delete snmpv3 user request: %s", err)
 
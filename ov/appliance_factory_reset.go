@@ -0,0 +1,77 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/docker/machine/libmachine/log"
+)
+
+// factory reset modes
+const (
+	FACTORY_RESET_FULL             = "FULL"             // erase all data and settings including the network configuration
+	FACTORY_RESET_PRESERVE_NETWORK = "PRESERVE_NETWORK" // erase all data and settings but keep the appliance network configuration
+)
+
+// FactoryResetRequest options for an appliance factory reset, Confirm must be set
+// to true as the reset erases every resource managed by the appliance
+type FactoryResetRequest struct {
+	Mode    string
+	Confirm bool
+}
+
+// FactoryReset resets the appliance to its factory settings. The request is refused unless
+// Confirm is set. The task is returned without waiting, the appliance is unavailable
+// until the reset completes.
+func (c *OVClient) FactoryReset(req FactoryResetRequest) (*Task, error) {
+	var (
+		uri = "/rest/appliance"
+		t   *Task
+	)
+	if !req.Confirm {
+		return t, errors.New("Error refusing factory reset of the appliance, the request was not confirmed")
+	}
+	if req.Mode != FACTORY_RESET_FULL && req.Mode != FACTORY_RESET_PRESERVE_NETWORK {
+		return t, fmt.Errorf("Error factory reset mode %q is not valid, valid modes are %s and %s", req.Mode, FACTORY_RESET_FULL, FACTORY_RESET_PRESERVE_NETWORK)
+	}
+
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	t = t.NewProfileTask(c)
+	t.ResetTask()
+	log.Warnf("Submitting %s factory reset of appliance %s", req.Mode, c.Endpoint)
+	data, err := c.RestAPICall(rest.DELETE, uri, nil, map[string]interface{}{"mode": req.Mode})
+	if err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error submitting factory reset request: %s", err)
+		return t, err
+	}
+
+	log.Debugf("Response factory reset %s", data)
+	if err := json.Unmarshal(data, &t); err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with task un-marshal: %s", err)
+		return t, err
+	}
+	return t, nil
+}
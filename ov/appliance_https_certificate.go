@@ -0,0 +1,223 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/docker/machine/libmachine/log"
+)
+
+// ApplianceHttpsCertificate certificate used by the appliance web server
+type ApplianceHttpsCertificate struct {
+	AlternativeName       string        `json:"alternativeName,omitempty"`       // "alternativeName": "ov.example.com,192.0.2.10",
+	Base64Data            string        `json:"base64Data,omitempty"`            // "base64Data": "-----BEGIN CERTIFICATE-----...",
+	Category              string        `json:"category,omitempty"`              // "category": "certificates",
+	CommonName            string        `json:"commonName,omitempty"`            // "commonName": "ov.example.com",
+	Country               string        `json:"country,omitempty"`               // "country": "US",
+	Created               string        `json:"created,omitempty"`               // "created": "20150831T154835.250Z",
+	ETAG                  string        `json:"eTag,omitempty"`                  // "eTag": "1441036118675/8",
+	Expires               string        `json:"expiresInDays,omitempty"`         // "expiresInDays": "365",
+	IssuedTo              string        `json:"issuedTo,omitempty"`              // "issuedTo": "ov.example.com",
+	IssuedBy              string        `json:"issuedBy,omitempty"`              // "issuedBy": "ov.example.com",
+	Locality              string        `json:"locality,omitempty"`              // "locality": "Houston",
+	Modified              string        `json:"modified,omitempty"`              // "modified": "20150831T154835.250Z",
+	Organization          string        `json:"organization,omitempty"`          // "organization": "HPE",
+	OrganizationalUnit    string        `json:"organizationalUnit,omitempty"`    // "organizationalUnit": "Lab",
+	SerialNumber          string        `json:"serialNumber,omitempty"`          // "serialNumber": "4c:b9:...",
+	SignatureAlgorithm    string        `json:"signatureAlgorithm,omitempty"`    // "signatureAlgorithm": "SHA256withRSA",
+	State                 string        `json:"state,omitempty"`                 // "state": "Texas",
+	Type                  string        `json:"type,omitempty"`                  // "type": "CertificateDtoV2",
+	URI                   utils.Nstring `json:"uri,omitempty"`                   // "uri": "/rest/certificates/https"
+	ValidFrom             string        `json:"validFrom,omitempty"`             // "validFrom": "2021-03-01T00:00:00.000Z",
+	ValidUntil            string        `json:"validUntil,omitempty"`            // "validUntil": "2022-03-01T00:00:00.000Z",
+	Version               string        `json:"version,omitempty"`               // "version": "3",
+	SelfSignedCertificate bool          `json:"selfSignedCertificate,omitempty"` // "selfSignedCertificate": true
+}
+
+// Certificate certificate of the appliance web server, see ApplianceHttpsCertificate
+type Certificate = ApplianceHttpsCertificate
+
+// CertificateSigningRequest subject of a certificate signing request for the appliance web server
+type CertificateSigningRequest struct {
+	AlternativeName    string `json:"alternativeName,omitempty"`    // "alternativeName": "ov.example.com,192.0.2.10",
+	Base64Data         string `json:"base64Data,omitempty"`         // "base64Data": "-----BEGIN CERTIFICATE REQUEST-----...",
+	CommonName         string `json:"commonName,omitempty"`         // "commonName": "ov.example.com",
+	ContactPerson      string `json:"contactPerson,omitempty"`      // "contactPerson": "admin",
+	Country            string `json:"country,omitempty"`            // "country": "US",
+	Email              string `json:"email,omitempty"`              // "email": "admin@example.com",
+	Locality           string `json:"locality,omitempty"`           // "locality": "Houston",
+	Organization       string `json:"organization,omitempty"`       // "organization": "HPE",
+	OrganizationalUnit string `json:"organizationalUnit,omitempty"` // "organizationalUnit": "Lab",
+	State              string `json:"state,omitempty"`              // "state": "Texas",
+	Type               string `json:"type,omitempty"`               // "type": "CertificateSigningRequest"
+}
+
+// AlternativeNames returns the subject alternative names of the certificate
+func (cert ApplianceHttpsCertificate) AlternativeNames() []string {
+	names := []string{}
+	for _, name := range strings.Split(cert.AlternativeName, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// GetApplianceCertificate gets the certificate used by the appliance web server
+func (c *OVClient) GetApplianceCertificate() (Certificate, error) {
+	return c.GetApplianceHttpsCertificate()
+}
+
+// GetApplianceHttpsCertificate gets the certificate used by the appliance web server
+func (c *OVClient) GetApplianceHttpsCertificate() (ApplianceHttpsCertificate, error) {
+	var (
+		uri  = "/rest/certificates/https"
+		cert ApplianceHttpsCertificate
+	)
+
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	data, err := c.RestAPICall(rest.GET, uri, nil)
+	if err != nil {
+		return cert, err
+	}
+
+	log.Debugf("GetApplianceHttpsCertificate %s", data)
+	if err := json.Unmarshal(data, &cert); err != nil {
+		return cert, err
+	}
+	return cert, nil
+}
+
+// RegenerateApplianceSelfSignedCertificate creates a new self-signed certificate for the appliance
+// web server issued to commonName and alternativeNames, use after a hostname or ip change with the
+// new names. The organization and location of the current certificate are kept.
+// The task is returned without waiting, the appliance web server restarts once it completes.
+func (c *OVClient) RegenerateApplianceSelfSignedCertificate(commonName string, alternativeNames []string) (*Task, error) {
+	var (
+		uri = "/rest/certificates/https"
+		t   *Task
+	)
+	if strings.TrimSpace(commonName) == "" {
+		return t, errors.New("Error regenerating self-signed certificate, no common name provided")
+	}
+	current, err := c.GetApplianceHttpsCertificate()
+	if err != nil {
+		return t, err
+	}
+	cert := ApplianceHttpsCertificate{
+		AlternativeName:    strings.Join(alternativeNames, ","),
+		CommonName:         commonName,
+		Country:            current.Country,
+		Locality:           current.Locality,
+		Organization:       current.Organization,
+		OrganizationalUnit: current.OrganizationalUnit,
+		State:              current.State,
+		Type:               "CertificateDtoV2",
+	}
+
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	t = t.NewProfileTask(c)
+	t.ResetTask()
+	log.Debugf("REST : %s \n %+v\n", uri, cert)
+	data, err := c.RestAPICall(rest.PUT, uri, cert)
+	if err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error submitting self-signed certificate request: %s", err)
+		return t, err
+	}
+
+	log.Debugf("Response self-signed certificate %s", data)
+	if err := json.Unmarshal(data, &t); err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with task un-marshal: %s", err)
+		return t, err
+	}
+	return t, nil
+}
+
+// GenerateCSR generates a certificate signing request for the appliance web server and
+// returns it PEM encoded, sign it with a CA and import the result with ImportCertificate
+func (c *OVClient) GenerateCSR(req CertificateSigningRequest) (string, error) {
+	var (
+		uri = "/rest/certificates/https/certificaterequest"
+		csr CertificateSigningRequest
+	)
+	if req.CommonName == "" {
+		return "", errors.New("Error generating certificate signing request, no common name provided")
+	}
+	if req.Type == "" {
+		req.Type = "CertificateSigningRequest"
+	}
+
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	log.Debugf("REST : %s \n %+v\n", uri, req)
+	data, err := c.RestAPICall(rest.POST, uri, req)
+	if err != nil {
+		log.Errorf("Error submitting certificate signing request: %s", err)
+		return "", err
+	}
+
+	log.Debugf("Response certificate signing request %s", data)
+	if err := json.Unmarshal(data, &csr); err != nil {
+		return "", err
+	}
+	return csr.Base64Data, nil
+}
+
+// ImportCertificate replaces the certificate of the appliance web server with a CA signed
+// certificate issued for a request from GenerateCSR. The task is returned without waiting.
+func (c *OVClient) ImportCertificate(certPEM string) (*Task, error) {
+	var (
+		uri = "/rest/certificates/https/certificaterequest"
+		t   *Task
+	)
+	if strings.TrimSpace(certPEM) == "" {
+		return t, errors.New("Error importing certificate, no certificate provided")
+	}
+	cert := map[string]string{"type": "CertificateDataV2", "base64Data": certPEM}
+
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	t = t.NewProfileTask(c)
+	t.ResetTask()
+	log.Debugf("REST : %s \n", uri)
+	data, err := c.RestAPICall(rest.PUT, uri, cert)
+	if err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error submitting import certificate request: %s", err)
+		return t, err
+	}
+
+	log.Debugf("Response import certificate %s", data)
+	if err := json.Unmarshal(data, &t); err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with task un-marshal: %s", err)
+		return t, err
+	}
+	return t, nil
+}
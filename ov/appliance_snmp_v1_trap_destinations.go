@@ -123,10 +123,7 @@ func (c *OVClient) GetSNMPv1TrapDestinations(filter string, sort string, start s
 	if count != "" {
 		q["count"] = count
 	}
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
 	if err != nil {
 		return traplist, err
 	}
@@ -2,12 +2,15 @@ package ov
 
 import (
 	"encoding/json"
+	"strings"
 
 	"github.com/HewlettPackard/oneview-golang/rest"
 	"github.com/HewlettPackard/oneview-golang/utils"
 	"github.com/docker/machine/libmachine/log"
 )
 
+// ApplianceSshAccess - whether ssh logins to the appliance are allowed, AllowSshAccess is always
+// sent so that access can be turned off
 type ApplianceSshAccess struct {
 	AllowSshAccess bool          `json:"allowSshAccess"`
 	Category       string        `json:"category,omitempty"`
@@ -18,6 +21,7 @@ type ApplianceSshAccess struct {
 	URI            utils.Nstring `json:"uri,omitempty"`
 }
 
+// GetSshAccess gets the appliance ssh access setting
 func (c *OVClient) GetSshAccess() (ApplianceSshAccess, error) {
 	var (
 		uri          = "/rest/appliance/ssh-access"
@@ -38,6 +42,8 @@ func (c *OVClient) GetSshAccess() (ApplianceSshAccess, error) {
 	return getsshaccess, nil
 }
 
+// SetSshAccess puts the ssh access setting to /rest/appliance/ssh-access, waiting on the task
+// when the appliance returns one
 func (c *OVClient) SetSshAccess(sshaccess ApplianceSshAccess) error {
 	log.Infof("Initializing setting of appliance SSH access.")
 	var (
@@ -59,12 +65,16 @@ func (c *OVClient) SetSshAccess(sshaccess ApplianceSshAccess) error {
 		return err
 	}
 
-	log.Infof("Response set timelocalework %s", data)
+	log.Infof("Response set appliance ssh access %s", data)
 	if err := json.Unmarshal(data, &t); err != nil {
 		t.TaskIsDone = true
 		log.Errorf("Error with task un-marshal: %s", err)
 		return err
 	}
+	if !strings.HasPrefix(t.URI.String(), "/rest/tasks/") {
+		// the updated setting was returned rather than a task
+		return nil
+	}
 
 	err = t.Wait()
 	if err != nil {
@@ -2,6 +2,10 @@ package ov
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/HewlettPackard/oneview-golang/rest"
 	"github.com/HewlettPackard/oneview-golang/utils"
@@ -23,8 +27,33 @@ type ApplianceTimeandLocal struct {
 	PollingInterval   string          `json:"pollingInterval,omitempty"`
 }
 
+// Validate checks the time and locale settings locally before they are submitted.
+// The timezone must be a known IANA zone (as accepted by OneView, e.g. "UTC" or
+// "America/New_York") and every NTP server must be an IP address or a well-formed
+// hostname. No network lookups are made.
+func (timelocale ApplianceTimeandLocal) Validate() error {
+	var problems []string
+	if !timelocale.Timezone.IsNil() {
+		if _, err := time.LoadLocation(string(timelocale.Timezone)); err != nil || timelocale.Timezone == "Local" {
+			problems = append(problems, fmt.Sprintf("invalid timezone %q", string(timelocale.Timezone)))
+		}
+	}
+	for _, server := range timelocale.NtpServers {
+		if !utils.IsValidHost(string(server)) {
+			problems = append(problems, fmt.Sprintf("invalid ntp server %q", string(server)))
+		}
+	}
+	if len(problems) > 0 {
+		return errors.New("Error validating time and locale: " + strings.Join(problems, ", "))
+	}
+	return nil
+}
+
 func (c *OVClient) CreateApplianceTimeandLocal(timelocale ApplianceTimeandLocal) error {
 	log.Infof("Initializing creation of time and locale for %s.", timelocale)
+	if err := timelocale.Validate(); err != nil {
+		return err
+	}
 	var (
 		uri = "/rest/appliance/configuration/time-locale"
 		t   = (&Task{}).NewProfileTask(c)
@@ -84,11 +113,7 @@ func (c *OVClient) GetApplianceTimeandLocals(filter string, sort string, start s
 	// refresh login
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	// Setup query
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
 	if err != nil {
 		return timelocalelist, err
 	}
@@ -99,3 +124,20 @@ func (c *OVClient) GetApplianceTimeandLocals(filter string, sort string, start s
 	}
 	return timelocalelist, nil
 }
+
+// TimeLocale time, locale and NTP settings of the appliance
+type TimeLocale = ApplianceTimeandLocal
+
+// GetApplianceTimeAndLocale gets the time, locale and NTP settings of the appliance
+func (c *OVClient) GetApplianceTimeAndLocale() (TimeLocale, error) {
+	return c.GetApplianceTimeandLocals("", "", "", "")
+}
+
+// SetApplianceTimeAndLocale sets the time, locale and NTP settings of the appliance and waits on the task,
+// at least one NTP server is required and every server must be a well-formed host, see Validate
+func (c *OVClient) SetApplianceTimeAndLocale(cfg TimeLocale) error {
+	if len(cfg.NtpServers) == 0 {
+		return errors.New("Error validating time and locale: at least one ntp server is required")
+	}
+	return c.CreateApplianceTimeandLocal(cfg)
+}
@@ -18,9 +18,11 @@ limitations under the License.
 package ov
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/HewlettPackard/oneview-golang/rest"
 	"github.com/docker/machine/libmachine/log"
@@ -39,7 +41,7 @@ func (c *OVClient) GetAuthHeaderMap() map[string]string {
 	return map[string]string{
 		"Content-Type":  "application/json; charset=utf-8",
 		"X-API-Version": strconv.Itoa(c.APIVersion),
-		"auth":          c.APIKey,
+		"auth":          c.SessionID(),
 		"If-Match":      c.IfMatch,
 	}
 }
@@ -48,7 +50,7 @@ func (c *OVClient) GetAuthHeaderMap() map[string]string {
 func (c *OVClient) GetAuthHeaderMapNoVer() map[string]string {
 	return map[string]string{
 		"Content-Type": "application/json; charset=utf-8",
-		"auth":         c.APIKey,
+		"auth":         c.SessionID(),
 	}
 }
 
@@ -70,25 +72,89 @@ type TimeOut struct {
 	IdleTimeout int64 `json:"idleTimeout"`
 }
 
+// SessionExpiryMargin - a session expiring within the margin is checked with the appliance before it is used
+const SessionExpiryMargin = time.Minute
+
+// GetSessionToken returns the session id and when it is expected to expire, to persist the session
+// and restore it with SetSessionToken, the expiry is zero when unknown
+func (c *OVClient) GetSessionToken() (string, time.Time) {
+	return c.SessionID(), c.SessionExpiry()
+}
+
+// SetSessionToken reuses a session saved with GetSessionToken, a zero expiry has the session
+// checked with the appliance on the next call
+func (c *OVClient) SetSessionToken(token string, expiry time.Time) {
+	c.SetSessionID(token)
+	c.SetSessionExpiry(expiry)
+}
+
 // RefreshLogin Refresh login authkey
-// Should make sure we have a valid APIKey
+// Should make sure we have a valid APIKey. The session is reused without a round trip until
+// it nears its expiry or a request is answered with 401 Unauthorized, then it is checked
+// against the appliance and renewed when no longer valid.
 func (c *OVClient) RefreshLogin() error {
-	if c.APIKey == "" || len(strings.TrimSpace(c.APIKey)) == 0 || c.APIKey == "none" {
+	key, expiry := c.GetSessionToken()
+	if len(strings.TrimSpace(key)) == 0 || key == "none" {
 		log.Debugf("Getting new session id")
-		s, err := c.SessionLogin()
-		if err != nil {
-			return err
-		}
-		c.APIKey = s.ID
-	}
-	// check it we are getting 404 Not Found from GetIdleTimeout, this means the Session-ID is no good
-	_, err := c.GetIdleTimeout()
-	if err != nil && strings.Contains(err.Error(), "404 Not Found") {
-		s, err := c.SessionLogin()
-		if err != nil {
-			return err
-		}
-		c.APIKey = s.ID
+		return c.relogin(key)
+	}
+	if time.Until(expiry) > SessionExpiryMargin {
+		return nil
+	}
+	// check it we are getting 404 Not Found or 401 Unauthorized from GetIdleTimeout, this means the Session-ID is no good
+	timeout, err := c.GetIdleTimeout()
+	if rest.IsNotFound(err) || rest.IsUnauthorized(err) {
+		return c.relogin(key)
+	}
+	if err == nil {
+		c.SetSessionExpiry(time.Now().Add(time.Duration(timeout) * time.Millisecond))
+	}
+	return nil
+}
+
+// relogin replaces the stale session id with a new session, unless another
+// goroutine already replaced it while waiting on the login lock
+func (c *OVClient) relogin(stale string) error {
+	c.loginLock.Lock()
+	defer c.loginLock.Unlock()
+	if c.SessionID() != stale {
+		return nil
+	}
+	return c.login()
+}
+
+// Login authenticates with the client credentials and keeps the new session for the following calls,
+// RefreshLogin logs in on its own when there is no valid session
+func (c *OVClient) Login() error {
+	c.loginLock.Lock()
+	defer c.loginLock.Unlock()
+	return c.login()
+}
+
+// Logout ends the session on the appliance to free its session slot, call it when the client is no
+// longer needed. A session the appliance already dropped is not an error, the next call logs in again.
+func (c *OVClient) Logout() error {
+	c.loginLock.Lock()
+	defer c.loginLock.Unlock()
+	if len(strings.TrimSpace(c.SessionID())) == 0 {
+		return nil
+	}
+	err := c.SessionLogout()
+	if rest.IsUnauthorized(err) || rest.IsNotFound(err) {
+		c.SetSessionToken("none", time.Time{})
+		return nil
+	}
+	return err
+}
+
+func (c *OVClient) login() error {
+	s, err := c.SessionLogin()
+	if err != nil {
+		return err
+	}
+	c.SetSessionToken(s.ID, time.Time{})
+	if timeout, err := c.GetIdleTimeout(); err == nil {
+		c.SetSessionExpiry(time.Now().Add(time.Duration(timeout) * time.Millisecond))
 	}
 	return nil
 }
@@ -102,8 +168,7 @@ func (c *OVClient) SessionLogin() (Session, error) {
 		session Session
 	)
 
-	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	data, err := c.RestAPICall(rest.POST, uri, body)
+	data, err := c.RestAPICallWithOptions(context.Background(), rest.POST, uri, body, rest.Options{Headers: c.GetAuthHeaderMap()})
 	if err != nil {
 		return session, err
 	}
@@ -123,17 +188,16 @@ func (c *OVClient) SessionLogout() error {
 		uri = "/rest/login-sessions"
 	)
 	log.Debugf("Calling logout for header -> %+v", c.GetAuthHeaderMap())
-	if c.APIKey == "none" {
+	if c.SessionID() == "none" {
 		log.Debugf("already logged out")
 		return nil
 	}
-	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	_, err := c.RestAPICall(rest.DELETE, uri, nil)
+	_, err := c.RestAPICallWithOptions(context.Background(), rest.DELETE, uri, nil, rest.Options{Headers: c.GetAuthHeaderMap()})
 	if err != nil {
 		log.Debugf("Error from %s :-> %+v", uri, err)
 		return err
 	}
-	c.APIKey = "none"
+	c.SetSessionToken("none", time.Time{})
 	return nil
 }
 
@@ -148,8 +212,7 @@ func (c *OVClient) GetIdleTimeout() (int64, error) {
 	log.Debugf("Calling idel-timeout get for header -> %+v", c.GetAuthHeaderMap())
 	header = c.GetAuthHeaderMap()
 	header["Session-ID"] = header["auth"]
-	c.SetAuthHeaderOptions(header)
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithOptions(context.Background(), rest.GET, uri, nil, rest.Options{Headers: header})
 	if err != nil {
 		return -1, err
 	}
@@ -171,8 +234,7 @@ func (c *OVClient) SetIdleTimeout(thetime int64) error {
 	log.Debugf("Calling idel-timeout POST for header -> %+v", c.GetAuthHeaderMap())
 	header = c.GetAuthHeaderMap()
 	header["Session-ID"] = header["auth"]
-	c.SetAuthHeaderOptions(header)
-	_, err := c.RestAPICall(rest.POST, uri, timeout)
+	_, err := c.RestAPICallWithOptions(context.Background(), rest.POST, uri, timeout, rest.Options{Headers: header})
 	if err != nil {
 		return err
 	}
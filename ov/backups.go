@@ -0,0 +1,230 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/docker/machine/libmachine/log"
+)
+
+// Backup appliance backup, download it with DownloadBackup
+type Backup struct {
+	BackupType      string        `json:"backupType,omitempty"`      // "backupType": "FULL",
+	Category        string        `json:"category,omitempty"`        // "category": "backups",
+	Created         string        `json:"created,omitempty"`         // "created": "2021-03-10T10:20:11.000Z",
+	DownloadUri     utils.Nstring `json:"downloadUri,omitempty"`     // "downloadUri": "/rest/backups/archive/ci-0050568f3f3d_backup_2021-03-10_102011",
+	ETAG            string        `json:"eTag,omitempty"`            // "eTag": "2021-03-10T10:20:11.000Z",
+	Hostname        string        `json:"hostname,omitempty"`        // "hostname": "oneview.example.com",
+	ID              string        `json:"id,omitempty"`              // "id": "ci-0050568f3f3d_backup_2021-03-10_102011",
+	Modified        string        `json:"modified,omitempty"`        // "modified": "2021-03-10T10:20:11.000Z",
+	PercentComplete int           `json:"percentComplete,omitempty"` // "percentComplete": 100,
+	SoftwareVersion string        `json:"softwareVersion,omitempty"` // "softwareVersion": "6.00.00-0426039",
+	Status          string        `json:"status,omitempty"`          // "status": "SUCCEEDED",
+	TaskUri         utils.Nstring `json:"taskUri,omitempty"`         // "taskUri": "/rest/tasks/1",
+	URI             utils.Nstring `json:"uri,omitempty"`             // "uri": "/rest/backups/ci-0050568f3f3d_backup_2021-03-10_102011"
+}
+
+// BackupList list of appliance backups
+type BackupList struct {
+	Total       int           `json:"total,omitempty"`       // "total": 1,
+	Count       int           `json:"count,omitempty"`       // "count": 1,
+	Start       int           `json:"start,omitempty"`       // "start": 0,
+	PrevPageURI utils.Nstring `json:"prevPageUri,omitempty"` // "prevPageUri": null,
+	NextPageURI utils.Nstring `json:"nextPageUri,omitempty"` // "nextPageUri": null,
+	URI         utils.Nstring `json:"uri,omitempty"`         // "uri": "/rest/backups",
+	Members     []Backup      `json:"members,omitempty"`     // "members":[]
+}
+
+// Restore restore of an appliance backup, the appliance is unavailable while it runs
+type Restore struct {
+	Category             string        `json:"category,omitempty"`             // "category": "restores",
+	Created              string        `json:"created,omitempty"`              // "created": "2021-03-10T10:20:11.000Z",
+	ETAG                 string        `json:"eTag,omitempty"`                 // "eTag": "2021-03-10T10:20:11.000Z",
+	ErrorMessage         string        `json:"errorMessage,omitempty"`         // "errorMessage": null,
+	ID                   string        `json:"id,omitempty"`                   // "id": "1",
+	Modified             string        `json:"modified,omitempty"`             // "modified": "2021-03-10T10:20:11.000Z",
+	PercentComplete      int           `json:"percentComplete,omitempty"`      // "percentComplete": 10,
+	ProgressStep         string        `json:"progressStep,omitempty"`         // "progressStep": "RESTORING_DATABASE",
+	Status               string        `json:"status,omitempty"`               // "status": "IN_PROGRESS",
+	Type                 string        `json:"type,omitempty"`                 // "type": "RESTORE",
+	URI                  utils.Nstring `json:"uri,omitempty"`                  // "uri": "/rest/restores/1",
+	UriOfBackupToRestore utils.Nstring `json:"uriOfBackupToRestore,omitempty"` // "uriOfBackupToRestore": "/rest/backups/ci-0050568f3f3d_backup_2021-03-10_102011"
+}
+
+// CreateBackup starts a backup of the appliance, the task is returned without waiting
+func (c *OVClient) CreateBackup() (*Task, error) {
+	var (
+		uri = "/rest/backups"
+		t   *Task
+	)
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	t = t.NewProfileTask(c)
+	t.ResetTask()
+	log.Debugf("REST : %s \n", uri)
+	data, err := c.RestAPICall(rest.POST, uri, nil)
+	if err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error submitting create backup request: %s", err)
+		return t, err
+	}
+
+	log.Debugf("Response create backup %s", data)
+	if err := json.Unmarshal(data, &t); err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with task un-marshal: %s", err)
+		return t, err
+	}
+	return t, nil
+}
+
+// GetBackups gets the backups held on the appliance
+func (c *OVClient) GetBackups() (BackupList, error) {
+	var (
+		uri     = "/rest/backups"
+		backups BackupList
+	)
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	data, err := c.RestAPICall(rest.GET, uri, nil)
+	if err != nil {
+		return backups, err
+	}
+
+	log.Debugf("GetBackups %s", data)
+	if err := json.Unmarshal(data, &backups); err != nil {
+		return backups, err
+	}
+	return backups, nil
+}
+
+// DownloadBackup streams the archive of a backup to w
+func (c *OVClient) DownloadBackup(backupURI utils.Nstring, w io.Writer) error {
+	var (
+		backup Backup
+	)
+	if backupURI.IsNil() {
+		return errors.New("Error downloading backup, no backup uri provided")
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	data, err := c.RestAPICall(rest.GET, backupURI.String(), nil)
+	if err != nil {
+		return err
+	}
+	log.Debugf("DownloadBackup %s", data)
+	if err := json.Unmarshal(data, &backup); err != nil {
+		return err
+	}
+	downloadURI := backup.DownloadUri.String()
+	if downloadURI == "" {
+		downloadURI = "/rest/backups/archive/" + path.Base(backupURI.String())
+	}
+	return c.RestAPIDownload(downloadURI, w)
+}
+
+// GetRestore gets the progress of a restore started by RestoreBackup
+func (c *OVClient) GetRestore(restoreURI utils.Nstring) (Restore, error) {
+	var (
+		restore Restore
+	)
+	if restoreURI.IsNil() {
+		return restore, errors.New("Error getting restore, no restore uri provided")
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	data, err := c.RestAPICall(rest.GET, restoreURI.String(), nil)
+	if err != nil {
+		return restore, err
+	}
+	log.Debugf("GetRestore %s", data)
+	if err := json.Unmarshal(data, &restore); err != nil {
+		return restore, err
+	}
+	return restore, nil
+}
+
+// RestoreBackup uploads a backup archive read from r, waits for the upload and starts
+// restoring the appliance from it. The appliance answers the restore with a restore
+// resource rather than a task, in that case the returned task is marked done and its
+// uri is the restore uri, poll it with GetRestore.
+func (c *OVClient) RestoreBackup(r io.Reader) (*Task, error) {
+	var (
+		t *Task
+	)
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	t = t.NewProfileTask(c)
+	t.ResetTask()
+	log.Debugf("REST : %s \n", "/rest/backups/archive")
+	data, err := c.RestAPIUpload("/rest/backups/archive", "backup.bkp", r)
+	if err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error uploading backup: %s", err)
+		return t, err
+	}
+	log.Debugf("Response upload backup %s", data)
+	if err := json.Unmarshal(data, &t); err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with task un-marshal: %s", err)
+		return t, err
+	}
+	if err := t.Wait(); err != nil {
+		return t, err
+	}
+	backupURI := t.AssociatedRes.ResourceURI
+	if backupURI.IsNil() {
+		return t, errors.New("Error restoring backup, the upload task did not report the backup uri")
+	}
+
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	restore := Restore{Type: "RESTORE", UriOfBackupToRestore: backupURI}
+	t = t.NewProfileTask(c)
+	t.ResetTask()
+	log.Debugf("REST : %s \n %+v\n", "/rest/restores", restore)
+	data, err = c.RestAPICall(rest.POST, "/rest/restores", restore)
+	if err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error submitting restore request: %s", err)
+		return t, err
+	}
+	log.Debugf("Response restore %s", data)
+	if err := json.Unmarshal(data, &t); err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with task un-marshal: %s", err)
+		return t, err
+	}
+	if !strings.HasPrefix(t.URI.String(), "/rest/tasks/") {
+		t.TaskIsDone = true
+	}
+	return t, nil
+}
@@ -0,0 +1,179 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/docker/machine/libmachine/log"
+)
+
+// TrustedCertificate CA certificate trusted by the appliance
+type TrustedCertificate struct {
+	Category           string            `json:"category,omitempty"`           // "category": "certificates",
+	CertificateDetails CertificateDetail `json:"certificateDetails,omitempty"` // "certificateDetails": {},
+	Created            string            `json:"created,omitempty"`            // "created": "2021-03-10T10:20:11.000Z",
+	ETAG               string            `json:"eTag,omitempty"`               // "eTag": "2021-03-10T10:20:11.000Z",
+	Modified           string            `json:"modified,omitempty"`           // "modified": "2021-03-10T10:20:11.000Z",
+	Type               string            `json:"type,omitempty"`               // "type": "CertificateAuthorityInfo",
+	URI                utils.Nstring     `json:"uri,omitempty"`                // "uri": "/rest/certificates/ca/ExampleRootCA"
+}
+
+// CertStore CA certificates trusted by the appliance
+type CertStore struct {
+	Total       int                  `json:"total,omitempty"`       // "total": 1,
+	Count       int                  `json:"count,omitempty"`       // "count": 1,
+	Start       int                  `json:"start,omitempty"`       // "start": 0,
+	PrevPageURI utils.Nstring        `json:"prevPageUri,omitempty"` // "prevPageUri": null,
+	NextPageURI utils.Nstring        `json:"nextPageUri,omitempty"` // "nextPageUri": null,
+	Type        string               `json:"type,omitempty"`        // "type": "CertificateAuthorityInfoCollection",
+	URI         utils.Nstring        `json:"uri,omitempty"`         // "uri": "/rest/certificates/ca",
+	Members     []TrustedCertificate `json:"members,omitempty"`     // "members":[]
+}
+
+// GetTrustedCertificates gets the CA certificates trusted by the appliance
+func (c *OVClient) GetTrustedCertificates() (CertStore, error) {
+	var (
+		store CertStore
+	)
+	err := c.Iterate("/rest/certificates/ca", nil, func(raw json.RawMessage) error {
+		var cert TrustedCertificate
+		if err := json.Unmarshal(raw, &cert); err != nil {
+			return err
+		}
+		store.Members = append(store.Members, cert)
+		return nil
+	})
+	if err != nil {
+		return store, err
+	}
+	store.Total = len(store.Members)
+	store.Count = len(store.Members)
+	return store, nil
+}
+
+// trustedCertificatesFromPEM builds a store member for every certificate of a PEM bundle,
+// aliased by the common name of its subject
+func trustedCertificatesFromPEM(certPEM string) ([]TrustedCertificate, error) {
+	certs := []TrustedCertificate{}
+	remaining := []byte(certPEM)
+	for {
+		var block *pem.Block
+		block, remaining = pem.Decode(remaining)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return certs, fmt.Errorf("Error parsing certificate %d of the bundle: %s", len(certs)+1, err)
+		}
+		if cert.Subject.CommonName == "" {
+			return certs, fmt.Errorf("Error certificate %d of the bundle has no common name to use as alias", len(certs)+1)
+		}
+		certs = append(certs, TrustedCertificate{
+			CertificateDetails: CertificateDetail{
+				AliasName:  cert.Subject.CommonName,
+				Base64Data: utils.NewNstring(string(pem.EncodeToMemory(block))),
+				Type:       "CertificateDetailV2",
+			},
+			Type: "CertificateAuthorityInfo",
+		})
+	}
+	if len(certs) == 0 {
+		return certs, errors.New("Error no PEM encoded certificate found")
+	}
+	return certs, nil
+}
+
+// AddTrustedCertificate adds every certificate of a PEM bundle to the trusted CA store in one
+// request, each certificate is aliased by its subject common name. The task is returned without waiting.
+func (c *OVClient) AddTrustedCertificate(certPEM string) (*Task, error) {
+	var (
+		uri = "/rest/certificates/ca"
+		t   *Task
+	)
+	certs, err := trustedCertificatesFromPEM(certPEM)
+	if err != nil {
+		return t, err
+	}
+	store := CertStore{Members: certs, Type: "CertificateAuthorityInfoCollection"}
+
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	t = t.NewProfileTask(c)
+	t.ResetTask()
+	log.Debugf("REST : %s \n", uri)
+	data, err := c.RestAPICall(rest.POST, uri, store)
+	if err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error submitting add trusted certificate request: %s", err)
+		return t, err
+	}
+
+	log.Debugf("Response add trusted certificate %s", data)
+	if err := json.Unmarshal(data, &t); err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with task un-marshal: %s", err)
+		return t, err
+	}
+	return t, nil
+}
+
+// RemoveTrustedCertificate removes a CA certificate from the trusted store by alias name.
+// The task is returned without waiting.
+func (c *OVClient) RemoveTrustedCertificate(aliasName string) (*Task, error) {
+	var (
+		uri = "/rest/certificates/ca/" + aliasName
+		t   *Task
+	)
+	if aliasName == "" {
+		return t, errors.New("Error removing trusted certificate, no alias name provided")
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	t = t.NewProfileTask(c)
+	t.ResetTask()
+	log.Debugf("REST : %s \n", uri)
+	data, err := c.RestAPICall(rest.DELETE, uri, nil)
+	if err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error submitting remove trusted certificate request: %s", err)
+		return t, err
+	}
+
+	log.Debugf("Response remove trusted certificate %s", data)
+	if err := json.Unmarshal(data, &t); err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with task un-marshal: %s", err)
+		return t, err
+	}
+	return t, nil
+}
@@ -0,0 +1,153 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/HewlettPackard/oneview-golang/utils"
+)
+
+// connection function types accepted by ConnectionBuilder.WithFunctionType
+const (
+	FUNCTION_TYPE_ETHERNET      = "Ethernet"
+	FUNCTION_TYPE_FIBRE_CHANNEL = "FibreChannel"
+)
+
+// connection boot priorities accepted by ConnectionBuilder.WithBootPriority
+const (
+	BOOT_PRIORITY_PRIMARY      = "Primary"
+	BOOT_PRIORITY_SECONDARY    = "Secondary"
+	BOOT_PRIORITY_NOT_BOOTABLE = "NotBootable"
+)
+
+// networkFunctionTypes maps the network uri prefixes to the function type of connections using them
+var networkFunctionTypes = map[string]string{
+	"/rest/ethernet-networks/": FUNCTION_TYPE_ETHERNET,
+	"/rest/network-sets/":      FUNCTION_TYPE_ETHERNET,
+	"/rest/fc-networks/":       FUNCTION_TYPE_FIBRE_CHANNEL,
+	"/rest/fcoe-networks/":     FUNCTION_TYPE_FIBRE_CHANNEL,
+}
+
+// ConnectionBuilder assembles a profile connection, every setter returns the builder so calls can be
+// chained and Build reports every invalid setting at once
+type ConnectionBuilder struct {
+	conn     Connection
+	priority string
+	problems []string
+}
+
+// NewConnectionBuilder starts a connection with the given id and name, an id of 0 lets OneView assign one
+func NewConnectionBuilder(id int, name string) *ConnectionBuilder {
+	b := &ConnectionBuilder{}
+	b.conn.ID = id
+	b.conn.Name = name
+	return b
+}
+
+// WithNetwork sets the ethernet network, fc network, fcoe network or network set of the connection
+func (b *ConnectionBuilder) WithNetwork(uri utils.Nstring) *ConnectionBuilder {
+	if networkFunctionType(uri) == "" {
+		b.problems = append(b.problems, fmt.Sprintf("%q is not an ethernet, fc, fcoe network or network set uri", uri))
+	}
+	b.conn.NetworkURI = uri
+	return b
+}
+
+// WithFunctionType sets the function type, Ethernet or FibreChannel
+func (b *ConnectionBuilder) WithFunctionType(functionType string) *ConnectionBuilder {
+	if functionType != FUNCTION_TYPE_ETHERNET && functionType != FUNCTION_TYPE_FIBRE_CHANNEL {
+		b.problems = append(b.problems, fmt.Sprintf("function type %q is not valid, valid types are Ethernet and FibreChannel", functionType))
+	}
+	b.conn.FunctionType = functionType
+	return b
+}
+
+// WithPortID sets the port used by the connection, for example "Mezz 3:1-a", "Auto" or "None"
+func (b *ConnectionBuilder) WithPortID(portId string) *ConnectionBuilder {
+	b.conn.PortID = portId
+	return b
+}
+
+// WithBootPriority sets the boot priority, Primary, Secondary or NotBootable
+func (b *ConnectionBuilder) WithBootPriority(priority string) *ConnectionBuilder {
+	switch priority {
+	case BOOT_PRIORITY_PRIMARY, BOOT_PRIORITY_SECONDARY, BOOT_PRIORITY_NOT_BOOTABLE:
+	default:
+		b.problems = append(b.problems, fmt.Sprintf("boot priority %q is not valid, valid priorities are Primary, Secondary and NotBootable", priority))
+	}
+	b.priority = priority
+	return b
+}
+
+// WithRequestedBandwidth sets the requested bandwidth in mbps
+func (b *ConnectionBuilder) WithRequestedBandwidth(mbps int) *ConnectionBuilder {
+	if mbps <= 0 {
+		b.problems = append(b.problems, fmt.Sprintf("requested bandwidth %d mbps must be positive", mbps))
+	}
+	b.conn.RequestedMbps = strconv.Itoa(mbps)
+	return b
+}
+
+// Build validates the settings and returns the connection. The function type defaults to the kind of the
+// network and must match it when set, and a boot priority other than NotBootable needs a connection to a
+// network, not a network set, on a port other than None.
+func (b *ConnectionBuilder) Build() (Connection, error) {
+	problems := append([]string{}, b.problems...)
+	conn := b.conn
+
+	if conn.NetworkURI.IsNil() {
+		problems = append(problems, "no network provided")
+	}
+	kind := networkFunctionType(conn.NetworkURI)
+	if conn.FunctionType == "" {
+		conn.FunctionType = kind
+	} else if kind != "" && conn.FunctionType != kind {
+		problems = append(problems, fmt.Sprintf("function type %s does not match %s network %s", conn.FunctionType, kind, conn.NetworkURI))
+	}
+
+	if b.priority != "" {
+		if b.priority != BOOT_PRIORITY_NOT_BOOTABLE {
+			if strings.HasPrefix(conn.NetworkURI.String(), "/rest/network-sets/") {
+				problems = append(problems, fmt.Sprintf("boot priority %s is not supported on network set %s", b.priority, conn.NetworkURI))
+			}
+			if conn.PortID == "None" {
+				problems = append(problems, fmt.Sprintf("boot priority %s is not supported on a connection with port None", b.priority))
+			}
+		}
+		conn.Boot = &BootOption{Priority: b.priority}
+	}
+
+	if len(problems) > 0 {
+		return Connection{}, errors.New("Error building connection: " + strings.Join(problems, "; "))
+	}
+	return conn, nil
+}
+
+// networkFunctionType returns the function type of connections to the network, empty when unknown
+func networkFunctionType(uri utils.Nstring) string {
+	for prefix, functionType := range networkFunctionTypes {
+		if strings.HasPrefix(uri.String(), prefix) {
+			return functionType
+		}
+	}
+	return ""
+}
@@ -0,0 +1,191 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/docker/machine/libmachine/log"
+)
+
+// DatacenterItem places a rack in the datacenter, X and Y are in millimeters from the top left corner
+type DatacenterItem struct {
+	ResourceUri utils.Nstring `json:"resourceUri,omitempty"` // "resourceUri": "/rest/racks/Rack-221",
+	Rotation    int           `json:"rotation"`              // "rotation": 0,
+	X           int           `json:"x"`                     // "x": 1000,
+	Y           int           `json:"y"`                     // "y": 1000
+}
+
+// Datacenter physical room holding racks, dimensions are in millimeters
+type Datacenter struct {
+	Category                string           `json:"category,omitempty"`                // "category": "datacenters",
+	Contents                []DatacenterItem `json:"contents"`                          // "contents": [],
+	CoolingCapacity         int              `json:"coolingCapacity,omitempty"`         // "coolingCapacity": 5,
+	CoolingMultiplier       float64          `json:"coolingMultiplier,omitempty"`       // "coolingMultiplier": 1.5,
+	CostPerKilowattHour     float64          `json:"costPerKilowattHour,omitempty"`     // "costPerKilowattHour": 0.1,
+	Created                 string           `json:"created,omitempty"`                 // "created": "2021-03-10T10:20:11.000Z",
+	Currency                string           `json:"currency,omitempty"`                // "currency": "USD",
+	DefaultPowerLineVoltage int              `json:"defaultPowerLineVoltage,omitempty"` // "defaultPowerLineVoltage": 220,
+	DeratingPercentage      float64          `json:"deratingPercentage,omitempty"`      // "deratingPercentage": 20.0,
+	DeratingType            string           `json:"deratingType,omitempty"`            // "deratingType": "NaJp",
+	Depth                   int              `json:"depth,omitempty"`                   // "depth": 5000,
+	ETAG                    string           `json:"eTag,omitempty"`                    // "eTag": "1",
+	ID                      string           `json:"id,omitempty"`                      // "id": "8a1b6b1c-1a7f-4c1e-a4b1-3e1ab0b0a9c1",
+	Modified                string           `json:"modified,omitempty"`                // "modified": "2021-03-10T10:20:11.000Z",
+	Name                    string           `json:"name,omitempty"`                    // "name": "Datacenter 1",
+	State                   string           `json:"state,omitempty"`                   // "state": "Unmanaged",
+	Status                  string           `json:"status,omitempty"`                  // "status": "OK",
+	Type                    string           `json:"type,omitempty"`                    // "type": "DatacenterV2",
+	URI                     utils.Nstring    `json:"uri,omitempty"`                     // "uri": "/rest/datacenters/8a1b6b1c-1a7f-4c1e-a4b1-3e1ab0b0a9c1",
+	Width                   int              `json:"width,omitempty"`                   // "width": 5000
+}
+
+// DatacenterList list of datacenters
+type DatacenterList struct {
+	Total       int           `json:"total,omitempty"`       // "total": 1,
+	Count       int           `json:"count,omitempty"`       // "count": 1,
+	Start       int           `json:"start,omitempty"`       // "start": 0,
+	PrevPageURI utils.Nstring `json:"prevPageUri,omitempty"` // "prevPageUri": null,
+	NextPageURI utils.Nstring `json:"nextPageUri,omitempty"` // "nextPageUri": null,
+	URI         utils.Nstring `json:"uri,omitempty"`         // "uri": "/rest/datacenters?start=0&count=10",
+	Members     []Datacenter  `json:"members,omitempty"`     // "members":[]
+}
+
+// GetDatacenterByName gets a datacenter by name, an empty Datacenter is returned when not found
+func (c *OVClient) GetDatacenterByName(name string) (Datacenter, error) {
+	datacenters, err := c.GetDatacenters(fmt.Sprintf("name matches '%s'", name), "name:asc", "", "")
+	if datacenters.Total > 0 {
+		return datacenters.Members[0], err
+	}
+	return Datacenter{}, err
+}
+
+// GetDatacenters gets the datacenters matching filter
+func (c *OVClient) GetDatacenters(filter string, sort string, start string, count string) (DatacenterList, error) {
+	var (
+		uri         = "/rest/datacenters"
+		q           = make(map[string]interface{})
+		datacenters DatacenterList
+	)
+	if len(filter) > 0 {
+		q["filter"] = filter
+	}
+	if sort != "" {
+		q["sort"] = sort
+	}
+	if start != "" {
+		q["start"] = start
+	}
+	if count != "" {
+		q["count"] = count
+	}
+
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
+	if err != nil {
+		return datacenters, err
+	}
+
+	log.Debugf("GetDatacenters %s", data)
+	if err := json.Unmarshal(data, &datacenters); err != nil {
+		return datacenters, err
+	}
+	return datacenters, nil
+}
+
+// CreateDatacenter creates a datacenter with its racks placed in Contents
+func (c *OVClient) CreateDatacenter(datacenter Datacenter) error {
+	log.Infof("Initializing creation of datacenter %s.", datacenter.Name)
+	var (
+		uri = "/rest/datacenters"
+	)
+	if datacenter.Name == "" {
+		return errors.New("Error creating datacenter, no name provided")
+	}
+	if datacenter.Contents == nil {
+		datacenter.Contents = []DatacenterItem{}
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	log.Debugf("REST : %s \n %+v\n", uri, datacenter)
+	data, err := c.RestAPICall(rest.POST, uri, datacenter)
+	if err != nil {
+		log.Errorf("Error submitting create datacenter request: %s", err)
+		return err
+	}
+	log.Debugf("Response create datacenter %s", data)
+	return nil
+}
+
+// UpdateDatacenter updates a datacenter, Contents replaces the racks placed in it
+func (c *OVClient) UpdateDatacenter(datacenter Datacenter) error {
+	log.Infof("Initializing update of datacenter %s.", datacenter.Name)
+	var (
+		uri = datacenter.URI.String()
+	)
+	if datacenter.URI.IsNil() {
+		return errors.New("Error updating datacenter, datacenter URI is empty")
+	}
+	if datacenter.Contents == nil {
+		datacenter.Contents = []DatacenterItem{}
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	log.Debugf("REST : %s \n %+v\n", uri, datacenter)
+	data, err := c.RestAPICall(rest.PUT, uri, datacenter)
+	if err != nil {
+		log.Errorf("Error submitting update datacenter request: %s", err)
+		return err
+	}
+	log.Debugf("Response update datacenter %s", data)
+	return nil
+}
+
+// DeleteDatacenter deletes a datacenter, a datacenter that does not exist is skipped
+func (c *OVClient) DeleteDatacenter(name string) error {
+	datacenter, err := c.GetDatacenterByName(name)
+	if err != nil {
+		return err
+	}
+	if datacenter.URI.IsNil() {
+		log.Infof("Datacenter could not be found to delete, %s, skipping delete ...", name)
+		return nil
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	log.Debugf("REST : %s \n", datacenter.URI)
+	data, err := c.RestAPICall(rest.DELETE, datacenter.URI.String(), nil)
+	if err != nil {
+		log.Errorf("Error submitting delete datacenter request: %s", err)
+		return err
+	}
+	log.Debugf("Response delete datacenter %s", data)
+	return nil
+}
@@ -0,0 +1,226 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/docker/machine/libmachine/log"
+)
+
+// DeploySteps are the steps DeployServer runs, a nil step uses the default implementation
+type DeploySteps struct {
+	// SelectHardware picks the server hardware for the profile, by default DefaultSelectHardware
+	SelectHardware func(c *OVClient, req DeployRequest, template ServerProfile) (ServerHardware, error)
+	// BuildProfile builds the profile to submit, by default NewProfileFromTemplate
+	// with the identifiers policy of the request applied
+	BuildProfile func(c *OVClient, req DeployRequest, template ServerProfile, hardware ServerHardware) (ServerProfile, error)
+	// CreateProfile submits the profile and waits for it, by default CreateProfileWithRollback
+	CreateProfile func(c *OVClient, p ServerProfile) (ServerProfile, error)
+	// PowerOn powers on the server once the profile is applied, by default ServerHardware.PowerOn
+	PowerOn func(c *OVClient, hardware ServerHardware) error
+}
+
+// DeployRequest describes the server DeployServer provisions. The hardware is the server hardware
+// given by uri, else the server in the bay of the enclosure, else the first free server of the
+// hardware type and enclosure group, which default to those of the template.
+type DeployRequest struct {
+	TemplateName          string
+	ProfileName           string
+	ServerHardwareURI     utils.Nstring
+	EnclosureURI          utils.Nstring
+	EnclosureBay          int
+	ServerHardwareTypeURI utils.Nstring
+	EnclosureGroupURI     utils.Nstring
+	Identifiers           string // IDENTIFIER_VIRTUAL or IDENTIFIER_PHYSICAL, empty keeps the template types
+	PowerOn               bool
+	Steps                 DeploySteps
+}
+
+// AssignedIdentifiers are the identifiers OneView assigned to a deployed profile,
+// the connection identifiers are keyed by connection name
+type AssignedIdentifiers struct {
+	SerialNumber utils.Nstring
+	UUID         utils.Nstring
+	MACs         map[string]utils.Nstring
+	WWPNs        map[string]utils.Nstring
+}
+
+// DeployResult is the outcome of DeployServer
+type DeployResult struct {
+	Profile        ServerProfile
+	ServerHardware ServerHardware
+	Identifiers    AssignedIdentifiers
+}
+
+// GetAssignedIdentifiers returns the serial number, uuid and connection addresses of the profile
+func (s ServerProfile) GetAssignedIdentifiers() AssignedIdentifiers {
+	ids := AssignedIdentifiers{
+		SerialNumber: s.SerialNumber,
+		UUID:         s.UUID,
+		MACs:         make(map[string]utils.Nstring),
+		WWPNs:        make(map[string]utils.Nstring),
+	}
+	for _, conn := range s.ConnectionSettings.Connections {
+		name := conn.Name
+		if name == "" {
+			name = fmt.Sprint(conn.ID)
+		}
+		if !conn.MAC.IsNil() {
+			ids.MACs[name] = conn.MAC
+		}
+		if !conn.WWPN.IsNil() {
+			ids.WWPNs[name] = conn.WWPN
+		}
+	}
+	return ids
+}
+
+// DefaultSelectHardware selects the server hardware of a deploy request, the selected
+// server must not have a profile assigned
+func DefaultSelectHardware(c *OVClient, req DeployRequest, template ServerProfile) (ServerHardware, error) {
+	var hardware ServerHardware
+	switch {
+	case !req.ServerHardwareURI.IsNil():
+		h, err := c.GetServerHardwareByUri(req.ServerHardwareURI)
+		if err != nil {
+			return hardware, err
+		}
+		hardware = h
+	case !req.EnclosureURI.IsNil():
+		if req.EnclosureBay <= 0 {
+			return hardware, errors.New("Error selecting server hardware, an enclosure bay is required with the enclosure")
+		}
+		list, err := c.GetServerHardwareList([]string{fmt.Sprintf("locationUri='%s'", req.EnclosureURI)}, "name:asc", "", "", "")
+		if err != nil {
+			return hardware, err
+		}
+		for _, h := range list.Members {
+			if h.Position == req.EnclosureBay {
+				hardware = h
+				break
+			}
+		}
+		if hardware.URI.IsNil() {
+			return hardware, fmt.Errorf("Error selecting server hardware, no server in bay %d of enclosure %s", req.EnclosureBay, req.EnclosureURI)
+		}
+	default:
+		hardwareType := req.ServerHardwareTypeURI
+		if hardwareType.IsNil() {
+			hardwareType = template.ServerHardwareTypeURI
+		}
+		enclosureGroup := req.EnclosureGroupURI
+		if enclosureGroup.IsNil() {
+			enclosureGroup = template.EnclosureGroupURI
+		}
+		return c.GetAvailableHardware(hardwareType, enclosureGroup)
+	}
+	if !hardware.ServerProfileURI.IsNil() {
+		return hardware, fmt.Errorf("Error selecting server hardware, %s is already assigned to profile %s", hardware.Name, hardware.ServerProfileURI)
+	}
+	return hardware, nil
+}
+
+func defaultBuildProfile(c *OVClient, req DeployRequest, template ServerProfile, hardware ServerHardware) (ServerProfile, error) {
+	p, err := c.NewProfileFromTemplate(req.ProfileName, template, hardware)
+	if err != nil {
+		return p, err
+	}
+	switch req.Identifiers {
+	case "":
+	case IDENTIFIER_VIRTUAL:
+		p.UseVirtualIdentifiers()
+	case IDENTIFIER_PHYSICAL:
+		p.UsePhysicalIdentifiers()
+	default:
+		return p, fmt.Errorf("Error unknown identifiers policy %q, valid policies are Virtual and Physical", req.Identifiers)
+	}
+	if err := c.ValidateIdentifierPools(p); err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+func defaultCreateProfile(c *OVClient, p ServerProfile) (ServerProfile, error) {
+	return c.CreateProfileWithRollback(p)
+}
+
+func defaultPowerOn(c *OVClient, hardware ServerHardware) error {
+	hardware.Client = c
+	return hardware.PowerOn()
+}
+
+// DeployServer provisions a server from a profile template in one call: it selects the hardware,
+// builds the profile with the identifiers policy, creates it and waits for it, optionally powers
+// the server on, and returns the profile with the identifiers OneView assigned. Each step can be
+// replaced through req.Steps.
+func (c *OVClient) DeployServer(req DeployRequest) (DeployResult, error) {
+	var result DeployResult
+	if req.TemplateName == "" || req.ProfileName == "" {
+		return result, errors.New("Error deploying server, a template name and a profile name are required")
+	}
+	steps := req.Steps
+	if steps.SelectHardware == nil {
+		steps.SelectHardware = DefaultSelectHardware
+	}
+	if steps.BuildProfile == nil {
+		steps.BuildProfile = defaultBuildProfile
+	}
+	if steps.CreateProfile == nil {
+		steps.CreateProfile = defaultCreateProfile
+	}
+	if steps.PowerOn == nil {
+		steps.PowerOn = defaultPowerOn
+	}
+
+	template, err := c.GetProfileTemplateByName(req.TemplateName)
+	if err != nil {
+		return result, err
+	}
+	if template.URI.IsNil() {
+		return result, fmt.Errorf("Error deploying server, profile template %s not found", req.TemplateName)
+	}
+
+	hardware, err := steps.SelectHardware(c, req, template)
+	if err != nil {
+		return result, err
+	}
+	result.ServerHardware = hardware
+	log.Infof("Deploying profile %s from template %s on %s", req.ProfileName, req.TemplateName, hardware.Name)
+
+	p, err := steps.BuildProfile(c, req, template, hardware)
+	if err != nil {
+		return result, err
+	}
+	profile, err := steps.CreateProfile(c, p)
+	if err != nil {
+		return result, err
+	}
+	result.Profile = profile
+	result.Identifiers = profile.GetAssignedIdentifiers()
+
+	if req.PowerOn {
+		if err := steps.PowerOn(c, hardware); err != nil {
+			log.Errorf("Unable to power on server %s, Error: %s", hardware.Name, err)
+			return result, err
+		}
+	}
+	return result, nil
+}
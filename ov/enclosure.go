@@ -2,6 +2,7 @@ package ov
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/HewlettPackard/oneview-golang/rest"
@@ -19,16 +20,16 @@ type Enclosure struct {
 	CrossBars                                 []CrossBar            `json:"crossBars,omitempty"`                                 // "crossBars": {},
 	Description                               utils.Nstring         `json:"description,omitempty"`                               // "description": "Enclosure Group 1",
 	DeviceBayCount                            int                   `json:"deviceBayCount,omitempty"`                            // "deviceBayCount": 16,
-	DeviceBays                                []DeviceBayMap        `json:"deviceBays,omitempty`                                 // "deviceBays": [],
+	DeviceBays                                []DeviceBayMap        `json:"deviceBays,omitempty"`                                // "deviceBays": [],
 	DeviceBayWatts                            int                   `json:"deviceBayWatts,omitempty"`                            // "deviceBayWatts": 16,
 	ETAG                                      string                `json:"eTag,omitempty"`                                      // "eTag": "1441036118675/8",
 	EmBays                                    int                   `json:"emBays,omitempty"`                                    // "emBays": 16,
 	EnclosureGroupUri                         utils.Nstring         `json:"enclosureGroupUri,omitempty"`                         // "enclosureGroupUri": "/rest/enclosure-groups/293e8efe-c6b1-4783-bf88-2d35a8e49071",
 	EnclosureModel                            string                `json:"enclosureModel,omitempty"`                            // "enclosureModel": "Enclosure Group 1",
 	EnclosureType                             string                `json:"enclosureType,omitempty"`                             // "enclosureType": "BladeSystem c7000 Enclosure",
-	EnclosureTypeUri                          utils.Nstring         `json:"enclosureTypeUriomitempty"`                           // "enclosureTypeUri": "/rest/enclosure-groups/293e8efe-c6b1-4783-bf88-2d35a8e49071",
+	EnclosureTypeUri                          utils.Nstring         `json:"enclosureTypeUri,omitempty"`                          // "enclosureTypeUri": "/rest/enclosure-groups/293e8efe-c6b1-4783-bf88-2d35a8e49071",
 	FanBayCount                               int                   `json:"fanBayCount,omitempty"`                               // "fanBayCount": 16,
-	FanBays                                   []FanBay              `json:"fanBays,omitempty`                                    // "fanBays": [],
+	FanBays                                   []FanBay              `json:"fanBays,omitempty"`                                   // "fanBays": [],
 	FanAndManagementDevicesWatts              int                   `json:"fanAndManagementDevicesWatts,omitempty"`              // "fanAndManagementDevicesWatts": 16,
 	ForceInstallFirmware                      bool                  `json:"forceInstallFirmware,omitempty"`                      // "forceInstallFirmware": true
 	FrameLinkModuleDomain                     string                `json:"frameLinkModuleDomain,omitempty"`                     // "frameLinkModuleDomain": "",
@@ -114,7 +115,7 @@ type CrossBar struct {
 
 type DeviceBayMap struct {
 	AvailableForFullHeightProfile           bool          `json:"availableForFullHeightProfile"`           // "availableForFullHeightProfile": false,
-	AvailableForFullHeightDoubleWideProfile bool          `json:"availableForHalfHeightDoubleWideProfile"` // "availableForHalfHeightDoubleWideProfile": true,
+	AvailableForFullHeightDoubleWideProfile bool          `json:"availableForFullHeightDoubleWideProfile"` // "availableForFullHeightDoubleWideProfile": true,
 	AvailableForHalfHeightProfile           bool          `json:"availableForHalfHeightProfile"`           // "availableForHalfHeightProfile": true,
 	AvailableForHalfHeightDoubleWideProfile bool          `json:"availableForHalfHeightDoubleWideProfile"` // "availableForHalfHeightDoubleWideProfile": true,
 	BayNumber                               int           `json:"bayNumber"`                               // "bayNumber": 1,
@@ -324,6 +325,72 @@ func (c *OVClient) GetEnclosurebyUri(uri utils.Nstring) (Enclosure, error) {
 	return enclosure, nil
 }
 
+// GetEnclosureByUri gets the enclosure by uri, same as GetEnclosurebyUri
+func (c *OVClient) GetEnclosureByUri(uri utils.Nstring) (Enclosure, error) {
+	return c.GetEnclosurebyUri(uri)
+}
+
+// GetAllEnclosures gets every enclosure matching the filter, following all pages
+func (c *OVClient) GetAllEnclosures(filter string, sort string) (EnclosureList, error) {
+	var (
+		enclosures EnclosureList
+		q          = make(map[string]interface{})
+	)
+	if filter != "" {
+		q["filter"] = filter
+	}
+	if sort != "" {
+		q["sort"] = sort
+	}
+	err := c.Iterate("/rest/enclosures", q, func(raw json.RawMessage) error {
+		var enclosure Enclosure
+		if err := json.Unmarshal(raw, &enclosure); err != nil {
+			return err
+		}
+		enclosures.Members = append(enclosures.Members, enclosure)
+		return nil
+	})
+	if err != nil {
+		return enclosures, err
+	}
+	enclosures.Total = len(enclosures.Members)
+	enclosures.Count = len(enclosures.Members)
+	return enclosures, nil
+}
+
+// RefreshEnclosure asks the appliance to re-sync the enclosure with the hardware and returns the
+// task without waiting on it. OneView takes the refresh as a PUT of the refresh state.
+func (c *OVClient) RefreshEnclosure(e Enclosure) (*Task, error) {
+	var t *Task
+	if e.URI.IsNil() {
+		return nil, errors.New("Error refreshing enclosure, enclosure URI is empty")
+	}
+	uri := e.URI.String() + "/refreshState"
+	body := map[string]string{"refreshState": "RefreshPending"}
+
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	t = t.NewProfileTask(c)
+	t.ResetTask()
+	log.Debugf("REST : %s \n %+v\n", uri, body)
+	data, err := c.RestAPICall(rest.PUT, uri, body)
+	if err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error while refreshing enclosure: %s", err)
+		return t, err
+	}
+
+	log.Debugf("Response of enclosure refresh %s", data)
+	if err := json.Unmarshal([]byte(data), &t); err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with task un-marshal: %s", err)
+		return t, err
+	}
+	return t, nil
+}
+
 func (c *OVClient) GetEnclosures(start string, count string, filter string, sort string, scopeUris string) (EnclosureList, error) {
 	var (
 		uri        = "/rest/enclosures"
@@ -354,12 +421,7 @@ func (c *OVClient) GetEnclosures(start string, count string, filter string, sort
 	// refresh login
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	// Setup query
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
 	if err != nil {
 		return enclosures, err
 	}
@@ -130,12 +130,7 @@ func (c *OVClient) GetEnclosureGroups(start string, count string, filter string,
 	// refresh login
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	// Setup query
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
 	if err != nil {
 		return enclosureGroups, err
 	}
@@ -147,6 +142,34 @@ func (c *OVClient) GetEnclosureGroups(start string, count string, filter string,
 	return enclosureGroups, nil
 }
 
+// GetAllEnclosureGroups gets every enclosure group matching the filter, following all pages
+func (c *OVClient) GetAllEnclosureGroups(filter string, sort string) (EnclosureGroupList, error) {
+	var (
+		enclosureGroups EnclosureGroupList
+		q               = make(map[string]interface{})
+	)
+	if filter != "" {
+		q["filter"] = filter
+	}
+	if sort != "" {
+		q["sort"] = sort
+	}
+	err := c.Iterate("/rest/enclosure-groups", q, func(raw json.RawMessage) error {
+		var enclosureGroup EnclosureGroup
+		if err := json.Unmarshal(raw, &enclosureGroup); err != nil {
+			return err
+		}
+		enclosureGroups.Members = append(enclosureGroups.Members, enclosureGroup)
+		return nil
+	})
+	if err != nil {
+		return enclosureGroups, err
+	}
+	enclosureGroups.Total = len(enclosureGroups.Members)
+	enclosureGroups.Count = len(enclosureGroups.Members)
+	return enclosureGroups, nil
+}
+
 func (c *OVClient) CreateEnclosureGroup(eGroup EnclosureGroup) error {
 	log.Infof("Initializing creation of enclosure group for %s.", eGroup.Name)
 	var (
@@ -158,6 +181,10 @@ func (c *OVClient) CreateEnclosureGroup(eGroup EnclosureGroup) error {
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
 
+	if eGroup.Type == "" {
+		eGroup.Type = DefaultEnclosureGroupType(c.APIVersion)
+	}
+
 	t = t.NewProfileTask(c)
 	t.ResetTask()
 	data, err := c.RestAPICall(rest.POST, uri, eGroup)
@@ -223,6 +250,10 @@ func (c *OVClient) UpdateEnclosureGroup(enclosureGroup EnclosureGroup) error {
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
 
+	if enclosureGroup.Type == "" {
+		enclosureGroup.Type = DefaultEnclosureGroupType(c.APIVersion)
+	}
+
 	t = t.NewProfileTask(c)
 	t.ResetTask()
 	log.Debugf("REST : %s \n %+v\n", uri, enclosureGroup)
@@ -2,10 +2,13 @@ package ov
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/HewlettPackard/oneview-golang/rest"
 	"github.com/HewlettPackard/oneview-golang/utils"
 	"github.com/docker/machine/libmachine/log"
+	"strconv"
+	"strings"
 )
 
 type EthernetNetwork struct {
@@ -57,7 +60,7 @@ type BulkEthernetNetwork struct {
 }
 
 type BulkDelete struct {
-	NetworkUris []utils.Nstring `json:"networkUris",omitempty` // "networkUris": [ "/rest/ethernet-networks/e2f0031b-52bd-4223-9ac1-d91cb519d548", "/rest/ethernet-networks/f2f0031b-52bd-4223-9ac1-d91cb519d549"]
+	NetworkUris []utils.Nstring `json:"networkUris,omitempty"` // "networkUris": [ "/rest/ethernet-networks/e2f0031b-52bd-4223-9ac1-d91cb519d548", "/rest/ethernet-networks/f2f0031b-52bd-4223-9ac1-d91cb519d549"]
 }
 
 func (c *OVClient) GetEthernetNetworkByName(name string) (EthernetNetwork, error) {
@@ -72,6 +75,26 @@ func (c *OVClient) GetEthernetNetworkByName(name string) (EthernetNetwork, error
 	}
 }
 
+// GetEthernetNetworkByUri - get an ethernet network from a uri
+func (c *OVClient) GetEthernetNetworkByUri(uri utils.Nstring) (EthernetNetwork, error) {
+	var (
+		eNet EthernetNetwork
+	)
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	data, err := c.RestAPICall(rest.GET, uri.String(), nil)
+	if err != nil {
+		return eNet, err
+	}
+
+	log.Debugf("GetEthernetNetworkByUri %s", data)
+	if err := json.Unmarshal([]byte(data), &eNet); err != nil {
+		return eNet, err
+	}
+	return eNet, nil
+}
+
 func (c *OVClient) GetEthernetNetworks(start string, count string, filter string, sort string) (EthernetNetworkList, error) {
 	var (
 		uri              = "/rest/ethernet-networks"
@@ -153,6 +176,9 @@ func (c *OVClient) GetAssociatedUplinkGroup(id string) ([]string, error) {
 
 func (c *OVClient) CreateEthernetNetwork(eNet EthernetNetwork) error {
 	log.Infof("Initializing creation of ethernet network for %s.", eNet.Name)
+	if err := c.ValidateResourceScopes(eNet.InitialScopeUris); err != nil {
+		return err
+	}
 	var (
 		uri = "/rest/ethernet-networks"
 		t   *Task
@@ -187,10 +213,36 @@ func (c *OVClient) CreateEthernetNetwork(eNet EthernetNetwork) error {
 	return nil
 }
 
+// ValidateVlanIdRange checks a bulk vlan id range such as "1-500,600,700-710", every vlan id must
+// be between 1 and 4094 and each range must start at or below its end
+func ValidateVlanIdRange(vlanIdRange string) error {
+	if strings.TrimSpace(vlanIdRange) == "" {
+		return errors.New("Error vlan id range is empty")
+	}
+	for _, part := range strings.Split(vlanIdRange, ",") {
+		bounds := strings.SplitN(strings.TrimSpace(part), "-", 2)
+		ids := make([]int, len(bounds))
+		for i, bound := range bounds {
+			id, err := strconv.Atoi(strings.TrimSpace(bound))
+			if err != nil || id < 1 || id > 4094 {
+				return fmt.Errorf("Error vlan id range %q, %q is not a vlan id between 1 and 4094", vlanIdRange, bound)
+			}
+			ids[i] = id
+		}
+		if len(ids) == 2 && ids[0] > ids[1] {
+			return fmt.Errorf("Error vlan id range %q, %q starts after it ends", vlanIdRange, part)
+		}
+	}
+	return nil
+}
+
 func (c *OVClient) CreateBulkEthernetNetwork(eNet BulkEthernetNetwork) error {
 	log.Infof("Initializing creation of bulk ethernet network")
+	if err := ValidateVlanIdRange(eNet.VlanIdRange); err != nil {
+		return err
+	}
 	var (
-		uri = "rest/ethernet-networks/bulk"
+		uri = "/rest/ethernet-networks/bulk"
 		t   *Task
 	)
 	//refresh login
@@ -0,0 +1,120 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/docker/machine/libmachine/log"
+)
+
+// EventDetail name and value pair attached to an event
+type EventDetail struct {
+	EventItemDescription string `json:"eventItemDescription,omitempty"` // "eventItemDescription": "Power supply bay",
+	EventItemName        string `json:"eventItemName,omitempty"`        // "eventItemName": "bay",
+	EventItemValue       string `json:"eventItemValue,omitempty"`       // "eventItemValue": "2",
+	IsThisVarbindData    bool   `json:"isThisVarbindData,omitempty"`    // "isThisVarbindData": false,
+	VarBindOrderIndex    int    `json:"varBindOrderIndex,omitempty"`    // "varBindOrderIndex": -1
+}
+
+// Event event recorded by the appliance, custom events can be posted with CreateEvent
+type Event struct {
+	Category           string        `json:"category,omitempty"`           // "category": "events",
+	Created            string        `json:"created,omitempty"`            // "created": "2021-03-10T10:20:11.000Z",
+	Description        string        `json:"description,omitempty"`        // "description": "Maintenance window started",
+	ETAG               string        `json:"eTag,omitempty"`               // "eTag": "2021-03-10T10:20:11.000Z",
+	EventDetails       []EventDetail `json:"eventDetails,omitempty"`       // "eventDetails": [],
+	EventTypeID        string        `json:"eventTypeID,omitempty"`        // "eventTypeID": "maintenance.started",
+	HealthCategory     string        `json:"healthCategory,omitempty"`     // "healthCategory": "None",
+	Modified           string        `json:"modified,omitempty"`           // "modified": "2021-03-10T10:20:11.000Z",
+	Processed          bool          `json:"processed,omitempty"`          // "processed": true,
+	ResourceUri        utils.Nstring `json:"resourceUri,omitempty"`        // "resourceUri": "/rest/server-hardware/1",
+	RxTime             string        `json:"rxTime,omitempty"`             // "rxTime": "2021-03-10T10:20:11.000Z",
+	ServiceEventSource bool          `json:"serviceEventSource,omitempty"` // "serviceEventSource": false,
+	Severity           string        `json:"severity,omitempty"`           // "severity": "OK",
+	Type               string        `json:"type,omitempty"`               // "type": "EventResourceV3",
+	URI                utils.Nstring `json:"uri,omitempty"`                // "uri": "/rest/events/1",
+	Urgency            string        `json:"urgency,omitempty"`            // "urgency": "None"
+}
+
+// EventList list of events
+type EventList struct {
+	Total       int           `json:"total,omitempty"`       // "total": 1,
+	Count       int           `json:"count,omitempty"`       // "count": 1,
+	Start       int           `json:"start,omitempty"`       // "start": 0,
+	PrevPageURI utils.Nstring `json:"prevPageUri,omitempty"` // "prevPageUri": null,
+	NextPageURI utils.Nstring `json:"nextPageUri,omitempty"` // "nextPageUri": null,
+	URI         utils.Nstring `json:"uri,omitempty"`         // "uri": "/rest/events?start=0&count=10",
+	Members     []Event       `json:"members,omitempty"`     // "members":[]
+}
+
+// GetEvents gets every event matching the filter
+func (c *OVClient) GetEvents(filter string, sort string) (EventList, error) {
+	var (
+		events EventList
+		q      = make(map[string]interface{})
+	)
+	if filter != "" {
+		q["filter"] = filter
+	}
+	if sort != "" {
+		q["sort"] = sort
+	}
+	err := c.Iterate("/rest/events", q, func(raw json.RawMessage) error {
+		var event Event
+		if err := json.Unmarshal(raw, &event); err != nil {
+			return err
+		}
+		events.Members = append(events.Members, event)
+		return nil
+	})
+	if err != nil {
+		return events, err
+	}
+	events.Total = len(events.Members)
+	events.Count = len(events.Members)
+	return events, nil
+}
+
+// CreateEvent posts a custom event, the type defaults to EventResourceV3
+func (c *OVClient) CreateEvent(event Event) error {
+	var (
+		uri = "/rest/events"
+	)
+	if event.Description == "" {
+		return errors.New("Error creating event, no description provided")
+	}
+	if event.Type == "" {
+		event.Type = "EventResourceV3"
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	log.Debugf("REST : %s \n %+v\n", uri, event)
+	data, err := c.RestAPICall(rest.POST, uri, event)
+	if err != nil {
+		log.Errorf("Error submitting create event request: %s", err)
+		return err
+	}
+	log.Debugf("Response create event %s", data)
+	return nil
+}
@@ -41,7 +41,7 @@ type FCNetworkList struct {
 }
 
 type FCNetworkBulkDelete struct {
-	FCNetworkUris []utils.Nstring `json:"networkUris",omitempty` // "networkUris": [ "/rest/ethernet-networks/e2f0031b-52bd-4223-9ac1-d91cb519d548", "/rest/ethernet-networks/f2f0031b-52bd-4223-9ac1-d91cb519d549"]
+	FCNetworkUris []utils.Nstring `json:"networkUris,omitempty"` // "networkUris": [ "/rest/ethernet-networks/e2f0031b-52bd-4223-9ac1-d91cb519d548", "/rest/ethernet-networks/f2f0031b-52bd-4223-9ac1-d91cb519d549"]
 }
 
 func (c *OVClient) GetFCNetworkByName(name string) (FCNetwork, error) {
@@ -38,7 +38,7 @@ type FCoENetworkList struct {
 }
 
 type FCoENetworkBulkDelete struct {
-	FCoENetworkUris []utils.Nstring `json:"networkUris",omitempty` // "networkUris": [ "/rest/fcoe-networks/e2f0031b-52bd-4223-9ac1-d91cb519d548", "/rest/fcoe-networks/f2f0031b-52bd-4223-9ac1-d91cb519d549"]
+	FCoENetworkUris []utils.Nstring `json:"networkUris,omitempty"` // "networkUris": [ "/rest/fcoe-networks/e2f0031b-52bd-4223-9ac1-d91cb519d548", "/rest/fcoe-networks/f2f0031b-52bd-4223-9ac1-d91cb519d549"]
 }
 
 func (c *OVClient) GetFCoENetworkByName(name string) (FCoENetwork, error) {
@@ -93,6 +93,9 @@ func (c *OVClient) GetFCoENetworks(filter string, sort string, start string, cou
 
 func (c *OVClient) CreateFCoENetwork(fcoeNet FCoENetwork) error {
 	log.Infof("Initializing creation of fcoe network for %s.", fcoeNet.Name)
+	if fcoeNet.VlanId < 1 || fcoeNet.VlanId > 4094 {
+		return fmt.Errorf("Error creating fcoe network %s, vlan id %d is not between 1 and 4094", fcoeNet.Name, fcoeNet.VlanId)
+	}
 	var (
 		uri = "/rest/fcoe-networks"
 		t   *Task
@@ -3,6 +3,7 @@ package ov
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/HewlettPackard/oneview-golang/rest"
@@ -66,6 +67,9 @@ type FirmwareDrivers struct {
 	XmlKeyName            string              `json:"xmlKeyName,omitempty"`
 }
 
+// FirmwareDriver - a firmware bundle (SPP) or custom service pack on the appliance
+type FirmwareDriver = FirmwareDrivers
+
 type FirmwareDriversList struct {
 	Category    string            `json:"category,omitempty"`
 	Count       int               `json:"count,omitempty"`
@@ -81,6 +85,9 @@ type FirmwareDriversList struct {
 	Uri         utils.Nstring     `json:"uri,omitempty"`
 }
 
+// FirmwareDriverList - list of firmware bundles
+type FirmwareDriverList = FirmwareDriversList
+
 type CustomServicePack struct {
 	BaselineUri        string          `json:"baselineUri,omitempty"`
 	CustomBaselineName string          `json:"customBaselineName,omitempty"`
@@ -110,11 +117,7 @@ func (c *OVClient) GetFirmwareBaselineList(sort string, start string, count stri
 	// refresh login
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	// Setup query
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
 	if err != nil {
 		return firmware, err
 	}
@@ -189,6 +192,95 @@ func (c *OVClient) GetFirmwareBaselineByNameandVersion(name string) (FirmwareDri
 	return FirmwareDrivers{}, err
 }
 
+// GetFirmwareDrivers gets every firmware bundle matching the filter, following all pages
+func (c *OVClient) GetFirmwareDrivers(filter string, sort string) (FirmwareDriverList, error) {
+	var (
+		firmware FirmwareDriverList
+		q        = make(map[string]interface{})
+	)
+	if filter != "" {
+		q["filter"] = filter
+	}
+	if sort != "" {
+		q["sort"] = sort
+	}
+	err := c.Iterate("/rest/firmware-drivers", q, func(raw json.RawMessage) error {
+		var driver FirmwareDriver
+		if err := json.Unmarshal(raw, &driver); err != nil {
+			return err
+		}
+		firmware.Members = append(firmware.Members, driver)
+		return nil
+	})
+	if err != nil {
+		return firmware, err
+	}
+	firmware.Total = len(firmware.Members)
+	firmware.Count = len(firmware.Members)
+	return firmware, nil
+}
+
+// GetFirmwareDriverByName gets the firmware bundle by name, an empty FirmwareDriver when not found
+func (c *OVClient) GetFirmwareDriverByName(name string) (FirmwareDriver, error) {
+	var driver FirmwareDriver
+	drivers, err := c.GetFirmwareDrivers(fmt.Sprintf("name matches '%s'", name), "name:asc")
+	if err != nil {
+		return driver, err
+	}
+	if drivers.Total > 0 {
+		return drivers.Members[0], nil
+	}
+	return driver, nil
+}
+
+// GetFirmwareBaselineByName gets the firmware baseline whose name or short name, for example
+// "SPP 2023.09", is name. An error is returned when no baseline or more than one matches.
+func (c *OVClient) GetFirmwareBaselineByName(name string) (FirmwareDriver, error) {
+	var matches []FirmwareDriver
+	if name == "" {
+		return FirmwareDriver{}, errors.New("Error getting firmware baseline, no name provided")
+	}
+	err := c.Iterate("/rest/firmware-drivers", nil, func(raw json.RawMessage) error {
+		var baseline FirmwareDriver
+		if err := json.Unmarshal(raw, &baseline); err != nil {
+			return err
+		}
+		if baseline.Name == name || baseline.BaselineShortName == name {
+			matches = append(matches, baseline)
+		}
+		return nil
+	})
+	if err != nil {
+		return FirmwareDriver{}, err
+	}
+	switch len(matches) {
+	case 0:
+		return FirmwareDriver{}, fmt.Errorf("Error firmware baseline %q not found", name)
+	case 1:
+		return matches[0], nil
+	}
+	found := make([]string, len(matches))
+	for i, baseline := range matches {
+		found[i] = fmt.Sprintf("%s version %s (%s)", baseline.Name, baseline.Version, baseline.Uri)
+	}
+	return FirmwareDriver{}, fmt.Errorf("Error firmware baseline %q matches %d baselines: %s", name, len(matches), strings.Join(found, ", "))
+}
+
+// SetFirmwareBaseline resolves the baseline name and sets it as the managed firmware baseline of the
+// profile, the install type defaults to FirmwareAndOSDrivers when not set
+func (c *OVClient) SetFirmwareBaseline(p *ServerProfile, baselineName string) error {
+	baseline, err := c.GetFirmwareBaselineByName(baselineName)
+	if err != nil {
+		return err
+	}
+	p.Firmware.ManageFirmware = true
+	p.Firmware.FirmwareBaselineUri = baseline.Uri
+	if p.Firmware.FirmwareInstallType == "" {
+		p.Firmware.FirmwareInstallType = "FirmwareAndOSDrivers"
+	}
+	return nil
+}
+
 func (c *OVClient) CreateCustomServicePack(sp CustomServicePack, force string) error {
 	var (
 		uri = "/rest/firmware-drivers/"
@@ -201,14 +293,11 @@ func (c *OVClient) CreateCustomServicePack(sp CustomServicePack, force string) e
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
 
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
 	t = t.NewProfileTask(c)
 	t.ResetTask()
 	log.Debugf("task -> %+v", t)
 
-	data, err := c.RestAPICall(rest.POST, uri, sp)
+	data, err := c.RestAPICallWithQuery(rest.POST, uri, q, sp)
 	if err != nil {
 		log.Errorf("Error submitting create firmware baseline request: %s", err)
 		t.TaskIsDone = true
@@ -245,9 +334,6 @@ func (c *OVClient) DeleteFirmwareBaseline(id string, force string) error {
 		if force != "" {
 			q["force"] = force
 		}
-		if len(q) > 0 {
-			c.SetQueryString(q)
-		}
 		t = t.NewProfileTask(c)
 		t.ResetTask()
 		log.Debugf("REST : %s \n %+v\n", firmware.Uri, firmware)
@@ -258,7 +344,7 @@ func (c *OVClient) DeleteFirmwareBaseline(id string, force string) error {
 			t.TaskIsDone = true
 			return err
 		}
-		data, err := c.RestAPICall(rest.DELETE, uri, nil)
+		data, err := c.RestAPICallWithQuery(rest.DELETE, uri, q, nil)
 		if err != nil {
 			log.Errorf("Error submitting delete firmware baseline request: %s", err)
 			t.TaskIsDone = true
@@ -240,12 +240,7 @@ func (c *OVClient) GetHypervisorClusterProfiles(start string, count string, filt
 	// refresh login
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	// Setup query
-	if len(q) > 1 {
-		c.SetQueryString(q)
-	}
-
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
 	if err != nil {
 		return hypervisorClusterProfiles, err
 	}
@@ -416,10 +411,7 @@ func (c *OVClient) DeleteHypervisorClusterProfileSoftDeleteForce(name string, so
 		// refresh login
 		c.RefreshLogin()
 		c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-		// Setup query
-		c.SetQueryString(q)
-
-		data, err := c.RestAPICall(rest.DELETE, uri, nil)
+		data, err := c.RestAPICallWithQuery(rest.DELETE, uri, q, nil)
 		if err != nil {
 			log.Errorf("Error submitting delete hypervisor cluster profile request: %s", err)
 			t.TaskIsDone = true
@@ -98,12 +98,7 @@ func (c *OVClient) GetHypervisorManagers(start string, count string, filter stri
 	// refresh login
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	// Setup query
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
 	if err != nil {
 		return hypervisorManagers, err
 	}
@@ -211,16 +206,11 @@ func (c *OVClient) UpdateHypervisorManager(hypM HypervisorManager, force string)
 	// refresh login
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	// Setup query
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-
 	t = t.NewProfileTask(c)
 	t.ResetTask()
 	log.Debugf("REST : %s \n %+v\n", uri, hypM)
 	log.Debugf("task -> %+v", t)
-	data, err := c.RestAPICall(rest.PUT, uri, hypM)
+	data, err := c.RestAPICallWithQuery(rest.PUT, uri, q, hypM)
 	if err != nil {
 		t.TaskIsDone = true
 		log.Errorf("Error submitting update hypervisor manager request: %s", err)
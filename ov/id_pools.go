@@ -2,12 +2,28 @@ package ov
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/HewlettPackard/oneview-golang/rest"
 	"github.com/HewlettPackard/oneview-golang/utils"
 	"github.com/docker/machine/libmachine/log"
 )
 
+// virtual id pools backing the Virtual MACType, WWNType and SerialNumberType of profiles
+const (
+	ID_POOL_VMAC = "vmac"
+	ID_POOL_VWWN = "vwwn"
+	ID_POOL_VSN  = "vsn"
+)
+
+// IDPoolTypes lists the virtual id pools that can be allocated from and collected to
+var IDPoolTypes = []string{ID_POOL_VMAC, ID_POOL_VWWN, ID_POOL_VSN}
+
+// IDPool - an id pool, same as IdPool
+type IDPool = IdPool
+
 type IdPool struct {
 	AllocatedCount int           `json:"allocatedCount,omitempty"`
 	Category       string        `json:"category,omitempty"`
@@ -45,12 +61,7 @@ func (c *OVClient) GetRangeAvailibility(poolType string, ids []string) (UpdateAl
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
 
-	// Setup query
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
 	if err != nil {
 		return idList, err
 	}
@@ -82,12 +93,7 @@ func (c *OVClient) GetValidateIds(poolType string, ids []string) (UpdateAllocato
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
 
-	// Setup query
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
 	if err != nil {
 		return idList, err
 	}
@@ -344,3 +350,54 @@ func (c *OVClient) UpdateValidateIds(Ids UpdateAllocatorList, poolType string) (
 
 	return response, nil
 }
+
+func validateIDPoolType(poolType string) error {
+	if !containsString(IDPoolTypes, poolType) {
+		return fmt.Errorf("Error unknown id pool type %q, valid types are %s", poolType, strings.Join(IDPoolTypes, ","))
+	}
+	return nil
+}
+
+// GetIDPool gets the vmac, vwwn or vsn pool with its allocated and free counts
+func (c *OVClient) GetIDPool(poolType string) (IDPool, error) {
+	if err := validateIDPoolType(poolType); err != nil {
+		return IDPool{}, err
+	}
+	return c.GetPoolType(poolType)
+}
+
+// AllocateIDs reserves count ids from the vmac, vwwn or vsn pool and returns them, the ids stay
+// allocated until returned with CollectIDs
+func (c *OVClient) AllocateIDs(poolType string, count int) ([]string, error) {
+	if err := validateIDPoolType(poolType); err != nil {
+		return nil, err
+	}
+	if count <= 0 {
+		return nil, fmt.Errorf("Error allocating ids, count must be positive, got %d", count)
+	}
+	allocated, err := c.Allocator(UpdateAllocatorList{Count: count}, poolType)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(allocated.IdList))
+	for i, id := range allocated.IdList {
+		ids[i] = id.String()
+	}
+	return ids, nil
+}
+
+// CollectIDs returns previously allocated ids to the vmac, vwwn or vsn pool
+func (c *OVClient) CollectIDs(poolType string, ids []string) error {
+	if err := validateIDPoolType(poolType); err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return errors.New("Error collecting ids, no ids provided")
+	}
+	collect := UpdateCollectorList{}
+	for _, id := range ids {
+		collect.IdList = append(collect.IdList, utils.NewNstring(id))
+	}
+	_, err := c.Collector(collect, poolType)
+	return err
+}
@@ -2,10 +2,12 @@ package ov
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/HewlettPackard/oneview-golang/rest"
 	"github.com/HewlettPackard/oneview-golang/utils"
 	"github.com/docker/machine/libmachine/log"
+	"net/url"
 )
 
 type Interconnect struct {
@@ -224,12 +226,7 @@ func (c *OVClient) GetInterconnects(start string, count string, filter string, s
 	// refresh login
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	// Setup query
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
 	if err != nil {
 		return interconnects, err
 	}
@@ -270,3 +267,97 @@ func (c *OVClient) GetInterconnectByUri(uri utils.Nstring) (Interconnect, error)
 	}
 	return interconnect, nil
 }
+
+// ResetInterconnectPortProtection clears the port protection (pause flood and port monitor) tripped on
+// the ports of an interconnect. The task is returned without waiting.
+func (c *OVClient) ResetInterconnectPortProtection(uri utils.Nstring) (*Task, error) {
+	var (
+		t *Task
+	)
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	t = t.NewProfileTask(c)
+	t.ResetTask()
+	log.Debugf("REST : %s \n", uri.String()+"/resetportprotection")
+	data, err := c.RestAPICall(rest.PUT, uri.String()+"/resetportprotection", nil)
+	if err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error submitting reset port protection request: %s", err)
+		return t, err
+	}
+
+	log.Debugf("Response reset port protection %s", data)
+	if err := json.Unmarshal([]byte(data), &t); err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with task un-marshal: %s", err)
+		return t, err
+	}
+	return t, nil
+}
+
+// PortStatistics statistics of an interconnect port
+type PortStatistics struct {
+	CommonStatistics CommonPortStatistics `json:"commonStatistics,omitempty"`
+	PortName         string               `json:"portName,omitempty"`   // "portName": "Q1",
+	PortStatus       string               `json:"portStatus,omitempty"` // "portStatus": "Linked",
+	PortType         string               `json:"portType,omitempty"`   // "portType": "Uplink",
+}
+
+// GetInterconnectStatistics gets the statistics of a port of an interconnect
+func (c *OVClient) GetInterconnectStatistics(interconnectURI utils.Nstring, portName string) (PortStatistics, error) {
+	var (
+		stats PortStatistics
+	)
+	if interconnectURI.IsNil() {
+		return stats, errors.New("Error getting interconnect statistics, no interconnect uri provided")
+	}
+	if portName == "" {
+		return stats, errors.New("Error getting interconnect statistics, no port name provided")
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	data, err := c.RestAPICall(rest.GET, interconnectURI.String()+"/statistics/"+url.PathEscape(portName), nil)
+	if err != nil {
+		return stats, err
+	}
+	log.Debugf("GetInterconnectStatistics %s", data)
+	if err := json.Unmarshal([]byte(data), &stats); err != nil {
+		return stats, err
+	}
+	return stats, nil
+}
+
+// UpdateInterconnectPort updates a port of an interconnect, setting Enabled to false and back
+// to true resets the port. The task is returned without waiting.
+func (c *OVClient) UpdateInterconnectPort(interconnectURI utils.Nstring, port Port) (*Task, error) {
+	var (
+		t *Task
+	)
+	if interconnectURI.IsNil() {
+		return t, errors.New("Error updating interconnect port, no interconnect uri provided")
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	t = t.NewProfileTask(c)
+	t.ResetTask()
+	log.Debugf("REST : %s \n %+v\n", interconnectURI.String()+"/ports", port)
+	data, err := c.RestAPICall(rest.PUT, interconnectURI.String()+"/ports", port)
+	if err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error submitting update interconnect port request: %s", err)
+		return t, err
+	}
+
+	log.Debugf("Response update interconnect port %s", data)
+	if err := json.Unmarshal([]byte(data), &t); err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with task un-marshal: %s", err)
+		return t, err
+	}
+	return t, nil
+}
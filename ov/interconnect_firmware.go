@@ -0,0 +1,97 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/HewlettPackard/oneview-golang/utils"
+)
+
+// FirmwareComponentCompliance installed and baseline firmware of one interconnect component
+type FirmwareComponentCompliance struct {
+	DeviceType     string
+	InstalledFw    string
+	DesiredFw      string
+	Compliant      bool
+	UpdateFlagDesc string
+}
+
+// FirmwareComplianceStatus firmware of an interconnect compared with the baseline of its logical interconnect
+type FirmwareComplianceStatus struct {
+	InterconnectName       string
+	InterconnectUri        utils.Nstring
+	LogicalInterconnectUri utils.Nstring
+	InstalledFw            string // firmware version reported by the interconnect
+	SppName                string // baseline of the logical interconnect
+	SppUri                 utils.Nstring
+	Compliant              bool
+	Components             []FirmwareComponentCompliance
+}
+
+// CompareInterconnectFirmware compares the firmware of the interconnect with the logical interconnect
+// firmware. The interconnect is compliant when the logical interconnect has a baseline and every
+// component of the interconnect in it has its desired firmware installed, it is not compliant when
+// there is no baseline or no component of the interconnect to compare.
+func CompareInterconnectFirmware(interconnect Interconnect, firmware Firmware) FirmwareComplianceStatus {
+	status := FirmwareComplianceStatus{
+		InterconnectName:       interconnect.Name,
+		InterconnectUri:        interconnect.URI,
+		LogicalInterconnectUri: interconnect.LogicalInterconnectUri,
+		InstalledFw:            interconnect.FirmwareVersion,
+		SppName:                firmware.SppName,
+		SppUri:                 firmware.SppUri,
+	}
+	compliant := true
+	for _, fw := range firmware.Interconnects {
+		if fw.InterconnectUri != interconnect.URI.String() {
+			continue
+		}
+		component := FirmwareComponentCompliance{
+			DeviceType:     fw.DeviceType,
+			InstalledFw:    fw.InstalledFw,
+			DesiredFw:      fw.DesiredFw,
+			Compliant:      fw.DesiredFw == "" || fw.InstalledFw == fw.DesiredFw,
+			UpdateFlagDesc: fw.UpdateFlagDesc,
+		}
+		compliant = compliant && component.Compliant
+		status.Components = append(status.Components, component)
+	}
+	status.Compliant = compliant && !firmware.SppUri.IsNil() && len(status.Components) > 0
+	return status
+}
+
+// GetInterconnectFirmwareCompliance compares the installed firmware of an interconnect with
+// the firmware baseline of its logical interconnect
+func (c *OVClient) GetInterconnectFirmwareCompliance(interconnectUri string) (FirmwareComplianceStatus, error) {
+	interconnect, err := c.GetInterconnectByUri(utils.NewNstring(interconnectUri))
+	if err != nil {
+		return FirmwareComplianceStatus{}, err
+	}
+	if interconnect.LogicalInterconnectUri.IsNil() {
+		return FirmwareComplianceStatus{}, fmt.Errorf("Error interconnect %s is not part of a logical interconnect", interconnect.Name)
+	}
+
+	id := strings.Replace(interconnect.LogicalInterconnectUri.String(), "/rest/logical-interconnects/", "", -1)
+	firmware, err := c.GetLogicalInterconnectFirmware(id)
+	if err != nil {
+		return FirmwareComplianceStatus{}, err
+	}
+	return CompareInterconnectFirmware(interconnect, firmware), nil
+}
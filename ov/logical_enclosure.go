@@ -2,6 +2,7 @@ package ov
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/HewlettPackard/oneview-golang/rest"
@@ -152,12 +153,7 @@ func (c *OVClient) GetLogicalEnclosures(start string, count string, filter strin
 	// refresh login
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	// Setup query
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
 	if err != nil {
 		return logicalEnclosures, err
 	}
@@ -307,9 +303,6 @@ func (c *OVClient) UpdateLogicalEnclosure(logEn LogicalEnclosure) error {
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
 
-	// reset query
-	c.SetQueryString(make(map[string]interface{}))
-
 	t = t.NewProfileTask(c)
 	t.ResetTask()
 	log.Debugf("REST : %s \n %+v\n", uri, logEn)
@@ -337,18 +330,28 @@ func (c *OVClient) UpdateLogicalEnclosure(logEn LogicalEnclosure) error {
 }
 
 func (c *OVClient) UpdateFromGroupLogicalEnclosure(logEn LogicalEnclosure) error {
+	t, err := c.UpdateLogicalEnclosureFromGroup(logEn)
+	if err != nil {
+		return err
+	}
+	return t.Wait()
+}
+
+// UpdateLogicalEnclosureFromGroup applies the configuration of the enclosure group to the
+// logical enclosure, the task is returned without waiting
+func (c *OVClient) UpdateLogicalEnclosureFromGroup(logEn LogicalEnclosure) (*Task, error) {
 	log.Infof("Initializing updateFromGroup of logical enclosure for %s.", logEn.Name)
 	var (
 		uri = logEn.URI.String() + "/updateFromGroup"
 		t   *Task
 	)
+	if logEn.URI.IsNil() {
+		return t, errors.New("Error updating logical enclosure from group, logical enclosure URI is empty")
+	}
 	// refresh login
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
 
-	// reset query
-	c.SetQueryString(make(map[string]interface{}))
-
 	t = t.NewProfileTask(c)
 	t.ResetTask()
 	log.Debugf("REST : %s \n %+v\n", uri, nil)
@@ -357,33 +360,41 @@ func (c *OVClient) UpdateFromGroupLogicalEnclosure(logEn LogicalEnclosure) error
 	if err != nil {
 		t.TaskIsDone = true
 		log.Errorf("Error submitting updateFromGroup logical enclosure request: %s", err)
-		return err
+		return t, err
 	}
 
 	log.Debugf("Response updateFromGroup LogicalEnclosure %s", data)
 	if err := json.Unmarshal([]byte(data), &t); err != nil {
 		t.TaskIsDone = true
 		log.Errorf("Error with task un-marshal: %s", err)
-		return err
+		return t, err
 	}
+	return t, nil
+}
 
-	err = t.Wait()
+func (c *OVClient) UpdateLogicalEnclosureFirmware(uri string, patchData LogicalEnclosureFirmware) error {
+	t, err := c.ApplyLogicalEnclosureFirmware(LogicalEnclosure{URI: utils.NewNstring(uri)}, patchData)
 	if err != nil {
 		return err
 	}
-
-	return nil
+	return t.Wait()
 }
 
-func (c *OVClient) UpdateLogicalEnclosureFirmware(uri string, patchData LogicalEnclosureFirmware) error {
+// ApplyLogicalEnclosureFirmware updates the firmware of the logical enclosure to the baseline
+// in firmware, the task is returned without waiting
+func (c *OVClient) ApplyLogicalEnclosureFirmware(logEn LogicalEnclosure, firmware LogicalEnclosureFirmware) (*Task, error) {
 	var (
-		t *Task
+		uri = logEn.URI.String()
+		t   *Task
 	)
+	if logEn.URI.IsNil() {
+		return t, errors.New("Error updating logical enclosure firmware, logical enclosure URI is empty")
+	}
 
 	firmwareUpdate := PatchFirmware{
 		Op:    "replace",
 		Path:  "/firmware",
-		Value: &patchData,
+		Value: &firmware,
 	}
 
 	operation := []PatchFirmware{firmwareUpdate}
@@ -398,20 +409,105 @@ func (c *OVClient) UpdateLogicalEnclosureFirmware(uri string, patchData LogicalE
 	if err != nil {
 		t.TaskIsDone = true
 		log.Errorf("Error while doing Patch: %s", err)
-		return err
+		return t, err
 	}
 
 	log.Debugf("Response of Patch %s", data)
 	if err := json.Unmarshal([]byte(data), &t); err != nil {
 		t.TaskIsDone = true
 		log.Errorf("Error with task un-marshal: %s", err)
-		return err
+		return t, err
 	}
+	return t, nil
+}
 
-	err = t.Wait()
+func (c *OVClient) GetLogicalEnclosureByUri(uri utils.Nstring) (LogicalEnclosure, error) {
+	var (
+		logEn LogicalEnclosure
+	)
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	data, err := c.RestAPICall(rest.GET, uri.String(), nil)
 	if err != nil {
-		return err
+		return logEn, err
+	}
+	log.Debugf("GetLogicalEnclosureByUri %s", data)
+	if err := json.Unmarshal([]byte(data), &logEn); err != nil {
+		return logEn, err
 	}
+	return logEn, nil
+}
 
-	return nil
+// SettingDifference a setting whose value on a resource differs from its group
+type SettingDifference struct {
+	Setting string // name of the setting, e.g. powerMode
+	Current string // value on the resource
+	Group   string // value the group would apply
+}
+
+// LogicalEnclosureCompliancePreview what an update from group would change on a logical enclosure
+type LogicalEnclosureCompliancePreview struct {
+	LogicalEnclosureUri               utils.Nstring
+	EnclosureGroupUri                 utils.Nstring
+	Differences                       []SettingDifference
+	NonConsistentLogicalInterconnects []utils.Nstring // logical interconnects that differ from their logical interconnect group
+}
+
+// IsCompliant reports whether an update from group would leave the logical enclosure unchanged
+func (p LogicalEnclosureCompliancePreview) IsCompliant() bool {
+	return len(p.Differences) == 0 && len(p.NonConsistentLogicalInterconnects) == 0
+}
+
+// CompareLogicalEnclosureToGroup lists the settings of the logical enclosure that differ from
+// its enclosure group, settings the group leaves unset are ignored
+func CompareLogicalEnclosureToGroup(logEn LogicalEnclosure, group EnclosureGroup) []SettingDifference {
+	var differences []SettingDifference
+	compare := func(setting string, current string, wanted string) {
+		if wanted != "" && current != wanted {
+			differences = append(differences, SettingDifference{Setting: setting, Current: current, Group: wanted})
+		}
+	}
+	compare("ambientTemperatureMode", logEn.AmbientTemperatureMode, group.AmbientTemperatureMode)
+	compare("powerMode", logEn.PowerMode, group.PowerMode)
+	compare("ipAddressingMode", logEn.IpAddressingMode, group.IpAddressingMode)
+	if group.EnclosureCount > 0 {
+		compare("enclosureCount", fmt.Sprint(len(logEn.EnclosureUris)), fmt.Sprint(group.EnclosureCount))
+	}
+	return differences
+}
+
+// GetLogicalEnclosureCompliancePreview previews what UpdateFromGroupLogicalEnclosure would change,
+// comparing the logical enclosure with its enclosure group and reporting the logical
+// interconnects that are not consistent with their logical interconnect group
+func (c *OVClient) GetLogicalEnclosureCompliancePreview(uri utils.Nstring) (LogicalEnclosureCompliancePreview, error) {
+	var (
+		preview LogicalEnclosureCompliancePreview
+	)
+	logEn, err := c.GetLogicalEnclosureByUri(uri)
+	if err != nil {
+		return preview, err
+	}
+	preview.LogicalEnclosureUri = logEn.URI
+	preview.EnclosureGroupUri = logEn.EnclosureGroupUri
+	if logEn.EnclosureGroupUri.IsNil() {
+		return preview, fmt.Errorf("Error logical enclosure %s has no enclosure group", logEn.Name)
+	}
+
+	group, err := c.GetEnclosureGroupByUri(logEn.EnclosureGroupUri)
+	if err != nil {
+		return preview, err
+	}
+	preview.Differences = CompareLogicalEnclosureToGroup(logEn, group)
+
+	for _, liUri := range logEn.LogicalInterconnectUris {
+		li, err := c.GetLogicalInterconnectByUri(liUri.String())
+		if err != nil {
+			return preview, err
+		}
+		if li.ConsistencyStatus != "" && li.ConsistencyStatus != "CONSISTENT" {
+			preview.NonConsistentLogicalInterconnects = append(preview.NonConsistentLogicalInterconnects, liUri)
+		}
+	}
+	return preview, nil
 }
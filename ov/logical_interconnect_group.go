@@ -2,6 +2,7 @@ package ov
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/HewlettPackard/oneview-golang/rest"
 	"github.com/HewlettPackard/oneview-golang/utils"
@@ -323,6 +324,30 @@ type SnmpConfiguration struct {
 	V3Enabled        *bool             `json:"v3Enabled,omitempty"`        // "v3Enabled": true
 }
 
+// Validate checks the SNMPv3 users of the interconnects, see Snmpv3User.Validate
+func (s *SnmpConfiguration) Validate() error {
+	if s == nil {
+		return nil
+	}
+	for _, user := range s.SnmpUsers {
+		if err := user.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate checks an interconnect SNMPv3 user, a privacy protocol requires an authentication protocol
+func (u Snmpv3User) Validate() error {
+	if u.SnmpV3UserName == "" {
+		return errors.New("Error SNMPv3 user has no user name")
+	}
+	if u.V3PrivacyProtocol != "" && u.V3AuthProtocol == "" {
+		return fmt.Errorf("Error SNMPv3 user %s requires an authentication protocol with privacy protocol %s", u.SnmpV3UserName, u.V3PrivacyProtocol)
+	}
+	return nil
+}
+
 type Snmpv3User struct {
 	SnmpV3UserName    string             `json:"snmpV3UserName,omitempty"`    //"snmpV3UserName":"",
 	UserCredentials   []ExtentedProperty `json:"userCredentials,omitempty"`   //"UserCredentials":"",
@@ -569,11 +594,7 @@ func (c *OVClient) GetLogicalInterconnectGroups(count int, filter string, scopeU
 	// refresh login
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	// Setup query
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
 	if err != nil {
 		return logicalInterconnectGroups, err
 	}
@@ -585,16 +606,51 @@ func (c *OVClient) GetLogicalInterconnectGroups(count int, filter string, scopeU
 	return logicalInterconnectGroups, nil
 }
 
+// GetAllLogicalInterconnectGroups gets every logical interconnect group matching the filter, following all pages
+func (c *OVClient) GetAllLogicalInterconnectGroups(filter string, sort string) (LogicalInterconnectGroupList, error) {
+	var (
+		logicalInterconnectGroups LogicalInterconnectGroupList
+		q                         = make(map[string]interface{})
+	)
+	if filter != "" {
+		q["filter"] = filter
+	}
+	if sort != "" {
+		q["sort"] = sort
+	}
+	err := c.Iterate("/rest/logical-interconnect-groups", q, func(raw json.RawMessage) error {
+		var logicalInterconnectGroup LogicalInterconnectGroup
+		if err := json.Unmarshal(raw, &logicalInterconnectGroup); err != nil {
+			return err
+		}
+		logicalInterconnectGroups.Members = append(logicalInterconnectGroups.Members, logicalInterconnectGroup)
+		return nil
+	})
+	if err != nil {
+		return logicalInterconnectGroups, err
+	}
+	logicalInterconnectGroups.Total = len(logicalInterconnectGroups.Members)
+	logicalInterconnectGroups.Count = len(logicalInterconnectGroups.Members)
+	return logicalInterconnectGroups, nil
+}
+
 func (c *OVClient) CreateLogicalInterconnectGroup(logicalInterconnectGroup LogicalInterconnectGroup) error {
 	log.Infof("Initializing creation of logicalInterconnectGroup for %s.", logicalInterconnectGroup.Name)
 	var (
 		uri = "/rest/logical-interconnect-groups"
 		t   *Task
 	)
+	if err := logicalInterconnectGroup.SnmpConfiguration.Validate(); err != nil {
+		return err
+	}
 	// refresh login
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
 
+	if logicalInterconnectGroup.Type == "" {
+		logicalInterconnectGroup.Type = DefaultLIGType(c.APIVersion)
+	}
+
 	t = t.NewProfileTask(c)
 	t.ResetTask()
 
@@ -675,10 +731,17 @@ func (c *OVClient) UpdateLogicalInterconnectGroup(logicalInterconnectGroup Logic
 		uri = logicalInterconnectGroup.URI.String()
 		t   *Task
 	)
+	if err := logicalInterconnectGroup.SnmpConfiguration.Validate(); err != nil {
+		return err
+	}
 	// refresh login
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
 
+	if logicalInterconnectGroup.Type == "" {
+		logicalInterconnectGroup.Type = DefaultLIGType(c.APIVersion)
+	}
+
 	t = t.NewProfileTask(c)
 	t.ResetTask()
 	log.Debugf("REST : %s \n %+v\n", uri, logicalInterconnectGroup)
@@ -2,6 +2,7 @@ package ov
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/HewlettPackard/oneview-golang/rest"
 	"github.com/HewlettPackard/oneview-golang/utils"
@@ -405,10 +406,7 @@ func (c *OVClient) GetLogicalQosAggregatedConfiguration(Id string, fields string
 	}
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
 	if err != nil {
 		return qosConfiguration, err
 	}
@@ -648,11 +646,7 @@ func (c *OVClient) GetLogicalInterconnectForwardingInformation(filter []string,
 	// refresh login
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	// Setup query
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
 	if err != nil {
 		return interconnectFibData, err
 	}
@@ -749,12 +743,11 @@ func (c *OVClient) UpdateLogicalInterconnectEthernetSettingsForce(ethernetSettin
 	// refresh login
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	c.SetQueryString(q)
 	t = t.NewProfileTask(c)
 	t.ResetTask()
 	log.Infof("REST : %s \n %+v\n", uri, ethernetSetting)
 	log.Infof("task -> %+v", t)
-	data, err := c.RestAPICall(rest.PUT, uri, ethernetSetting)
+	data, err := c.RestAPICallWithQuery(rest.PUT, uri, q, ethernetSetting)
 	if err != nil {
 		t.TaskIsDone = true
 		log.Errorf("Error updating logicalInterConnect EthernetSetting request: %s", err)
@@ -793,12 +786,11 @@ func (c *OVClient) UpdateLogicalInterconnectFirmwareForce(firmware Firmware, Id
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
 	q = make(map[string]interface{})
 	q["force"] = strconv.FormatBool(force)
-	c.SetQueryString(q)
 	t = t.NewProfileTask(c)
 	t.ResetTask()
 	log.Infof("REST : %s \n %+v\n", uri, firmware)
 	log.Infof("task -> %+v", t)
-	data, err := c.RestAPICall(rest.PUT, uri, firmware)
+	data, err := c.RestAPICallWithQuery(rest.PUT, uri, q, firmware)
 	if err != nil {
 		t.TaskIsDone = true
 		log.Errorf("Error updating logicalInterConnect Firmware request: %s", err)
@@ -837,12 +829,11 @@ func (c *OVClient) UpdateLogicalInterconnectInternalNetworksForce(internalNetwor
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
 	q = make(map[string]interface{})
 	q["force"] = strconv.FormatBool(force)
-	c.SetQueryString(q)
 	t = t.NewProfileTask(c)
 	t.ResetTask()
 	log.Infof("REST : %s \n %+v\n", uri, internalNetworks)
 	log.Infof("task -> %+v", t)
-	data, err := c.RestAPICall(rest.PUT, uri, internalNetworks)
+	data, err := c.RestAPICallWithQuery(rest.PUT, uri, q, internalNetworks)
 	if err != nil {
 		t.TaskIsDone = true
 		log.Errorf("Error updating logicalInterConnect InternalNetwork request: %s", err)
@@ -865,38 +856,93 @@ func (c *OVClient) UpdateLogicalInterconnectInternalNetworksForce(internalNetwor
 }
 
 func (c *OVClient) UpdateLogicalInterconnectQosConfigurations(qosConfig QosConfiguration, Id string) error {
+	t, err := c.UpdateLogicalInterconnectQosConfiguration(utils.NewNstring("/rest/logical-interconnects/"+Id), qosConfig)
+	if err != nil {
+		return err
+	}
+
+	err = t.Wait()
+	if err != nil {
+		return err
+	}
+	return nil
+
+}
+
+// GetLogicalInterconnectQosConfiguration gets the active and inactive QoS configurations of a logical interconnect
+func (c *OVClient) GetLogicalInterconnectQosConfiguration(uri utils.Nstring) (QosConfiguration, error) {
 	var (
-		uri = "/rest/logical-interconnects/"
-		t   *Task
+		qosConfiguration QosConfiguration
 	)
-	uri = uri + Id + "/qos-aggregated-configuration"
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	data, err := c.RestAPICall(rest.GET, uri.String()+"/qos-aggregated-configuration", nil)
+	if err != nil {
+		return qosConfiguration, err
+	}
+	log.Debugf("GetLogicalInterconnectQosConfiguration %s", data)
+	if err := json.Unmarshal([]byte(data), &qosConfiguration); err != nil {
+		return qosConfiguration, err
+	}
+	return qosConfiguration, nil
+}
+
+// validateQosTrafficClassifiers checks the 802.1p priorities and bandwidth of the traffic classes
+func validateQosTrafficClassifiers(classifiers []QosTrafficClassifier) error {
+	for _, classifier := range classifiers {
+		name := ""
+		if classifier.QosTrafficClass != nil {
+			name = classifier.QosTrafficClass.ClassName
+			if classifier.QosTrafficClass.MaxBandwidth < 0 || classifier.QosTrafficClass.MaxBandwidth > 100 {
+				return fmt.Errorf("Error traffic class %s max bandwidth %d must be a percentage between 0 and 100", name, classifier.QosTrafficClass.MaxBandwidth)
+			}
+			if p := classifier.QosTrafficClass.EgressDot1pValue; p != nil && (*p < 0 || *p > 7) {
+				return fmt.Errorf("Error traffic class %s egress 802.1p value %d must be between 0 and 7", name, *p)
+			}
+		}
+		if classifier.QosClassificationMapping != nil {
+			for _, p := range classifier.QosClassificationMapping.Dot1pClassMapping {
+				if p < 0 || p > 7 {
+					return fmt.Errorf("Error traffic class %s 802.1p mapping %d must be between 0 and 7", name, p)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// UpdateLogicalInterconnectQosConfiguration updates the active QoS configuration of a logical
+// interconnect, the traffic classifiers are checked before submitting. The task is returned without waiting.
+func (c *OVClient) UpdateLogicalInterconnectQosConfiguration(uri utils.Nstring, qosConfig QosConfiguration) (*Task, error) {
+	var (
+		t *Task
+	)
+	if qosConfig.ActiveQosConfig != nil {
+		if err := validateQosTrafficClassifiers(qosConfig.ActiveQosConfig.QosTrafficClassifiers); err != nil {
+			return t, err
+		}
+	}
 	// refresh login
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
 	t = t.NewProfileTask(c)
 	t.ResetTask()
-	log.Infof("REST : %s \n %+v\n", uri, qosConfig)
+	log.Infof("REST : %s \n %+v\n", uri.String()+"/qos-aggregated-configuration", qosConfig)
 	log.Infof("task -> %+v", t)
-	data, err := c.RestAPICall(rest.PUT, uri, qosConfig)
+	data, err := c.RestAPICall(rest.PUT, uri.String()+"/qos-aggregated-configuration", qosConfig)
 	if err != nil {
 		t.TaskIsDone = true
-		log.Errorf("Error updating logicalInterConnect InternalNetwork request: %s", err)
-		return err
+		log.Errorf("Error updating logicalInterConnect QoS configuration request: %s", err)
+		return t, err
 	}
 
-	log.Debugf("Response update LogicalInterConnect InternalNetwork %s", data)
+	log.Debugf("Response update LogicalInterConnect QoS configuration %s", data)
 	if err := json.Unmarshal([]byte(data), &t); err != nil {
 		t.TaskIsDone = true
 		log.Errorf("Error with task un-marshal: %s", err)
-		return err
+		return t, err
 	}
-
-	err = t.Wait()
-	if err != nil {
-		return err
-	}
-	return nil
-
+	return t, nil
 }
 
 func (c *OVClient) UpdateLogicalInterconnectSNMPConfigurations(snmpConfig SnmpConfiguration, Id string) error {
@@ -1077,11 +1123,7 @@ func (c *OVClient) GetLogicalInterconnects(sort string, start string, count stri
 	// refresh login
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	// Setup query
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
 	if err != nil {
 		return logicalInterconnectList, err
 	}
@@ -1091,3 +1133,97 @@ func (c *OVClient) GetLogicalInterconnects(sort string, start string, count stri
 	}
 	return logicalInterconnectList, nil
 }
+
+// GetLogicalInterconnectByName gets the logical interconnect by name, an empty LogicalInterconnect when not found
+func (c *OVClient) GetLogicalInterconnectByName(name string) (LogicalInterconnect, error) {
+	var (
+		logicalInterconnect LogicalInterconnect
+		found               bool
+	)
+	q := map[string]interface{}{"filter": fmt.Sprintf("name='%s'", name)}
+	err := c.Iterate("/rest/logical-interconnects", q, func(raw json.RawMessage) error {
+		if found {
+			return nil
+		}
+		found = true
+		return json.Unmarshal(raw, &logicalInterconnect)
+	})
+	if err != nil {
+		return LogicalInterconnect{}, err
+	}
+	return logicalInterconnect, nil
+}
+
+// GetLogicalInterconnectCompliance returns the consistency status of the logical interconnect
+// with its logical interconnect group, CONSISTENT or NOT_CONSISTENT, read fresh from the appliance
+func (c *OVClient) GetLogicalInterconnectCompliance(li LogicalInterconnect) (string, error) {
+	if li.URI.IsNil() {
+		return "", errors.New("Error getting logical interconnect compliance, logical interconnect URI is empty")
+	}
+	current, err := c.GetLogicalInterconnectByUri(li.URI.String())
+	if err != nil {
+		return "", err
+	}
+	return current.ConsistencyStatus, nil
+}
+
+// UpdateLogicalInterconnectFromGroup brings the logical interconnect back in line with its logical
+// interconnect group, the "update from group" action, and returns the task without waiting on it
+func (c *OVClient) UpdateLogicalInterconnectFromGroup(li LogicalInterconnect) (*Task, error) {
+	var t *Task
+	if li.URI.IsNil() {
+		return nil, errors.New("Error updating logical interconnect from group, logical interconnect URI is empty")
+	}
+	uri := li.URI.String() + "/compliance"
+
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	t = t.NewProfileTask(c)
+	t.ResetTask()
+	log.Infof("Updating logical interconnect %s from group.", li.Name)
+	data, err := c.RestAPICall(rest.PUT, uri, nil)
+	if err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error updating logicalInterConnectCompliance request: %s", err)
+		return t, err
+	}
+
+	log.Debugf("Response update LogicalInterConnectCompliance %s", data)
+	if err := json.Unmarshal([]byte(data), &t); err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with task un-marshal: %s", err)
+		return t, err
+	}
+	return t, nil
+}
+
+// ResetPortProtectionForLogicalInterconnect clears the port protection on every interconnect of the
+// logical interconnect. A reset is submitted to each interconnect without waiting, letting them run
+// in parallel on the appliance. It returns one task per interconnect rather than a single *Task:
+// OneView has no logical interconnect level reset, only /resetportprotection on each interconnect,
+// so no single task tracks the whole reset. It is done once every returned task is done. When a
+// submit fails the tasks already submitted are returned with the error.
+func (c *OVClient) ResetPortProtectionForLogicalInterconnect(liUri string) ([]*Task, error) {
+	var (
+		tasks []*Task
+	)
+	li, err := c.GetLogicalInterconnectByUri(liUri)
+	if err != nil {
+		return tasks, err
+	}
+	if len(li.Interconnects) == 0 {
+		return tasks, fmt.Errorf("Error resetting port protection, logical interconnect %s has no interconnects", li.Name)
+	}
+	for _, interconnectUri := range li.Interconnects {
+		if interconnectUri.IsNil() {
+			continue
+		}
+		t, err := c.ResetInterconnectPortProtection(interconnectUri)
+		if err != nil {
+			return tasks, err
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, nil
+}
@@ -0,0 +1,269 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/docker/machine/libmachine/log"
+)
+
+// directory protocols accepted in AuthDirectory.AuthProtocol
+const (
+	AUTH_PROTOCOL_AD   = "AD"
+	AUTH_PROTOCOL_LDAP = "LDAP"
+)
+
+// DirectoryServer server of an authentication directory
+type DirectoryServer struct {
+	DirectoryServerCertificateStatus string `json:"directoryServerCertificateStatus,omitempty"` // "directoryServerCertificateStatus": "",
+	DirectoryServerIpAddress         string `json:"directoryServerIpAddress,omitempty"`         // "directoryServerIpAddress": "ad.example.com",
+	DirectoryServerSSLPortNumber     string `json:"directoryServerSSLPortNumber,omitempty"`     // "directoryServerSSLPortNumber": "636",
+	ServerCertificate                string `json:"serverCertificate,omitempty"`                // "serverCertificate": "-----BEGIN CERTIFICATE-----...",
+	Type                             string `json:"type,omitempty"`                             // "type": "LoginDomainDirectoryServerInfoDto"
+}
+
+// DirectoryCredential account used to bind to the directory, only sent on create, update and validate
+type DirectoryCredential struct {
+	Password string `json:"password,omitempty"`
+	UserName string `json:"userName,omitempty"`
+}
+
+// AuthDirectory LDAP or Active Directory login domain
+type AuthDirectory struct {
+	AuthProtocol         string               `json:"authProtocol,omitempty"`         // "authProtocol": "AD",
+	BaseDN               string               `json:"baseDN,omitempty"`               // "baseDN": "dc=example,dc=com",
+	Category             string               `json:"category,omitempty"`             // "category": "logindomains",
+	Created              string               `json:"created,omitempty"`              // "created": "2021-03-10T10:20:11.000Z",
+	Credential           *DirectoryCredential `json:"credential,omitempty"`           // "credential": {},
+	DirectoryBindingType string               `json:"directoryBindingType,omitempty"` // "directoryBindingType": "USER_ACCOUNT",
+	DirectoryServers     []DirectoryServer    `json:"directoryServers,omitempty"`     // "directoryServers": [],
+	ETAG                 string               `json:"eTag,omitempty"`                 // "eTag": "2021-03-10T10:20:11.000Z",
+	Modified             string               `json:"modified,omitempty"`             // "modified": "2021-03-10T10:20:11.000Z",
+	Name                 string               `json:"name,omitempty"`                 // "name": "example.com",
+	OrgUnits             []string             `json:"orgUnits,omitempty"`             // "orgUnits": ["ou=Users"],
+	Type                 string               `json:"type,omitempty"`                 // "type": "LoginDomainConfigVersion600",
+	URI                  utils.Nstring        `json:"uri,omitempty"`                  // "uri": "/rest/logindomains/1",
+	UserNamingAttribute  string               `json:"userNamingAttribute,omitempty"`  // "userNamingAttribute": "UID",
+	UseSsl               bool                 `json:"useSsl,omitempty"`               // "useSsl": true
+}
+
+// AuthDirectoryList list of login domains
+type AuthDirectoryList struct {
+	Total       int             `json:"total,omitempty"`       // "total": 1,
+	Count       int             `json:"count,omitempty"`       // "count": 1,
+	Start       int             `json:"start,omitempty"`       // "start": 0,
+	PrevPageURI utils.Nstring   `json:"prevPageUri,omitempty"` // "prevPageUri": null,
+	NextPageURI utils.Nstring   `json:"nextPageUri,omitempty"` // "nextPageUri": null,
+	URI         utils.Nstring   `json:"uri,omitempty"`         // "uri": "/rest/logindomains?start=0&count=10",
+	Members     []AuthDirectory `json:"members,omitempty"`     // "members":[]
+}
+
+// GroupToRoleMapping grants permissions to the members of a directory group
+type GroupToRoleMapping struct {
+	Credential  *DirectoryCredential `json:"credential,omitempty"`  // "credential": {},
+	EGroup      string               `json:"egroup,omitempty"`      // "egroup": "OneView Admins",
+	LoginDomain string               `json:"loginDomain,omitempty"` // "loginDomain": "example.com",
+	Permissions []Permission         `json:"permissions,omitempty"` // "permissions": [],
+	Type        string               `json:"type,omitempty"`        // "type": "LoginDomainGroupCredentials",
+	URI         utils.Nstring        `json:"uri,omitempty"`         // "uri": "/rest/logindomains/grouptorolemapping/1"
+}
+
+// GroupToRoleMappingList list of group to role mappings
+type GroupToRoleMappingList struct {
+	Total       int                  `json:"total,omitempty"`       // "total": 1,
+	Count       int                  `json:"count,omitempty"`       // "count": 1,
+	Start       int                  `json:"start,omitempty"`       // "start": 0,
+	PrevPageURI utils.Nstring        `json:"prevPageUri,omitempty"` // "prevPageUri": null,
+	NextPageURI utils.Nstring        `json:"nextPageUri,omitempty"` // "nextPageUri": null,
+	URI         utils.Nstring        `json:"uri,omitempty"`         // "uri": "/rest/logindomains/grouptorolemapping",
+	Members     []GroupToRoleMapping `json:"members,omitempty"`     // "members":[]
+}
+
+// validate checks the settings the appliance requires before contacting the directory
+func (dir AuthDirectory) validate() error {
+	if dir.Name == "" {
+		return errors.New("Error login domain has no name")
+	}
+	if dir.AuthProtocol != AUTH_PROTOCOL_AD && dir.AuthProtocol != AUTH_PROTOCOL_LDAP {
+		return fmt.Errorf("Error login domain %s auth protocol %q is not valid, valid protocols are AD and LDAP", dir.Name, dir.AuthProtocol)
+	}
+	if dir.BaseDN == "" {
+		return fmt.Errorf("Error login domain %s has no base DN", dir.Name)
+	}
+	if len(dir.DirectoryServers) == 0 {
+		return fmt.Errorf("Error login domain %s has no directory server", dir.Name)
+	}
+	for _, server := range dir.DirectoryServers {
+		if server.DirectoryServerIpAddress == "" {
+			return fmt.Errorf("Error login domain %s has a directory server without address", dir.Name)
+		}
+	}
+	return nil
+}
+
+// submitLoginDomain sends a login domain request, the task is returned without waiting.
+// When the appliance answers with the login domain rather than a task the task is marked done.
+func (c *OVClient) submitLoginDomain(method rest.Method, uri string, body interface{}, action string) (*Task, error) {
+	var (
+		t *Task
+	)
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	t = t.NewProfileTask(c)
+	t.ResetTask()
+	log.Debugf("REST : %s \n", uri)
+	data, err := c.RestAPICall(method, uri, body)
+	if err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error submitting %s request: %s", action, err)
+		return t, err
+	}
+
+	log.Debugf("Response %s %s", action, data)
+	if len(data) == 0 {
+		t.TaskIsDone = true
+		return t, nil
+	}
+	if err := json.Unmarshal(data, &t); err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with task un-marshal: %s", err)
+		return t, err
+	}
+	if !strings.HasPrefix(t.URI.String(), "/rest/tasks/") {
+		t.TaskIsDone = true
+	}
+	return t, nil
+}
+
+// GetLoginDomains gets the LDAP and Active Directory login domains
+func (c *OVClient) GetLoginDomains() (AuthDirectoryList, error) {
+	var (
+		domains AuthDirectoryList
+	)
+	err := c.Iterate("/rest/logindomains", nil, func(raw json.RawMessage) error {
+		var domain AuthDirectory
+		if err := json.Unmarshal(raw, &domain); err != nil {
+			return err
+		}
+		domains.Members = append(domains.Members, domain)
+		return nil
+	})
+	if err != nil {
+		return domains, err
+	}
+	domains.Total = len(domains.Members)
+	domains.Count = len(domains.Members)
+	return domains, nil
+}
+
+// ValidateDirectory checks the login domain settings locally then asks the appliance to
+// bind to the directory with them, without saving the login domain
+func (c *OVClient) ValidateDirectory(dir AuthDirectory) error {
+	if err := dir.validate(); err != nil {
+		return err
+	}
+	if dir.Credential == nil || dir.Credential.UserName == "" {
+		return fmt.Errorf("Error login domain %s has no credential to bind to the directory", dir.Name)
+	}
+	_, err := c.submitLoginDomain(rest.POST, "/rest/logindomains/validator", dir, "validate login domain")
+	return err
+}
+
+// CreateLoginDomain creates a login domain, the type defaults to LoginDomainConfigVersion600
+func (c *OVClient) CreateLoginDomain(dir AuthDirectory) (*Task, error) {
+	if err := dir.validate(); err != nil {
+		return nil, err
+	}
+	if dir.Type == "" {
+		dir.Type = "LoginDomainConfigVersion600"
+	}
+	return c.submitLoginDomain(rest.POST, "/rest/logindomains", dir, "create login domain")
+}
+
+// UpdateLoginDomain updates a login domain
+func (c *OVClient) UpdateLoginDomain(dir AuthDirectory) (*Task, error) {
+	if dir.URI.IsNil() {
+		return nil, errors.New("Error updating login domain, no uri provided")
+	}
+	if err := dir.validate(); err != nil {
+		return nil, err
+	}
+	return c.submitLoginDomain(rest.PUT, dir.URI.String(), dir, "update login domain")
+}
+
+// DeleteLoginDomain deletes a login domain by name, a login domain that does not exist is skipped
+func (c *OVClient) DeleteLoginDomain(name string) error {
+	domains, err := c.GetLoginDomains()
+	if err != nil {
+		return err
+	}
+	for _, domain := range domains.Members {
+		if domain.Name == name {
+			t, err := c.submitLoginDomain(rest.DELETE, domain.URI.String(), nil, "delete login domain")
+			if err != nil || t.TaskIsDone {
+				return err
+			}
+			return t.Wait()
+		}
+	}
+	log.Infof("Login domain could not be found to delete, %s, skipping delete ...", name)
+	return nil
+}
+
+// GetGroupToRoleMappings gets the group to role mappings of every login domain
+func (c *OVClient) GetGroupToRoleMappings() (GroupToRoleMappingList, error) {
+	var (
+		mappings GroupToRoleMappingList
+	)
+	err := c.Iterate("/rest/logindomains/grouptorolemapping", nil, func(raw json.RawMessage) error {
+		var mapping GroupToRoleMapping
+		if err := json.Unmarshal(raw, &mapping); err != nil {
+			return err
+		}
+		mappings.Members = append(mappings.Members, mapping)
+		return nil
+	})
+	if err != nil {
+		return mappings, err
+	}
+	mappings.Total = len(mappings.Members)
+	mappings.Count = len(mappings.Members)
+	return mappings, nil
+}
+
+// CreateGroupToRoleMapping grants permissions to the members of a directory group
+func (c *OVClient) CreateGroupToRoleMapping(mapping GroupToRoleMapping) (*Task, error) {
+	if mapping.LoginDomain == "" || mapping.EGroup == "" {
+		return nil, errors.New("Error creating group to role mapping, a login domain and group are required")
+	}
+	if len(mapping.Permissions) == 0 {
+		return nil, errors.New("Error creating group to role mapping, at least one permission is required")
+	}
+	if mapping.Type == "" {
+		mapping.Type = "LoginDomainGroupCredentials"
+	}
+	return c.submitLoginDomain(rest.POST, "/rest/logindomains/grouptorolemapping", mapping, "create group to role mapping")
+}
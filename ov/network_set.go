@@ -2,10 +2,12 @@ package ov
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/HewlettPackard/oneview-golang/rest"
 	"github.com/HewlettPackard/oneview-golang/utils"
 	"github.com/docker/machine/libmachine/log"
+	"strings"
 )
 
 type NetworkSet struct {
@@ -80,8 +82,44 @@ func (c *OVClient) GetNetworkSets(filter string, sort string) (NetworkSetList, e
 	return networkSets, nil
 }
 
+// GetNetworkSetsWithoutEthernet gets the network sets without their networkUris, a lighter
+// listing for appliances with large network sets
+func (c *OVClient) GetNetworkSetsWithoutEthernet(filter string, sort string) (NetworkSetList, error) {
+	var (
+		uri         = "/rest/network-sets/withoutEthernet"
+		q           map[string]interface{}
+		networkSets NetworkSetList
+	)
+	q = make(map[string]interface{})
+	if len(filter) > 0 {
+		q["filter"] = filter
+	}
+
+	if sort != "" {
+		q["sort"] = sort
+	}
+
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	data, err := c.RestAPICall(rest.GET, uri, nil, q)
+	if err != nil {
+		return networkSets, err
+	}
+
+	log.Debugf("GetNetworkSetsWithoutEthernet %s", data)
+	if err := json.Unmarshal([]byte(data), &networkSets); err != nil {
+		return networkSets, err
+	}
+	return networkSets, nil
+}
+
 func (c *OVClient) CreateNetworkSet(netSet NetworkSet) error {
 	log.Infof("Initializing creation of network set for %s.", netSet.Name)
+	if err := c.ValidateResourceScopes(netSet.InitialScopeUris); err != nil {
+		return err
+	}
 	var (
 		uri = "/rest/network-sets"
 		t   *Task
@@ -198,3 +236,85 @@ func (c *OVClient) UpdateNetworkSet(netSet NetworkSet) error {
 
 	return nil
 }
+
+// GetNetworkSetByUri - get a network set from a uri
+func (c *OVClient) GetNetworkSetByUri(uri utils.Nstring) (NetworkSet, error) {
+	var (
+		netSet NetworkSet
+	)
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	data, err := c.RestAPICall(rest.GET, uri.String(), nil)
+	if err != nil {
+		return netSet, err
+	}
+
+	log.Debugf("GetNetworkSetByUri %s", data)
+	if err := json.Unmarshal([]byte(data), &netSet); err != nil {
+		return netSet, err
+	}
+	return netSet, nil
+}
+
+// GetNetworkSetAssociatedProfiles - get the server profiles with a connection to the network set.
+// OneView has no associated profiles endpoint for network sets, so every page of
+// server profiles is read and the connections are matched against the network set uri.
+func (c *OVClient) GetNetworkSetAssociatedProfiles(uri utils.Nstring) ([]ServerProfile, error) {
+	var (
+		associated []ServerProfile
+	)
+	if uri.IsNil() {
+		return associated, errors.New("Error getting network set associated profiles, no uri provided")
+	}
+	q := map[string]interface{}{"sort": "name:asc"}
+	err := c.Iterate("/rest/server-profiles", q, func(raw json.RawMessage) error {
+		var profile ServerProfile
+		if err := json.Unmarshal(raw, &profile); err != nil {
+			return err
+		}
+		for _, conn := range profile.ConnectionSettings.Connections {
+			if conn.NetworkURI == uri {
+				associated = append(associated, profile)
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return associated, err
+	}
+	return associated, nil
+}
+
+// SetNetworkSetBandwidth - set the typical and maximum bandwidth (Mbps) on the
+// connection template of the network set, returns the updated connection template
+func (c *OVClient) SetNetworkSetBandwidth(uri utils.Nstring, typical int, max int) (ConnectionTemplate, error) {
+	var (
+		template ConnectionTemplate
+	)
+	if typical <= 0 || max <= 0 {
+		return template, fmt.Errorf("Error setting network set bandwidth, typical (%d) and maximum (%d) bandwidth must be greater than 0", typical, max)
+	}
+	if typical > max {
+		return template, fmt.Errorf("Error setting network set bandwidth, typical bandwidth %d exceeds maximum bandwidth %d", typical, max)
+	}
+
+	netSet, err := c.GetNetworkSetByUri(uri)
+	if err != nil {
+		return template, err
+	}
+	if netSet.ConnectionTemplateUri.IsNil() {
+		return template, fmt.Errorf("Error setting network set bandwidth, network set %s has no connection template", netSet.Name)
+	}
+
+	template, err = c.GetConnectionTemplateByURI(netSet.ConnectionTemplateUri)
+	if err != nil {
+		return template, err
+	}
+	template.Bandwidth.TypicalBandwidth = typical
+	template.Bandwidth.MaximumBandwidth = max
+
+	id := strings.Replace(netSet.ConnectionTemplateUri.String(), "/rest/connection-templates/", "", -1)
+	return c.UpdateConnectionTemplate(id, template)
+}
@@ -0,0 +1,101 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/docker/machine/libmachine/log"
+)
+
+// PageInfo - paging fields common to every OneView collection
+type PageInfo struct {
+	Total       int           `json:"total,omitempty"`       // "total": 1,
+	Count       int           `json:"count,omitempty"`       // "count": 1,
+	Start       int           `json:"start,omitempty"`       // "start": 0,
+	PrevPageURI utils.Nstring `json:"prevPageUri,omitempty"` // "prevPageUri": null,
+	NextPageURI utils.Nstring `json:"nextPageUri,omitempty"` // "nextPageUri": null,
+	URI         utils.Nstring `json:"uri,omitempty"`         // "uri": "/rest/server-profiles?start=0&count=1"
+}
+
+// OVList - a page of any OneView collection, members are left raw for the caller to decode
+type OVList struct {
+	PageInfo
+	Members []json.RawMessage `json:"members,omitempty"` // "members":[]
+}
+
+// splitPageURI splits the nextPageUri or prevPageUri of a list, relative or absolute, into the
+// path and query accepted by RestAPICall. The filter and sort are added when the appliance left
+// them out of the page uri, so every page of a listing uses the same ones.
+func splitPageURI(pageUri utils.Nstring, filter string, sort string) (string, map[string]interface{}, error) {
+	u, err := url.Parse(pageUri.String())
+	if err != nil {
+		return "", nil, fmt.Errorf("Error parsing page uri %s: %s", pageUri, err)
+	}
+	q := make(map[string]interface{})
+	for k, v := range u.Query() {
+		q[k] = v
+	}
+	if _, ok := q["filter"]; !ok && filter != "" {
+		q["filter"] = filter
+	}
+	if _, ok := q["sort"]; !ok && sort != "" {
+		q["sort"] = sort
+	}
+	return u.Path, q, nil
+}
+
+// Iterate walks every page of the collection at uri, following nextPageUri, and calls fn with each
+// member in turn. q is sent with the first page, its filter and sort are kept for the next pages.
+// Iteration stops at the first error returned by fn.
+func (c *OVClient) Iterate(uri string, q map[string]interface{}, fn func(raw json.RawMessage) error) error {
+	filter, _ := q["filter"].(string)
+	sort, _ := q["sort"].(string)
+	path := uri
+	for {
+		// refresh login
+		c.RefreshLogin()
+		c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+		data, err := c.RestAPICall(rest.GET, path, nil, q)
+		if err != nil {
+			return err
+		}
+		log.Debugf("Iterate %s", data)
+
+		var page OVList
+		if err := json.Unmarshal(data, &page); err != nil {
+			return err
+		}
+		for _, raw := range page.Members {
+			if err := fn(raw); err != nil {
+				return err
+			}
+		}
+		if page.NextPageURI.IsNil() || len(page.Members) == 0 {
+			return nil
+		}
+		path, q, err = splitPageURI(page.NextPageURI, filter, sort)
+		if err != nil {
+			return err
+		}
+	}
+}
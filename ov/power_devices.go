@@ -0,0 +1,261 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/docker/machine/libmachine/log"
+)
+
+// PowerDeviceConnectionInfo credentials of the management processor of an iPDU,
+// Force takes the iPDU over from another manager
+type PowerDeviceConnectionInfo struct {
+	Hostname string `json:"hostname,omitempty"` // "hostname": "ipdu.example.com",
+	Username string `json:"username,omitempty"` // "username": "admin",
+	Password string `json:"password,omitempty"` // "password": "secret",
+	Force    bool   `json:"force"`              // "force": false
+}
+
+// PowerConnection connects an outlet or feed of a power device to the resource it powers
+type PowerConnection struct {
+	ConnectionUri    utils.Nstring `json:"connectionUri,omitempty"`    // "connectionUri": "/rest/enclosures/0000000000A66101",
+	DeviceConnection utils.Nstring `json:"deviceConnection,omitempty"` // "deviceConnection": "/rest/enclosures/0000000000A66101/powerSupplies/1",
+	SourceConnection utils.Nstring `json:"sourceConnection,omitempty"` // "sourceConnection": "/rest/power-devices/1/outlets/3"
+}
+
+// PowerDevice power delivery device, an iPDU discovered by the appliance or a device added manually
+type PowerDevice struct {
+	Category         string            `json:"category,omitempty"`         // "category": "power-devices",
+	Created          string            `json:"created,omitempty"`          // "created": "2021-03-10T10:20:11.000Z",
+	DeviceType       string            `json:"deviceType,omitempty"`       // "deviceType": "HPIpduCore",
+	ETAG             string            `json:"eTag,omitempty"`             // "eTag": "1",
+	FeedIdentifier   string            `json:"feedIdentifier,omitempty"`   // "feedIdentifier": "A",
+	FirmwareVersion  string            `json:"firmwareVersion,omitempty"`  // "firmwareVersion": "2.0.0.14",
+	ID               string            `json:"id,omitempty"`               // "id": "1",
+	LineVoltage      int               `json:"lineVoltage,omitempty"`      // "lineVoltage": 220,
+	Model            string            `json:"model,omitempty"`            // "model": "HP Intelligent Modular PDU",
+	Modified         string            `json:"modified,omitempty"`         // "modified": "2021-03-10T10:20:11.000Z",
+	Name             string            `json:"name,omitempty"`             // "name": "ipdu-1",
+	PartNumber       string            `json:"partNumber,omitempty"`       // "partNumber": "AF522A",
+	PhaseType        string            `json:"phaseType,omitempty"`        // "phaseType": "SinglePhase",
+	PowerConnections []PowerConnection `json:"powerConnections,omitempty"` // "powerConnections": [],
+	RatedCapacity    int               `json:"ratedCapacity,omitempty"`    // "ratedCapacity": 5000,
+	RefreshState     string            `json:"refreshState,omitempty"`     // "refreshState": "NotRefreshing",
+	SerialNumber     string            `json:"serialNumber,omitempty"`     // "serialNumber": "2S12345678",
+	State            string            `json:"state,omitempty"`            // "state": "Monitored",
+	Status           string            `json:"status,omitempty"`           // "status": "OK",
+	Type             string            `json:"type,omitempty"`             // "type": "PowerDeliveryDeviceV2",
+	URI              utils.Nstring     `json:"uri,omitempty"`              // "uri": "/rest/power-devices/1"
+}
+
+// PowerDeviceList list of power devices
+type PowerDeviceList struct {
+	Total       int           `json:"total,omitempty"`       // "total": 1,
+	Count       int           `json:"count,omitempty"`       // "count": 1,
+	Start       int           `json:"start,omitempty"`       // "start": 0,
+	PrevPageURI utils.Nstring `json:"prevPageUri,omitempty"` // "prevPageUri": null,
+	NextPageURI utils.Nstring `json:"nextPageUri,omitempty"` // "nextPageUri": null,
+	URI         utils.Nstring `json:"uri,omitempty"`         // "uri": "/rest/power-devices?start=0&count=10",
+	Members     []PowerDevice `json:"members,omitempty"`     // "members":[]
+}
+
+// PowerDeviceUtilization utilization metrics of a power device, such as AveragePower and PeakPower
+type PowerDeviceUtilization = ServerHardwareUtilization
+
+// GetPowerDevices gets every power device
+func (c *OVClient) GetPowerDevices(filter string, sort string) (PowerDeviceList, error) {
+	var (
+		powerDevices PowerDeviceList
+		q            = make(map[string]interface{})
+	)
+	if filter != "" {
+		q["filter"] = filter
+	}
+	if sort != "" {
+		q["sort"] = sort
+	}
+	err := c.Iterate("/rest/power-devices", q, func(raw json.RawMessage) error {
+		var powerDevice PowerDevice
+		if err := json.Unmarshal(raw, &powerDevice); err != nil {
+			return err
+		}
+		powerDevices.Members = append(powerDevices.Members, powerDevice)
+		return nil
+	})
+	if err != nil {
+		return powerDevices, err
+	}
+	powerDevices.Total = len(powerDevices.Members)
+	powerDevices.Count = len(powerDevices.Members)
+	return powerDevices, nil
+}
+
+// GetPowerDeviceByName gets a power device by name, an empty PowerDevice is returned when not found
+func (c *OVClient) GetPowerDeviceByName(name string) (PowerDevice, error) {
+	var (
+		powerDevice PowerDevice
+	)
+	powerDevices, err := c.GetPowerDevices(fmt.Sprintf("name='%s'", name), "name:asc")
+	if powerDevices.Total > 0 {
+		return powerDevices.Members[0], err
+	}
+	return powerDevice, err
+}
+
+// AddPowerDevice discovers an iPDU and the power devices it manages,
+// the task is returned without waiting
+func (c *OVClient) AddPowerDevice(connectionInfo PowerDeviceConnectionInfo) (*Task, error) {
+	var (
+		uri = "/rest/power-devices/discover"
+		t   *Task
+	)
+	if connectionInfo.Hostname == "" {
+		return t, errors.New("Error adding power device, no hostname provided")
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	t = t.NewProfileTask(c)
+	t.ResetTask()
+	log.Debugf("REST : %s \n", uri)
+	data, err := c.RestAPICall(rest.POST, uri, connectionInfo)
+	if err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error submitting add power device request: %s", err)
+		return t, err
+	}
+
+	log.Debugf("Response add power device %s", data)
+	if err := json.Unmarshal([]byte(data), &t); err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with task un-marshal: %s", err)
+		return t, err
+	}
+	return t, nil
+}
+
+// DiscoverPowerDevice requests the iPDU to be discovered again, refreshing its outlets and
+// power connections, the task is returned without waiting
+func (c *OVClient) DiscoverPowerDevice(powerDevice PowerDevice) (*Task, error) {
+	var (
+		t *Task
+	)
+	if powerDevice.URI.IsNil() {
+		return t, errors.New("Error discovering power device, no uri provided")
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	t = t.NewProfileTask(c)
+	t.ResetTask()
+	uri := powerDevice.URI.String() + "/refreshState"
+	log.Debugf("REST : %s \n", uri)
+	data, err := c.RestAPICall(rest.PUT, uri, map[string]string{"refreshState": "RefreshPending"})
+	if err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error submitting discover power device request: %s", err)
+		return t, err
+	}
+
+	log.Debugf("Response discover power device %s", data)
+	if err := json.Unmarshal([]byte(data), &t); err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with task un-marshal: %s", err)
+		return t, err
+	}
+	return t, nil
+}
+
+// RemovePowerDevice removes a power device, a power device that does not exist is skipped
+func (c *OVClient) RemovePowerDevice(name string) error {
+	var (
+		t *Task
+	)
+	powerDevice, err := c.GetPowerDeviceByName(name)
+	if err != nil {
+		return err
+	}
+	if powerDevice.URI.IsNil() {
+		log.Infof("Power device could not be found to remove, %s, skipping remove ...", name)
+		return nil
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	t = t.NewProfileTask(c)
+	t.ResetTask()
+	log.Debugf("REST : %s \n", powerDevice.URI)
+	data, err := c.RestAPICall(rest.DELETE, powerDevice.URI.String(), nil)
+	if err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error submitting remove power device request: %s", err)
+		return err
+	}
+
+	log.Debugf("Response remove power device %s", data)
+	if len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, &t); err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with task un-marshal: %s", err)
+		return err
+	}
+	if !strings.HasPrefix(t.URI.String(), "/rest/tasks/") {
+		return nil
+	}
+	return t.Wait()
+}
+
+// GetPowerDeviceUtilization gets the power drawn through a power device, fields selects the
+// metrics such as AveragePower or PeakPower, all metrics when empty
+func (c *OVClient) GetPowerDeviceUtilization(uri utils.Nstring, fields []string) (PowerDeviceUtilization, error) {
+	var (
+		q           = make(map[string]interface{})
+		utilization PowerDeviceUtilization
+	)
+	if uri.IsNil() {
+		return utilization, errors.New("Error getting power device utilization, no uri provided")
+	}
+	if len(fields) > 0 {
+		q["fields"] = strings.Join(fields, ",")
+	}
+
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	data, err := c.RestAPICallWithQuery(rest.GET, uri.String()+"/utilization", q, nil)
+	if err != nil {
+		return utilization, err
+	}
+
+	log.Debugf("GetPowerDeviceUtilization %s", data)
+	if err := json.Unmarshal([]byte(data), &utilization); err != nil {
+		return utilization, err
+	}
+	return utilization, nil
+}
@@ -20,6 +20,7 @@ package ov
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -212,3 +213,63 @@ func (pt *PowerTask) PowerExecutor(s PowerState) error {
 	log.Infof("Power Task Execution Completed")
 	return nil
 }
+
+// PowerControls lists the power controls accepted for each power state by SetServerPowerState,
+// ColdBoot and Reset restart a server that is on
+var PowerControls = map[string][]string{
+	P_ON.String():  {P_MOMPRESS.String(), P_COLDBOOT.String(), P_RESET.String()},
+	P_OFF.String(): {P_MOMPRESS.String(), P_PRESSANDHOLD.String()},
+}
+
+// SetServerPowerState requests the power state, On or Off, on the server hardware with the power
+// control, MomentaryPress when empty, and returns the task without waiting on it. Turning a server
+// on or off when it already is in that state returns a task that is already done.
+func (c *OVClient) SetServerPowerState(sh ServerHardware, state string, control string) (*Task, error) {
+	var t *Task
+	if sh.URI.IsNil() {
+		return nil, errors.New("Error setting power state, server hardware URI is empty")
+	}
+	if control == "" {
+		control = P_MOMPRESS.String()
+	}
+	controls, ok := PowerControls[state]
+	if !ok {
+		return nil, fmt.Errorf("Error unknown power state %q, valid states are On and Off", state)
+	}
+	if !containsString(controls, control) {
+		return nil, fmt.Errorf("Error power control %q is not valid with power state %s, valid controls are %s", control, state, strings.Join(controls, ","))
+	}
+
+	t = t.NewProfileTask(c)
+	t.ResetTask()
+	restart := control == P_COLDBOOT.String() || control == P_RESET.String()
+	if !restart && strings.EqualFold(state, sh.PowerState) {
+		log.Infof("Desired Power State already set -> %s", state)
+		t.TaskIsDone = true
+		return t, nil
+	}
+
+	var (
+		uri  = sh.URI.String() + "/powerState"
+		body = PowerRequest{PowerState: state, PowerControl: control}
+	)
+	log.Infof("Powering %s server %s with %s.", state, sh.Name, control)
+
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	data, err := c.RestAPICall(rest.PUT, uri, body)
+	if err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with power state request: %s", err)
+		return t, err
+	}
+
+	log.Debugf("SetServerPowerState %s", data)
+	if err := json.Unmarshal([]byte(data), &t); err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with power state un-marshal: %s", err)
+		return t, err
+	}
+	return t, nil
+}
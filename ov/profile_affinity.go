@@ -0,0 +1,58 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"fmt"
+)
+
+// Affinity controls what the profile stays assigned to when the server hardware in its
+// enclosure bay is removed or replaced. It only applies to profiles of blade servers.
+type Affinity string
+
+const (
+	// AFFINITY_BAY keeps the profile with the bay, a server inserted in the bay
+	// receives the profile on the next apply
+	AFFINITY_BAY Affinity = "Bay"
+	// AFFINITY_BAY_AND_SERVER keeps the profile with the bay and the specific server,
+	// the profile is unassigned when a different server is inserted in the bay
+	AFFINITY_BAY_AND_SERVER Affinity = "BayAndServer"
+)
+
+// DefaultAffinity is the affinity OneView applies when none is set on the profile
+const DefaultAffinity = AFFINITY_BAY
+
+// GetAffinity returns the affinity of the profile, DefaultAffinity when unset
+func (s ServerProfile) GetAffinity() Affinity {
+	if s.Affinity == "" {
+		return DefaultAffinity
+	}
+	return Affinity(s.Affinity)
+}
+
+// SetAffinity validates and sets the affinity of the profile, an empty affinity sets DefaultAffinity
+func (s *ServerProfile) SetAffinity(affinity Affinity) error {
+	if affinity == "" {
+		affinity = DefaultAffinity
+	}
+	if affinity != AFFINITY_BAY && affinity != AFFINITY_BAY_AND_SERVER {
+		return fmt.Errorf("Error unknown affinity %q, valid values are %s and %s", affinity, AFFINITY_BAY, AFFINITY_BAY_AND_SERVER)
+	}
+	s.Affinity = string(affinity)
+	return nil
+}
@@ -0,0 +1,160 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/docker/machine/libmachine/log"
+)
+
+// MinimumRequestedMbps is the lowest bandwidth OneView accepts on an ethernet connection
+const MinimumRequestedMbps = 100
+
+// PhysicalPortId returns the physical port of a connection port id, "Mezz 3:1-a" gives "Mezz 3:1".
+// Port ids that do not name a port, such as Auto or None, give an empty string.
+func PhysicalPortId(portId string) string {
+	if portId == "" || portId == "Auto" || portId == "None" || !strings.Contains(portId, ":") {
+		return ""
+	}
+	if i := strings.LastIndex(portId, "-"); i > strings.Index(portId, ":") {
+		return portId[:i]
+	}
+	return portId
+}
+
+// GetPortMaxSpeedMbps returns the maximum speed of the physical port of a connection port id,
+// false when the server hardware type has no such port
+func (t ServerHardwareType) GetPortMaxSpeedMbps(portId string) (int, bool) {
+	port := PhysicalPortId(portId)
+	if port == "" {
+		return 0, false
+	}
+	var (
+		location  string
+		slot, num int
+	)
+	if _, err := fmt.Sscanf(strings.Replace(port, ":", " ", 1), "%s %d %d", &location, &slot, &num); err != nil {
+		return 0, false
+	}
+	for _, adapter := range t.Adapters {
+		if adapter.Location != location || adapter.Slot != slot {
+			continue
+		}
+		for _, p := range adapter.Ports {
+			if p.Number == num && p.MaxSpeedMbps > 0 {
+				return p.MaxSpeedMbps, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// getNetworkConnectionTemplate gets the connection template of an ethernet, fc or fcoe network or network set
+func (c *OVClient) getNetworkConnectionTemplate(networkUri utils.Nstring) (ConnectionTemplate, error) {
+	var (
+		network struct {
+			ConnectionTemplateUri utils.Nstring `json:"connectionTemplateUri,omitempty"`
+		}
+		template ConnectionTemplate
+	)
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	data, err := c.RestAPICall(rest.GET, networkUri.String(), nil)
+	if err != nil {
+		return template, err
+	}
+	if err := json.Unmarshal(data, &network); err != nil {
+		return template, err
+	}
+	if network.ConnectionTemplateUri.IsNil() {
+		return template, fmt.Errorf("Error network %s has no connection template", networkUri)
+	}
+	return c.GetConnectionTemplateByURI(network.ConnectionTemplateUri)
+}
+
+// AutoSizeBandwidth sets the requested bandwidth of every ethernet connection that has none to the
+// typical bandwidth of its network. When the profile has a server hardware type the bandwidth of
+// the connections sharing a physical port is kept within the port speed, a connection that does
+// not fit gets what is left on the port and a warning is returned for it. An error is returned
+// when the bandwidth already requested exceeds a port or a port has no bandwidth left.
+func (s *ServerProfile) AutoSizeBandwidth(c *OVClient) ([]string, error) {
+	var (
+		warnings  []string
+		sht       ServerHardwareType
+		err       error
+		allocated = make(map[string]int)
+		typical   = make(map[utils.Nstring]int)
+	)
+	if !s.ServerHardwareTypeURI.IsNil() {
+		if sht, err = c.GetServerHardwareTypeByUri(s.ServerHardwareTypeURI); err != nil {
+			return warnings, err
+		}
+	}
+
+	connections := s.ConnectionSettings.Connections
+	for _, conn := range connections {
+		if mbps, err := strconv.Atoi(conn.RequestedMbps); err == nil {
+			allocated[PhysicalPortId(conn.PortID)] += mbps
+		}
+	}
+	for port, mbps := range allocated {
+		if capacity, ok := sht.GetPortMaxSpeedMbps(port); ok && mbps > capacity {
+			return warnings, fmt.Errorf("Error connections on port %s request %d Mbps, the port supports %d Mbps", port, mbps, capacity)
+		}
+	}
+
+	for i, conn := range connections {
+		if conn.RequestedMbps != "" || conn.FunctionType == "FibreChannel" || conn.NetworkURI.IsNil() {
+			continue
+		}
+		mbps, ok := typical[conn.NetworkURI]
+		if !ok {
+			template, err := c.getNetworkConnectionTemplate(conn.NetworkURI)
+			if err != nil {
+				return warnings, err
+			}
+			mbps = template.Bandwidth.TypicalBandwidth
+			typical[conn.NetworkURI] = mbps
+		}
+		if mbps <= 0 {
+			continue
+		}
+
+		port := PhysicalPortId(conn.PortID)
+		if capacity, ok := sht.GetPortMaxSpeedMbps(port); ok {
+			remaining := capacity - allocated[port]
+			if remaining < MinimumRequestedMbps {
+				return warnings, fmt.Errorf("Error connection %s can not be sized, port %s has no bandwidth left", conn.Name, port)
+			}
+			if mbps > remaining {
+				warnings = append(warnings, fmt.Sprintf("connection %s clamped from %d to %d Mbps to fit port %s", conn.Name, mbps, remaining, port))
+				mbps = remaining
+			}
+		}
+		allocated[port] += mbps
+		connections[i].RequestedMbps = strconv.Itoa(mbps)
+		log.Debugf("connection %s requested bandwidth set to %d Mbps", conn.Name, mbps)
+	}
+	return warnings, nil
+}
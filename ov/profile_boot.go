@@ -0,0 +1,115 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/HewlettPackard/oneview-golang/utils"
+)
+
+// boot modes accepted in BootModeOption.Mode
+const (
+	BOOT_MODE_BIOS           = "BIOS"
+	BOOT_MODE_UEFI           = "UEFI"
+	BOOT_MODE_UEFI_OPTIMIZED = "UEFIOptimized"
+)
+
+// pxe boot policies accepted in BootModeOption.PXEBootPolicy for UEFI modes
+var PXEBootPolicies = []string{"Auto", "IPv4", "IPv6", "IPv4ThenIPv6", "IPv6ThenIPv4"}
+
+// BootOrderValues lists the devices allowed in BootManagement.Order for each boot mode.
+// Legacy BIOS accepts any ordering of its devices, UEFI modes accept a single device.
+var BootOrderValues = map[string][]string{
+	BOOT_MODE_BIOS:           {"CD", "Floppy", "USB", "HardDisk", "PXE"},
+	BOOT_MODE_UEFI:           {"HardDisk", "PXE"},
+	BOOT_MODE_UEFI_OPTIMIZED: {"HardDisk", "PXE"},
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateBootOrder checks a boot order against the devices allowed for the boot mode,
+// an empty mode is treated as legacy BIOS
+func ValidateBootOrder(mode string, order []string) error {
+	if mode == "" {
+		mode = BOOT_MODE_BIOS
+	}
+	allowed, ok := BootOrderValues[mode]
+	if !ok {
+		return fmt.Errorf("Error unknown boot mode %q, valid modes are BIOS, UEFI and UEFIOptimized", mode)
+	}
+	if mode != BOOT_MODE_BIOS && len(order) > 1 {
+		return fmt.Errorf("Error boot mode %s allows only one boot device, got %s", mode, strings.Join(order, ","))
+	}
+	seen := make(map[string]bool)
+	for _, device := range order {
+		if !containsString(allowed, device) {
+			return fmt.Errorf("Error boot device %q is not valid for boot mode %s, valid devices are %s", device, mode, strings.Join(allowed, ","))
+		}
+		if seen[device] {
+			return fmt.Errorf("Error boot device %q is listed more than once", device)
+		}
+		seen[device] = true
+	}
+	return nil
+}
+
+// SetBootOrder validates the order against the profile boot mode and sets it as a managed boot order
+func (s *ServerProfile) SetBootOrder(order []string) error {
+	if err := ValidateBootOrder(s.BootMode.Mode, order); err != nil {
+		return err
+	}
+	s.Boot.ManageBoot = true
+	s.Boot.Order = order
+	return nil
+}
+
+// SetBootMode sets a managed boot mode, pxePolicy only applies to UEFI modes and defaults to Auto.
+// An existing boot order that is not valid for the new mode is cleared.
+func (s *ServerProfile) SetBootMode(mode string, pxePolicy string) error {
+	if _, ok := BootOrderValues[mode]; !ok {
+		return fmt.Errorf("Error unknown boot mode %q, valid modes are BIOS, UEFI and UEFIOptimized", mode)
+	}
+	if mode == BOOT_MODE_BIOS {
+		if pxePolicy != "" {
+			return fmt.Errorf("Error pxe boot policy is not supported with boot mode %s", mode)
+		}
+	} else {
+		if pxePolicy == "" {
+			pxePolicy = "Auto"
+		}
+		if !containsString(PXEBootPolicies, pxePolicy) {
+			return fmt.Errorf("Error pxe boot policy %q is not valid, valid policies are %s", pxePolicy, strings.Join(PXEBootPolicies, ","))
+		}
+	}
+	s.BootMode.ManageMode = utils.GetBoolPointer(true)
+	s.BootMode.Mode = mode
+	s.BootMode.PXEBootPolicy = utils.NewNstring(pxePolicy)
+	if ValidateBootOrder(mode, s.Boot.Order) != nil {
+		s.Boot.Order = nil
+	}
+	return nil
+}
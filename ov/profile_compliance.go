@@ -0,0 +1,142 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/docker/machine/libmachine/log"
+)
+
+// template compliance values reported in ServerProfile.TemplateCompliance
+const (
+	COMPLIANCE_COMPLIANT     = "Compliant"
+	COMPLIANCE_NON_COMPLIANT = "NonCompliant"
+	COMPLIANCE_UNKNOWN       = "Unknown"
+)
+
+// GetProfileCompliance reads the profile from the appliance and returns its template compliance,
+// Compliant, NonCompliant or Unknown
+func (c *OVClient) GetProfileCompliance(p ServerProfile) (string, error) {
+	if p.URI.IsNil() {
+		return "", errors.New("Error getting profile compliance, profile URI is empty")
+	}
+	profile, err := c.GetProfileByURI(p.URI)
+	if err != nil {
+		return "", err
+	}
+	if profile.ServerProfileTemplateURI.IsNil() {
+		return "", fmt.Errorf("Error getting profile compliance, profile %s is not bound to a template", profile.Name)
+	}
+	return profile.TemplateCompliance, nil
+}
+
+// RemediateProfileFromTemplate brings the profile back in line with its template and returns the task
+// without waiting on it. OneView remediates by patching /templateCompliance to Compliant on the profile,
+// unlike RemediateProfile the patch is sent without checking the compliance first.
+func (c *OVClient) RemediateProfileFromTemplate(p ServerProfile) (*Task, error) {
+	if p.URI.IsNil() {
+		return nil, errors.New("Error remediating profile, profile URI is empty")
+	}
+	if p.ServerProfileTemplateURI.IsNil() {
+		return nil, fmt.Errorf("Error remediating profile, profile %s is not bound to a template", p.Name)
+	}
+	return c.PatchProfile(p.URI, []PatchOp{{Op: "replace", Path: "/templateCompliance", Value: COMPLIANCE_COMPLIANT}})
+}
+
+// ProfileCompliancePreview changes an update from template would make to a server profile
+type ProfileCompliancePreview struct {
+	AutomaticUpdates []string `json:"automaticUpdates,omitempty"` // "automaticUpdates": ["Change the boot mode."],
+	IsOnlineUpdate   bool     `json:"isOnlineUpdate,omitempty"`   // "isOnlineUpdate": true,
+	ManualUpdates    []string `json:"manualUpdates,omitempty"`    // "manualUpdates": ["Update the firmware baseline."],
+	Type             string   `json:"type,omitempty"`             // "type": "ServerProfileCompliancePreviewV1"
+}
+
+// IsCompliant reports whether the preview has no change to apply
+func (p ProfileCompliancePreview) IsCompliant() bool {
+	return len(p.AutomaticUpdates) == 0 && len(p.ManualUpdates) == 0
+}
+
+// GetProfileCompliancePreview gets the changes an update from template would make to the profile
+func (c *OVClient) GetProfileCompliancePreview(uri utils.Nstring) (ProfileCompliancePreview, error) {
+	var (
+		preview ProfileCompliancePreview
+	)
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	data, err := c.RestAPICall(rest.GET, uri.String()+"/compliance-preview", nil)
+	if err != nil {
+		return preview, err
+	}
+
+	log.Debugf("GetProfileCompliancePreview %s", data)
+	if err := json.Unmarshal([]byte(data), &preview); err != nil {
+		return preview, err
+	}
+	return preview, nil
+}
+
+// RemediateProfile updates a profile from its template when it has drifted, waiting for the
+// update when wait is set. A nil task is returned when the profile is already compliant, so the
+// call can be repeated safely. Changes the preview lists as manual are logged as they are not
+// applied by the update.
+func (c *OVClient) RemediateProfile(profileName string, wait bool) (*Task, error) {
+	profile, err := c.GetProfileByName(profileName)
+	if err != nil {
+		return nil, err
+	}
+	if profile.URI.IsNil() {
+		return nil, fmt.Errorf("Error remediating profile, could not find server profile %s", profileName)
+	}
+	if profile.ServerProfileTemplateURI.IsNil() {
+		return nil, fmt.Errorf("Error remediating profile, server profile %s is not created from a template", profileName)
+	}
+	if profile.TemplateCompliance == "Compliant" {
+		log.Debugf("server profile %s is compliant with its template", profileName)
+		return nil, nil
+	}
+
+	preview, err := c.GetProfileCompliancePreview(profile.URI)
+	if err != nil {
+		return nil, err
+	}
+	if preview.IsCompliant() {
+		log.Debugf("server profile %s has no change to apply from its template", profileName)
+		return nil, nil
+	}
+	for _, update := range preview.ManualUpdates {
+		log.Warnf("server profile %s needs a manual update: %s", profileName, update)
+	}
+
+	request := []Options{{Op: "replace", Path: "/templateCompliance", Value: "Compliant"}}
+	t, err := c.submitPatchServerProfile(profile, request)
+	if err != nil {
+		return t, err
+	}
+	if wait {
+		if err := t.Wait(); err != nil {
+			return t, err
+		}
+	}
+	return t, nil
+}
@@ -0,0 +1,82 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// identifier types accepted in the macType, wwnType and serialNumberType of a profile
+const (
+	IDENTIFIER_VIRTUAL      = "Virtual"
+	IDENTIFIER_PHYSICAL     = "Physical"
+	IDENTIFIER_USER_DEFINED = "UserDefined"
+)
+
+// setIdentifierTypes sets the mac, wwn and serial number types together
+func (s *ServerProfile) setIdentifierTypes(identifierType string) {
+	s.MACType = identifierType
+	s.WWNType = identifierType
+	s.SerialNumberType = identifierType
+}
+
+// UseVirtualIdentifiers sets the mac, wwn and serial number types to Virtual,
+// the matching id pools must be enabled, see ValidateIdentifierPools
+func (s *ServerProfile) UseVirtualIdentifiers() {
+	s.setIdentifierTypes(IDENTIFIER_VIRTUAL)
+}
+
+// UsePhysicalIdentifiers sets the mac, wwn and serial number types to Physical
+func (s *ServerProfile) UsePhysicalIdentifiers() {
+	s.setIdentifierTypes(IDENTIFIER_PHYSICAL)
+}
+
+// ValidateIdentifierPools checks the id pool backing every Virtual identifier type of the
+// profile is enabled, returning a single error listing the pools that must be enabled
+func (c *OVClient) ValidateIdentifierPools(p ServerProfile) error {
+	var (
+		disabled []string
+		types    = []struct {
+			field    string
+			value    string
+			poolType string
+		}{
+			{"macType", p.MACType, "vmac"},
+			{"wwnType", p.WWNType, "vwwn"},
+			{"serialNumberType", p.SerialNumberType, "vsn"},
+		}
+	)
+	for _, t := range types {
+		if t.value != IDENTIFIER_VIRTUAL {
+			continue
+		}
+		pool, err := c.GetPoolType(t.poolType)
+		if err != nil {
+			return err
+		}
+		if pool.Enabled == nil || !*pool.Enabled {
+			disabled = append(disabled, fmt.Sprintf("%s (%s)", t.poolType, t.field))
+		}
+	}
+	if len(disabled) > 0 {
+		return errors.New("Error validating identifiers, id pools must be enabled: " + strings.Join(disabled, ", "))
+	}
+	return nil
+}
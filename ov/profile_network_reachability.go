@@ -0,0 +1,198 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/docker/machine/libmachine/log"
+)
+
+// Warning is a non fatal problem found while checking a profile before submit
+type Warning struct {
+	ConnectionID int           `json:"connectionId,omitempty"`
+	ResourceUri  utils.Nstring `json:"resourceUri,omitempty"`
+	Message      string        `json:"message"`
+}
+
+func (w Warning) String() string {
+	if w.ConnectionID > 0 {
+		return fmt.Sprintf("connection %d: %s", w.ConnectionID, w.Message)
+	}
+	return w.Message
+}
+
+// AvailableNetwork is a network or network set that can be used by a profile connection
+type AvailableNetwork struct {
+	Name   string        `json:"name,omitempty"`
+	Uri    utils.Nstring `json:"uri,omitempty"`
+	Vlan   int           `json:"vlan,omitempty"`
+	Type   string        `json:"type,omitempty"`
+	Status string        `json:"status,omitempty"`
+}
+
+// AvailableNetworks lists the networks reachable from an enclosure group and server hardware type
+type AvailableNetworks struct {
+	EthernetNetworks      []AvailableNetwork `json:"ethernetNetworks,omitempty"`
+	FcNetworks            []AvailableNetwork `json:"fcNetworks,omitempty"`
+	NetworkSets           []AvailableNetwork `json:"networkSets,omitempty"`
+	ServerHardwareTypeUri utils.Nstring      `json:"serverHardwareTypeUri,omitempty"`
+	EnclosureGroupUri     utils.Nstring      `json:"enclosureGroupUri,omitempty"`
+	Type                  string             `json:"type,omitempty"`
+}
+
+// GetAvailableNetworks gets the networks and network sets reachable by profile connections
+// for the enclosure group and server hardware type
+func (c *OVClient) GetAvailableNetworks(enclosureGroupUri utils.Nstring, serverHardwareTypeUri utils.Nstring) (AvailableNetworks, error) {
+	var (
+		uri      = "/rest/server-profiles/available-networks"
+		networks AvailableNetworks
+		q        = make(map[string]interface{})
+	)
+	if !enclosureGroupUri.IsNil() {
+		q["enclosureGroupUri"] = enclosureGroupUri.String()
+	}
+	if !serverHardwareTypeUri.IsNil() {
+		q["serverHardwareTypeUri"] = serverHardwareTypeUri.String()
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	data, err := c.RestAPICall(rest.GET, uri, nil, q)
+	if err != nil {
+		return networks, err
+	}
+
+	log.Debugf("GetAvailableNetworks %s", data)
+	if err := json.Unmarshal([]byte(data), &networks); err != nil {
+		return networks, err
+	}
+	return networks, nil
+}
+
+// Contains - true when uri is one of the available ethernet networks, fc networks or network sets
+func (a AvailableNetworks) Contains(uri utils.Nstring) bool {
+	for _, group := range [][]AvailableNetwork{a.EthernetNetworks, a.FcNetworks, a.NetworkSets} {
+		for _, network := range group {
+			if network.Uri == uri {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AvailableStorageSystem is a storage system a profile san storage can attach volumes from
+type AvailableStorageSystem struct {
+	Name             string             `json:"name,omitempty"`
+	StorageSystemUri utils.Nstring      `json:"storageSystemUri,omitempty"`
+	Networks         []AvailableNetwork `json:"networks,omitempty"`
+}
+
+// AvailableStorageSystems lists the storage systems reachable from an enclosure group and server hardware type
+type AvailableStorageSystems struct {
+	Total       int                      `json:"total,omitempty"`
+	Count       int                      `json:"count,omitempty"`
+	Start       int                      `json:"start,omitempty"`
+	NextPageURI utils.Nstring            `json:"nextPageUri,omitempty"`
+	PrevPageURI utils.Nstring            `json:"prevPageUri,omitempty"`
+	Members     []AvailableStorageSystem `json:"members,omitempty"`
+}
+
+// GetProfileAvailableStorageSystems gets the storage systems profile san storage can use on the
+// server hardware type in the enclosure group, the arguments are in the order GetAvailableNetworks takes them
+func (c *OVClient) GetProfileAvailableStorageSystems(enclosureGroupURI, serverHardwareTypeURI utils.Nstring) (AvailableStorageSystems, error) {
+	var (
+		uri     = "/rest/server-profiles/available-storage-systems"
+		systems AvailableStorageSystems
+		q       = make(map[string]interface{})
+	)
+	if !enclosureGroupURI.IsNil() {
+		q["enclosureGroupUri"] = enclosureGroupURI.String()
+	}
+	if !serverHardwareTypeURI.IsNil() {
+		q["serverHardwareTypeUri"] = serverHardwareTypeURI.String()
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	data, err := c.RestAPICall(rest.GET, uri, nil, q)
+	if err != nil {
+		return systems, err
+	}
+
+	log.Debugf("GetProfileAvailableStorageSystems %s", data)
+	if err := json.Unmarshal([]byte(data), &systems); err != nil {
+		return systems, err
+	}
+	return systems, nil
+}
+
+// ValidateNetworkSetReachability checks every member network of the network sets used by the
+// profile connections is reachable from the profile enclosure group. A member network missing
+// from the uplink sets is silently dropped by the appliance, so each one is returned as a
+// warning naming the network and its VLAN.
+func (c *OVClient) ValidateNetworkSetReachability(p ServerProfile) ([]Warning, error) {
+	var (
+		warnings  []Warning
+		reachable map[utils.Nstring]bool
+	)
+	for _, conn := range p.ConnectionSettings.Connections {
+		if !strings.HasPrefix(conn.NetworkURI.String(), "/rest/network-sets/") {
+			continue
+		}
+		if reachable == nil {
+			if p.EnclosureGroupURI.IsNil() {
+				return warnings, errors.New("Error validating network set reachability, profile has no enclosure group")
+			}
+			available, err := c.GetAvailableNetworks(p.EnclosureGroupURI, p.ServerHardwareTypeURI)
+			if err != nil {
+				return warnings, err
+			}
+			reachable = make(map[utils.Nstring]bool)
+			for _, network := range available.EthernetNetworks {
+				reachable[network.Uri] = true
+			}
+		}
+		netSet, err := c.GetNetworkSetByUri(conn.NetworkURI)
+		if err != nil {
+			return warnings, err
+		}
+		for _, networkUri := range netSet.NetworkUris {
+			if reachable[networkUri] {
+				continue
+			}
+			network, err := c.GetEthernetNetworkByUri(networkUri)
+			if err != nil {
+				return warnings, err
+			}
+			warnings = append(warnings, Warning{
+				ConnectionID: conn.ID,
+				ResourceUri:  networkUri,
+				Message: fmt.Sprintf("network %s (VLAN %d) in network set %s is not reachable from enclosure group %s, add it to an uplink set",
+					network.Name, network.VlanId, netSet.Name, p.EnclosureGroupURI),
+			})
+		}
+	}
+	return warnings, nil
+}
@@ -0,0 +1,182 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/docker/machine/libmachine/log"
+)
+
+// ProfilePort a port (FlexNIC) a profile connection can be assigned to
+type ProfilePort struct {
+	Capabilities        []string `json:"capabilities,omitempty"`        // "capabilities": ["Ethernet", "FibreChannel"],
+	MaxSpeedMbps        int      `json:"maxSpeedMbps,omitempty"`        // "maxSpeedMbps": 20000,
+	PhysicalPortNumber  int      `json:"physicalPortNumber,omitempty"`  // "physicalPortNumber": 1,
+	PortId              string   `json:"portId,omitempty"`              // "portId": "Mezz 3:1-a",
+	PortName            string   `json:"portName,omitempty"`            // "portName": "Mezzanine 3:1-a",
+	Type                string   `json:"type,omitempty"`                // "type": "Ethernet",
+	VirtualPortFunction string   `json:"virtualPortFunction,omitempty"` // "virtualPortFunction": "a"
+}
+
+// ProfilePorts ports available to the connections of a profile
+type ProfilePorts struct {
+	Ports []ProfilePort `json:"ports,omitempty"`
+	Type  string        `json:"type,omitempty"`
+}
+
+// supports reports whether a connection of the function type can use the port
+func (p ProfilePort) supports(functionType string) bool {
+	if functionType == "" {
+		functionType = "Ethernet"
+	}
+	return p.Type == functionType || containsString(p.Capabilities, functionType)
+}
+
+// GetProfilePorts gets the ports available to profile connections for the server hardware type
+// and enclosure group, or for the server hardware when its uri is given
+func (c *OVClient) GetProfilePorts(enclosureGroupUri utils.Nstring, serverHardwareTypeUri utils.Nstring, serverHardwareUri utils.Nstring) (ProfilePorts, error) {
+	var (
+		uri   = "/rest/server-profiles/profile-ports"
+		q     = make(map[string]interface{})
+		ports ProfilePorts
+	)
+	if !enclosureGroupUri.IsNil() {
+		q["enclosureGroupUri"] = enclosureGroupUri.String()
+	}
+	if !serverHardwareTypeUri.IsNil() {
+		q["serverHardwareTypeUri"] = serverHardwareTypeUri.String()
+	}
+	if !serverHardwareUri.IsNil() {
+		q["serverHardwareUri"] = serverHardwareUri.String()
+	}
+
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	data, err := c.RestAPICall(rest.GET, uri, nil, q)
+	if err != nil {
+		return ports, err
+	}
+
+	log.Debugf("GetProfilePorts %s", data)
+	if err := json.Unmarshal([]byte(data), &ports); err != nil {
+		return ports, err
+	}
+	return ports, nil
+}
+
+// DistributeConnections assigns every connection without a port (empty or Auto) to a free
+// port of the given ports, choosing the physical port with the least requested bandwidth, then
+// the fewest connections, so connections spread evenly. An error is returned when a connection
+// can not be placed or the requested bandwidth of a physical port exceeds its maximum speed, the
+// profile connections are then left unchanged.
+func (s *ServerProfile) DistributeConnections(ports []ProfilePort) error {
+	var (
+		used      = make(map[string]bool)
+		allocated = make(map[string]int)
+		capacity  = make(map[string]int)
+		count     = make(map[string]int)
+		physical  []string
+	)
+	for _, port := range ports {
+		phys := PhysicalPortId(port.PortId)
+		if _, ok := capacity[phys]; !ok {
+			physical = append(physical, phys)
+		}
+		if port.MaxSpeedMbps > capacity[phys] {
+			capacity[phys] = port.MaxSpeedMbps
+		}
+	}
+
+	// ports are assigned on a copy, the profile only gets them once every connection fits
+	connections := append([]Connection(nil), s.ConnectionSettings.Connections...)
+	for _, conn := range connections {
+		if conn.PortID == "" || conn.PortID == "Auto" {
+			continue
+		}
+		used[conn.PortID] = true
+		mbps, _ := strconv.Atoi(conn.RequestedMbps)
+		allocated[PhysicalPortId(conn.PortID)] += mbps
+		count[PhysicalPortId(conn.PortID)]++
+	}
+
+	// less loaded compares physical ports by requested bandwidth, then by number of connections
+	less := func(a string, b string) bool {
+		if allocated[a] != allocated[b] {
+			return allocated[a] < allocated[b]
+		}
+		return count[a] < count[b]
+	}
+
+	for i, conn := range connections {
+		if conn.PortID != "" && conn.PortID != "Auto" {
+			continue
+		}
+		mbps, _ := strconv.Atoi(conn.RequestedMbps)
+		best := ""
+		for _, phys := range physical {
+			for _, port := range ports {
+				if PhysicalPortId(port.PortId) != phys || used[port.PortId] || !port.supports(conn.FunctionType) {
+					continue
+				}
+				if best == "" || less(phys, PhysicalPortId(best)) {
+					best = port.PortId
+				}
+				break
+			}
+		}
+		if best == "" {
+			return fmt.Errorf("Error no free port for connection %s", conn.Name)
+		}
+		used[best] = true
+		allocated[PhysicalPortId(best)] += mbps
+		count[PhysicalPortId(best)]++
+		connections[i].PortID = best
+	}
+
+	for _, phys := range physical {
+		if capacity[phys] > 0 && allocated[phys] > capacity[phys] {
+			return fmt.Errorf("Error connections on port %s request %d Mbps, the port supports %d Mbps", phys, allocated[phys], capacity[phys])
+		}
+	}
+	s.ConnectionSettings.Connections = connections
+	return nil
+}
+
+// DistributeConnectionsAcrossPorts assigns the connections without a port evenly across the
+// physical ports available to the profile hardware, see DistributeConnections.
+// The updated profile is returned, it is not submitted to OneView.
+func (s *ServerProfile) DistributeConnectionsAcrossPorts(c *OVClient) (ServerProfile, error) {
+	if s.ServerHardwareURI.IsNil() && s.ServerHardwareTypeURI.IsNil() {
+		return *s, errors.New("Error distributing connections, the profile has no server hardware or server hardware type")
+	}
+	ports, err := c.GetProfilePorts(s.EnclosureGroupURI, s.ServerHardwareTypeURI, s.ServerHardwareURI)
+	if err != nil {
+		return *s, err
+	}
+	if err := s.DistributeConnections(ports.Ports); err != nil {
+		return *s, err
+	}
+	return *s, nil
+}
@@ -0,0 +1,66 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"fmt"
+
+	"github.com/docker/machine/libmachine/log"
+)
+
+// getProfileServerHardware resolves the server hardware assigned to the profile
+func (c *OVClient) getProfileServerHardware(p ServerProfile) (ServerHardware, error) {
+	if p.ServerHardwareURI.IsNil() {
+		return ServerHardware{}, fmt.Errorf("Error profile %s has no server hardware assigned", p.Name)
+	}
+	return c.GetServerHardwareByUri(p.ServerHardwareURI)
+}
+
+// submitProfilePowerState requests the power state on the server assigned to the profile and returns
+// the task without waiting on it, the task is already done when the server is in that state
+func (c *OVClient) submitProfilePowerState(p ServerProfile, s PowerState) (*Task, error) {
+	hardware, err := c.getProfileServerHardware(p)
+	if err != nil {
+		return nil, err
+	}
+	control := P_PRESSANDHOLD
+	if s == P_ON {
+		control = P_MOMPRESS
+	}
+	log.Infof("Powering %s server %s for profile %s.", s, hardware.Name, p.Name)
+	return c.SetServerPowerState(hardware, s.String(), control.String())
+}
+
+// PowerOnProfile powers on the server assigned to the profile and returns the task
+func (c *OVClient) PowerOnProfile(p ServerProfile) (*Task, error) {
+	return c.submitProfilePowerState(p, P_ON)
+}
+
+// PowerOffProfile powers off the server assigned to the profile with a press and hold and returns the task
+func (c *OVClient) PowerOffProfile(p ServerProfile) (*Task, error) {
+	return c.submitProfilePowerState(p, P_OFF)
+}
+
+// GetProfilePowerState returns the power state, On or Off, of the server assigned to the profile
+func (c *OVClient) GetProfilePowerState(p ServerProfile) (string, error) {
+	hardware, err := c.getProfileServerHardware(p)
+	if err != nil {
+		return "", err
+	}
+	return hardware.PowerState, nil
+}
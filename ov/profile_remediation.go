@@ -0,0 +1,136 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/HewlettPackard/oneview-golang/utils"
+)
+
+// RemediationWave profiles that can be remediated at the same time,
+// no two profiles in a wave share an enclosure
+type RemediationWave struct {
+	Profiles []ServerProfile
+}
+
+// RemediationPlan ordered waves of non compliant profiles to bring back in line with a template
+type RemediationPlan struct {
+	TemplateName string
+	TemplateURI  utils.Nstring
+	Waves        []RemediationWave
+}
+
+// Count returns the number of profiles in the plan
+func (p RemediationPlan) Count() int {
+	count := 0
+	for _, wave := range p.Waves {
+		count += len(wave.Profiles)
+	}
+	return count
+}
+
+// remediationDomain returns the failure domain of a profile, the enclosure for blades
+// and the server hardware for rack servers
+func remediationDomain(profile ServerProfile) string {
+	if !profile.EnclosureURI.IsNil() {
+		return profile.EnclosureURI.String()
+	}
+	if !profile.ServerHardwareURI.IsNil() {
+		return profile.ServerHardwareURI.String()
+	}
+	return profile.URI.String()
+}
+
+// NewRemediationPlan builds a plan from the profiles of a template. Only profiles whose
+// template compliance is NonCompliant are planned. Each wave takes at most one profile from
+// every enclosure so a wave never takes down two servers sharing an enclosure, and at most
+// maxWaveSize profiles, so rack servers, each its own domain, are rolled over several waves.
+// A maxWaveSize of 0 or less puts no limit on the size of a wave.
+func NewRemediationPlan(template ServerProfile, profiles []ServerProfile, maxWaveSize int) RemediationPlan {
+	plan := RemediationPlan{TemplateName: template.Name, TemplateURI: template.URI}
+
+	domains := make(map[string][]ServerProfile)
+	keys := []string{}
+	for _, profile := range profiles {
+		if profile.TemplateCompliance != "NonCompliant" {
+			continue
+		}
+		key := remediationDomain(profile)
+		if _, ok := domains[key]; !ok {
+			keys = append(keys, key)
+		}
+		domains[key] = append(domains[key], profile)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		sort.Slice(domains[key], func(i, j int) bool { return domains[key][i].Name < domains[key][j].Name })
+	}
+
+	for {
+		wave := RemediationWave{}
+		for _, key := range keys {
+			if maxWaveSize > 0 && len(wave.Profiles) == maxWaveSize {
+				break
+			}
+			if len(domains[key]) > 0 {
+				wave.Profiles = append(wave.Profiles, domains[key][0])
+				domains[key] = domains[key][1:]
+			}
+		}
+		if len(wave.Profiles) == 0 {
+			break
+		}
+		plan.Waves = append(plan.Waves, wave)
+	}
+	return plan
+}
+
+// PlanFleetRemediation returns the remediation plan for the profiles created from the
+// template in waves of at most maxWaveSize profiles, see NewRemediationPlan. The plan is not executed.
+func (c *OVClient) PlanFleetRemediation(templateName string, maxWaveSize int) (RemediationPlan, error) {
+	var (
+		profiles []ServerProfile
+	)
+	template, err := c.GetProfileTemplateByName(templateName)
+	if err != nil {
+		return RemediationPlan{}, err
+	}
+	if template.URI.IsNil() {
+		return RemediationPlan{}, fmt.Errorf("Error planning remediation, could not find server profile template %s", templateName)
+	}
+
+	q := map[string]interface{}{
+		"filter": fmt.Sprintf("serverProfileTemplateUri='%s'", template.URI),
+		"sort":   "name:asc",
+	}
+	err = c.Iterate("/rest/server-profiles", q, func(raw json.RawMessage) error {
+		var profile ServerProfile
+		if err := json.Unmarshal(raw, &profile); err != nil {
+			return err
+		}
+		profiles = append(profiles, profile)
+		return nil
+	})
+	if err != nil {
+		return RemediationPlan{}, err
+	}
+	return NewRemediationPlan(template, profiles, maxWaveSize), nil
+}
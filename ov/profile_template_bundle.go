@@ -0,0 +1,466 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/docker/machine/libmachine/log"
+)
+
+// A template bundle carries a server profile template with the definitions of every network,
+// network set, logical interconnect group and enclosure group it references, so the template can
+// be recreated on another appliance where those uris do not exist. References between resources
+// are kept as source appliance uris and resolved by name on import. Server hardware types and
+// interconnect types are hardware descriptions, they are never created, only resolved by name.
+
+// bundle dependency actions reported by ImportProfileTemplateBundle
+const (
+	BUNDLE_EXISTING   = "Existing"
+	BUNDLE_CREATED    = "Created"
+	BUNDLE_FAILED     = "Failed"
+	BUNDLE_UNRESOLVED = "Unresolved"
+)
+
+// Bundle is a profile template with its dependencies, see ExportProfileTemplateBundle
+type Bundle struct {
+	Template                  ServerProfile              `json:"template"`
+	EthernetNetworks          []EthernetNetwork          `json:"ethernetNetworks,omitempty"`
+	FCNetworks                []FCNetwork                `json:"fcNetworks,omitempty"`
+	FCoENetworks              []FCoENetwork              `json:"fcoeNetworks,omitempty"`
+	NetworkSets               []NetworkSet               `json:"networkSets,omitempty"`
+	LogicalInterconnectGroups []LogicalInterconnectGroup `json:"logicalInterconnectGroups,omitempty"`
+	EnclosureGroup            *EnclosureGroup            `json:"enclosureGroup,omitempty"`
+	ServerHardwareTypes       map[utils.Nstring]string   `json:"serverHardwareTypes,omitempty"` // uri -> name
+	InterconnectTypes         map[utils.Nstring]string   `json:"interconnectTypes,omitempty"`   // uri -> name
+}
+
+// BundleDependency reports how one resource of a bundle was resolved on import
+type BundleDependency struct {
+	Category  string        `json:"category"`
+	Name      string        `json:"name"`
+	SourceUri utils.Nstring `json:"sourceUri"`
+	TargetUri utils.Nstring `json:"targetUri,omitempty"`
+	Action    string        `json:"action"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// BundleImportReport lists the resolution of every resource of an imported bundle, in import order
+type BundleImportReport struct {
+	Dependencies []BundleDependency `json:"dependencies"`
+}
+
+// getBundleResource reads the resource at uri into v
+func (c *OVClient) getBundleResource(uri utils.Nstring, v interface{}) error {
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	data, err := c.RestAPICall(rest.GET, uri.String(), nil)
+	if err != nil {
+		return err
+	}
+	log.Debugf("getBundleResource %s", data)
+	return json.Unmarshal([]byte(data), v)
+}
+
+// bundleExport tracks the resources already added to a bundle
+type bundleExport struct {
+	c      *OVClient
+	bundle *Bundle
+	seen   map[utils.Nstring]bool
+}
+
+// addNetwork adds the ethernet, fc, fcoe network or network set at uri and the networks of a set
+func (e *bundleExport) addNetwork(uri utils.Nstring) error {
+	if uri.IsNil() || e.seen[uri] {
+		return nil
+	}
+	e.seen[uri] = true
+	switch {
+	case strings.HasPrefix(uri.String(), "/rest/ethernet-networks/"):
+		var network EthernetNetwork
+		if err := e.c.getBundleResource(uri, &network); err != nil {
+			return err
+		}
+		e.bundle.EthernetNetworks = append(e.bundle.EthernetNetworks, network)
+	case strings.HasPrefix(uri.String(), "/rest/fc-networks/"):
+		var network FCNetwork
+		if err := e.c.getBundleResource(uri, &network); err != nil {
+			return err
+		}
+		e.bundle.FCNetworks = append(e.bundle.FCNetworks, network)
+	case strings.HasPrefix(uri.String(), "/rest/fcoe-networks/"):
+		var network FCoENetwork
+		if err := e.c.getBundleResource(uri, &network); err != nil {
+			return err
+		}
+		e.bundle.FCoENetworks = append(e.bundle.FCoENetworks, network)
+	case strings.HasPrefix(uri.String(), "/rest/network-sets/"):
+		netSet, err := e.c.GetNetworkSetByUri(uri)
+		if err != nil {
+			return err
+		}
+		for _, member := range append(netSet.NetworkUris, netSet.NativeNetworkUri) {
+			if err := e.addNetwork(member); err != nil {
+				return err
+			}
+		}
+		e.bundle.NetworkSets = append(e.bundle.NetworkSets, netSet)
+	default:
+		return fmt.Errorf("Error exporting bundle, %s is not a network or network set", uri)
+	}
+	return nil
+}
+
+// addLogicalInterconnectGroup adds the logical interconnect group with its uplink set networks and interconnect types
+func (e *bundleExport) addLogicalInterconnectGroup(uri utils.Nstring) error {
+	if uri.IsNil() || e.seen[uri] {
+		return nil
+	}
+	e.seen[uri] = true
+	lig, err := e.c.GetLogicalInterconnectGroupByUri(uri)
+	if err != nil {
+		return err
+	}
+	for _, uplinkSet := range lig.UplinkSets {
+		for _, network := range append(uplinkSet.NetworkUris, uplinkSet.NativeNetworkUri) {
+			if err := e.addNetwork(network); err != nil {
+				return err
+			}
+		}
+	}
+	for _, network := range lig.InternalNetworkUris {
+		if err := e.addNetwork(network); err != nil {
+			return err
+		}
+	}
+	if lig.InterconnectMapTemplate != nil {
+		for _, entry := range lig.InterconnectMapTemplate.InterconnectMapEntryTemplates {
+			typeUri := entry.PermittedInterconnectTypeUri
+			if typeUri.IsNil() || e.bundle.InterconnectTypes[typeUri] != "" {
+				continue
+			}
+			interconnectType, err := e.c.GetInterconnectTypeByUri(typeUri)
+			if err != nil {
+				return err
+			}
+			e.bundle.InterconnectTypes[typeUri] = interconnectType.Name.String()
+		}
+	}
+	e.bundle.LogicalInterconnectGroups = append(e.bundle.LogicalInterconnectGroups, lig)
+	return nil
+}
+
+// ExportProfileTemplateBundle exports the named profile template with the definitions of the
+// networks, network sets, enclosure group and logical interconnect groups it references, and the
+// names of the server hardware and interconnect types, into a single serializable Bundle
+func (c *OVClient) ExportProfileTemplateBundle(name string) (Bundle, error) {
+	var (
+		bundle = Bundle{
+			ServerHardwareTypes: make(map[utils.Nstring]string),
+			InterconnectTypes:   make(map[utils.Nstring]string),
+		}
+		export = bundleExport{c: c, bundle: &bundle, seen: make(map[utils.Nstring]bool)}
+	)
+	template, err := c.GetProfileTemplateByName(name)
+	if err != nil {
+		return bundle, err
+	}
+	if template.URI.IsNil() {
+		return bundle, fmt.Errorf("Error exporting bundle, profile template %s not found", name)
+	}
+	bundle.Template = template
+
+	for _, conn := range template.ConnectionSettings.Connections {
+		if err := export.addNetwork(conn.NetworkURI); err != nil {
+			return bundle, err
+		}
+	}
+
+	if !template.EnclosureGroupURI.IsNil() {
+		enclosureGroup, err := c.GetEnclosureGroupByUri(template.EnclosureGroupURI)
+		if err != nil {
+			return bundle, err
+		}
+		for _, mapping := range enclosureGroup.InterconnectBayMappings {
+			if err := export.addLogicalInterconnectGroup(mapping.LogicalInterconnectGroupUri); err != nil {
+				return bundle, err
+			}
+		}
+		bundle.EnclosureGroup = &enclosureGroup
+	}
+
+	if !template.ServerHardwareTypeURI.IsNil() {
+		sht, err := c.GetServerHardwareTypeByUri(template.ServerHardwareTypeURI)
+		if err != nil {
+			return bundle, err
+		}
+		bundle.ServerHardwareTypes[template.ServerHardwareTypeURI] = sht.Name
+	}
+	return bundle, nil
+}
+
+// bundleImport tracks the target uri of every resolved source uri
+type bundleImport struct {
+	c      *OVClient
+	report *BundleImportReport
+	uris   map[utils.Nstring]utils.Nstring
+}
+
+// resolve returns the target uri of a source uri, uris outside the bundle are kept
+func (i *bundleImport) resolve(uri utils.Nstring) utils.Nstring {
+	if target, ok := i.uris[uri]; ok {
+		return target
+	}
+	return uri
+}
+
+func (i *bundleImport) resolveAll(uris []utils.Nstring) []utils.Nstring {
+	if uris == nil {
+		return nil
+	}
+	resolved := make([]utils.Nstring, len(uris))
+	for n, uri := range uris {
+		resolved[n] = i.resolve(uri)
+	}
+	return resolved
+}
+
+// dependency looks the resource up by name on the target and creates it when missing
+func (i *bundleImport) dependency(category string, name string, source utils.Nstring, lookup func() (utils.Nstring, error), create func() error) error {
+	dep := BundleDependency{Category: category, Name: name, SourceUri: source}
+	target, err := lookup()
+	if err == nil && target.IsNil() {
+		dep.Action = BUNDLE_CREATED
+		log.Infof("Importing bundle, creating %s %s", category, name)
+		if err = create(); err == nil {
+			target, err = lookup()
+			if err == nil && target.IsNil() {
+				err = fmt.Errorf("%s %s not found after create", category, name)
+			}
+		}
+	} else {
+		dep.Action = BUNDLE_EXISTING
+	}
+	if err != nil {
+		dep.Action = BUNDLE_FAILED
+		dep.Error = err.Error()
+		i.report.Dependencies = append(i.report.Dependencies, dep)
+		return fmt.Errorf("Error importing bundle, %s %s: %s", category, name, err)
+	}
+	dep.TargetUri = target
+	i.uris[source] = target
+	i.report.Dependencies = append(i.report.Dependencies, dep)
+	return nil
+}
+
+// hardwareType resolves a server hardware or interconnect type by name, types are never created
+func (i *bundleImport) hardwareType(category string, name string, source utils.Nstring, lookup func() (utils.Nstring, error)) {
+	dep := BundleDependency{Category: category, Name: name, SourceUri: source, Action: BUNDLE_EXISTING}
+	target, err := lookup()
+	if err != nil || target.IsNil() {
+		dep.Action = BUNDLE_UNRESOLVED
+		if err != nil {
+			dep.Error = err.Error()
+		}
+	} else {
+		dep.TargetUri = target
+		i.uris[source] = target
+	}
+	i.report.Dependencies = append(i.report.Dependencies, dep)
+}
+
+// ImportProfileTemplateBundle recreates a bundle on the appliance. Each network, network set,
+// logical interconnect group and enclosure group is looked up by name and created when missing,
+// with its references rewritten to the target uris, then the template is created the same way.
+// Server hardware and interconnect types are resolved by name, an unresolved type is reported and
+// the resources using it are not created. Scopes and the address ranges of the enclosure group
+// belong to the source appliance and are dropped. The report lists how every resource was
+// resolved, up to the one that failed when an error is returned.
+func (c *OVClient) ImportProfileTemplateBundle(bundle Bundle) (BundleImportReport, error) {
+	var (
+		report BundleImportReport
+		imp    = bundleImport{c: c, report: &report, uris: make(map[utils.Nstring]utils.Nstring)}
+	)
+	if bundle.Template.Name == "" {
+		return report, errors.New("Error importing bundle, the bundle has no template")
+	}
+
+	for uri, name := range bundle.ServerHardwareTypes {
+		name := name
+		imp.hardwareType("server-hardware-types", name, uri, func() (utils.Nstring, error) {
+			sht, err := c.GetServerHardwareTypeByName(name)
+			return sht.URI, err
+		})
+	}
+	for uri, name := range bundle.InterconnectTypes {
+		name := name
+		imp.hardwareType("interconnect-types", name, uri, func() (utils.Nstring, error) {
+			interconnectType, err := c.GetInterconnectTypeByName(name)
+			return interconnectType.URI, err
+		})
+	}
+
+	for _, network := range bundle.EthernetNetworks {
+		network := network
+		err := imp.dependency("ethernet-networks", network.Name, network.URI, func() (utils.Nstring, error) {
+			existing, err := c.GetEthernetNetworkByName(network.Name)
+			return existing.URI, err
+		}, func() error {
+			network.URI, network.ETAG, network.Created, network.Modified = "", "", "", ""
+			network.ConnectionTemplateUri, network.FabricUri, network.SubnetUri, network.ScopesUri = "", "", "", ""
+			network.InitialScopeUris = nil
+			return c.CreateEthernetNetwork(network)
+		})
+		if err != nil {
+			return report, err
+		}
+	}
+	for _, network := range bundle.FCNetworks {
+		network := network
+		err := imp.dependency("fc-networks", network.Name, network.URI, func() (utils.Nstring, error) {
+			existing, err := c.GetFCNetworkByName(network.Name)
+			return existing.URI, err
+		}, func() error {
+			network.URI, network.ETAG, network.Created, network.Modified = "", "", "", ""
+			network.ConnectionTemplateUri, network.FabricUri, network.ManagedSanURI, network.ScopesUri = "", "", "", ""
+			network.InitialScopeUris = nil
+			return c.CreateFCNetwork(network)
+		})
+		if err != nil {
+			return report, err
+		}
+	}
+	for _, network := range bundle.FCoENetworks {
+		network := network
+		err := imp.dependency("fcoe-networks", network.Name, network.URI, func() (utils.Nstring, error) {
+			existing, err := c.GetFCoENetworkByName(network.Name)
+			return existing.URI, err
+		}, func() error {
+			network.URI, network.ETAG, network.Created, network.Modified = "", "", "", ""
+			network.ConnectionTemplateUri, network.FabricUri, network.ManagedSanUri, network.ScopesUri = "", "", "", ""
+			network.InitialScopeUris = nil
+			return c.CreateFCoENetwork(network)
+		})
+		if err != nil {
+			return report, err
+		}
+	}
+	for _, netSet := range bundle.NetworkSets {
+		netSet := netSet
+		err := imp.dependency("network-sets", netSet.Name, netSet.URI, func() (utils.Nstring, error) {
+			existing, err := c.GetNetworkSetByName(netSet.Name)
+			return existing.URI, err
+		}, func() error {
+			netSet.URI, netSet.ETAG, netSet.Created, netSet.Modified = "", "", "", ""
+			netSet.ConnectionTemplateUri, netSet.ScopesUri = "", ""
+			netSet.InitialScopeUris = nil
+			netSet.NetworkUris = imp.resolveAll(netSet.NetworkUris)
+			netSet.NativeNetworkUri = imp.resolve(netSet.NativeNetworkUri)
+			return c.CreateNetworkSet(netSet)
+		})
+		if err != nil {
+			return report, err
+		}
+	}
+
+	for _, lig := range bundle.LogicalInterconnectGroups {
+		lig := lig
+		err := imp.dependency("logical-interconnect-groups", lig.Name, lig.URI, func() (utils.Nstring, error) {
+			existing, err := c.GetLogicalInterconnectGroupByName(lig.Name)
+			return existing.URI, err
+		}, func() error {
+			if lig.InterconnectMapTemplate != nil {
+				entries := make([]InterconnectMapEntryTemplate, len(lig.InterconnectMapTemplate.InterconnectMapEntryTemplates))
+				for n, entry := range lig.InterconnectMapTemplate.InterconnectMapEntryTemplates {
+					if _, ok := imp.uris[entry.PermittedInterconnectTypeUri]; !ok && !entry.PermittedInterconnectTypeUri.IsNil() {
+						return fmt.Errorf("interconnect type %s is not available", bundle.InterconnectTypes[entry.PermittedInterconnectTypeUri])
+					}
+					entry.PermittedInterconnectTypeUri = imp.resolve(entry.PermittedInterconnectTypeUri)
+					entry.LogicalDownlinkUri = ""
+					entries[n] = entry
+				}
+				lig.InterconnectMapTemplate = &InterconnectMapTemplate{InterconnectMapEntryTemplates: entries}
+			}
+			if lig.UplinkSets != nil {
+				uplinkSets := make([]UplinkSets, len(lig.UplinkSets))
+				for n, uplinkSet := range lig.UplinkSets {
+					uplinkSet.NetworkUris = imp.resolveAll(uplinkSet.NetworkUris)
+					uplinkSet.NativeNetworkUri = imp.resolve(uplinkSet.NativeNetworkUri)
+					uplinkSets[n] = uplinkSet
+				}
+				lig.UplinkSets = uplinkSets
+			}
+			lig.InternalNetworkUris = imp.resolveAll(lig.InternalNetworkUris)
+			lig.URI, lig.ETAG, lig.Created, lig.Modified, lig.ScopesUri, lig.FabricUri = "", "", "", "", "", ""
+			lig.InitialScopeUris = nil
+			return c.CreateLogicalInterconnectGroup(lig)
+		})
+		if err != nil {
+			return report, err
+		}
+	}
+
+	if eg := bundle.EnclosureGroup; eg != nil {
+		enclosureGroup := *eg
+		err := imp.dependency("enclosure-groups", enclosureGroup.Name, enclosureGroup.URI, func() (utils.Nstring, error) {
+			existing, err := c.GetEnclosureGroupByName(enclosureGroup.Name)
+			return existing.URI, err
+		}, func() error {
+			mappings := make([]InterconnectBayMap, len(enclosureGroup.InterconnectBayMappings))
+			for n, mapping := range enclosureGroup.InterconnectBayMappings {
+				mapping.LogicalInterconnectGroupUri = imp.resolve(mapping.LogicalInterconnectGroupUri)
+				mappings[n] = mapping
+			}
+			enclosureGroup.InterconnectBayMappings = mappings
+			enclosureGroup.AssociatedLogicalInterconnectGroups = nil
+			enclosureGroup.URI, enclosureGroup.ETAG, enclosureGroup.Created, enclosureGroup.Modified, enclosureGroup.ScopesUri = "", "", "", "", ""
+			enclosureGroup.InitialScopeUris, enclosureGroup.IpRangeUris, enclosureGroup.Ipv6RangeUris = nil, nil, nil
+			return c.CreateEnclosureGroup(enclosureGroup)
+		})
+		if err != nil {
+			return report, err
+		}
+	}
+
+	template := bundle.Template
+	err := imp.dependency("server-profile-templates", template.Name, template.URI, func() (utils.Nstring, error) {
+		existing, err := c.GetProfileTemplateByName(template.Name)
+		return existing.URI, err
+	}, func() error {
+		if _, ok := imp.uris[template.ServerHardwareTypeURI]; !ok && !template.ServerHardwareTypeURI.IsNil() {
+			return fmt.Errorf("server hardware type %s is not available", bundle.ServerHardwareTypes[template.ServerHardwareTypeURI])
+		}
+		template.ServerHardwareTypeURI = imp.resolve(template.ServerHardwareTypeURI)
+		template.EnclosureGroupURI = imp.resolve(template.EnclosureGroupURI)
+		connections := make([]Connection, len(template.ConnectionSettings.Connections))
+		for n, conn := range template.ConnectionSettings.Connections {
+			conn.NetworkURI = imp.resolve(conn.NetworkURI)
+			connections[n] = conn
+		}
+		template.ConnectionSettings.Connections = connections
+		template.URI, template.ETAG, template.Created, template.Modified, template.ScopesUri = "", "", "", "", ""
+		template.InitialScopeUris = nil
+		return c.CreateProfileTemplate(template)
+	})
+	return report, err
+}
@@ -18,13 +18,16 @@ limitations under the License.
 package ov
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
 
 	"github.com/HewlettPackard/oneview-golang/liboneview"
 	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/HewlettPackard/oneview-golang/utils"
 	"github.com/docker/machine/libmachine/log"
 )
 
@@ -32,6 +35,12 @@ import (
 // to build the profiles for servers and associate them.
 // we don't operate on a new struct, we simply use the ServerProfile struct
 
+// ServerProfileTemplate - server profile templates are read and written with the ServerProfile struct
+type ServerProfileTemplate = ServerProfile
+
+// ServerProfileTemplateList - list of server profile templates
+type ServerProfileTemplateList = ServerProfileList
+
 // ProfileTemplatesNotSupported - determine these functions are supported
 func (c *OVClient) ProfileTemplatesNotSupported() bool {
 	var currentversion liboneview.Version
@@ -120,6 +129,52 @@ func (c *OVClient) GetProfileTemplates(start string, count string, filter string
 	return profiles, nil
 }
 
+// GetNewProfileFromTemplate returns the profile the appliance derives from the template at templateURI,
+// populated but not saved so it can be adjusted and passed to SubmitNewProfile
+func (c *OVClient) GetNewProfileFromTemplate(templateURI utils.Nstring) (ServerProfile, error) {
+	var (
+		uri     = templateURI.String() + "/new-profile"
+		profile ServerProfile
+	)
+	if templateURI.IsNil() {
+		return profile, errors.New("Error getting new profile from template, template URI is empty")
+	}
+
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	data, err := c.RestAPICall(rest.GET, uri, nil)
+	if err != nil {
+		if rest.IsNotFound(err) {
+			return profile, fmt.Errorf("Error getting new profile from template, template %s does not exist: %w", templateURI, err)
+		}
+		return profile, err
+	}
+
+	log.Debugf("GetNewProfileFromTemplate %s", data)
+	if err := json.Unmarshal([]byte(data), &profile); err != nil {
+		return profile, err
+	}
+	return profile, nil
+}
+
+// CreateProfileFromTemplateURI creates a profile for blade derived by the appliance from the template
+// at templateURI, the derived profile inherits the template connections and local storage
+func (c *OVClient) CreateProfileFromTemplateURI(name string, templateURI utils.Nstring, blade ServerHardware) error {
+	if name == "" {
+		return errors.New("Error creating profile from template, no name provided")
+	}
+	profile, err := c.GetNewProfileFromTemplate(templateURI)
+	if err != nil {
+		return err
+	}
+	profile.Name = name
+	profile.ServerHardwareURI = blade.URI
+	profile.ServerProfileTemplateURI = templateURI
+	return c.SubmitNewProfile(profile)
+}
+
 // IsZeroOfUnderlyingType returns true if a value is initialized.
 func IsZeroOfUnderlyingType(x interface{}) bool {
 	return reflect.DeepEqual(x, reflect.Zero(reflect.TypeOf(x)).Interface())
@@ -271,7 +326,19 @@ func SetMp(shtgen string, mp ManagementProcessors) IntManagementProcessor {
 }
 
 func (c *OVClient) CreateProfileTemplate(serverProfileTemplate ServerProfile) error {
+	t, err := c.CreateServerProfileTemplate(serverProfileTemplate)
+	if err != nil {
+		return err
+	}
+	return t.Wait()
+}
+
+// CreateServerProfileTemplate submits the new server profile template and returns the task without waiting on it
+func (c *OVClient) CreateServerProfileTemplate(serverProfileTemplate ServerProfileTemplate) (*Task, error) {
 	log.Infof("Initializing creation of server profile template for %s.", serverProfileTemplate.Name)
+	if err := c.ValidateResourceScopes(serverProfileTemplate.InitialScopeUris); err != nil {
+		return nil, err
+	}
 	var (
 		uri = "/rest/server-profile-templates"
 		t   *Task
@@ -301,22 +368,16 @@ func (c *OVClient) CreateProfileTemplate(serverProfileTemplate ServerProfile) er
 	if err != nil {
 		t.TaskIsDone = true
 		log.Errorf("Error submitting new server profile template request: %s", err)
-		return err
+		return t, err
 	}
 
 	log.Debugf("Response New server profile template %s", data)
 	if err := json.Unmarshal([]byte(data), &t); err != nil {
 		t.TaskIsDone = true
 		log.Errorf("Error with task un-marshal: %s", err)
-		return err
-	}
-
-	err = t.Wait()
-	if err != nil {
-		return err
+		return t, err
 	}
-
-	return nil
+	return t, nil
 }
 
 func (c *OVClient) DeleteProfileTemplate(name string) error {
@@ -366,12 +427,31 @@ func (c *OVClient) DeleteProfileTemplate(name string) error {
 	return nil
 }
 
+// DeleteServerProfileTemplate deletes the server profile template by name and waits on the delete,
+// a template that does not exist is skipped
+func (c *OVClient) DeleteServerProfileTemplate(name string) error {
+	return c.DeleteProfileTemplate(name)
+}
+
 func (c *OVClient) UpdateProfileTemplate(serverProfileTemplate ServerProfile) error {
+	t, err := c.UpdateServerProfileTemplate(serverProfileTemplate)
+	if err != nil {
+		return err
+	}
+	return t.Wait()
+}
+
+// UpdateServerProfileTemplate submits the modified server profile template and returns the task without
+// waiting on it, the template eTag is sent in If-Match like UpdateProfile
+func (c *OVClient) UpdateServerProfileTemplate(serverProfileTemplate ServerProfileTemplate) (*Task, error) {
 	log.Infof("Initializing update of server profile template for %s.", serverProfileTemplate.Name)
 	var (
 		uri = serverProfileTemplate.URI.String()
 		t   *Task
 	)
+	if uri == "" {
+		return nil, errors.New("Error updating server profile template, template URI is empty")
+	}
 	// refresh login
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
@@ -393,26 +473,24 @@ func (c *OVClient) UpdateProfileTemplate(serverProfileTemplate ServerProfile) er
 		serverProfileTemplate.ManagementProcessor = mp
 	}
 
-	data, err := c.RestAPICall(rest.PUT, uri, serverProfileTemplate)
+	headers := c.GetAuthHeaderMap()
+	if serverProfileTemplate.ETAG != "" {
+		headers["If-Match"] = serverProfileTemplate.ETAG
+	}
+	data, err := c.RestAPICallWithOptions(context.Background(), rest.PUT, uri, serverProfileTemplate, rest.Options{Headers: headers})
 	if err != nil {
 		t.TaskIsDone = true
 		log.Errorf("Error submitting update server profile template request: %s", err)
-		return err
+		return t, err
 	}
 
 	log.Debugf("Response update ServerProfileTemplate %s", data)
 	if err := json.Unmarshal([]byte(data), &t); err != nil {
 		t.TaskIsDone = true
 		log.Errorf("Error with task un-marshal: %s", err)
-		return err
+		return t, err
 	}
-
-	err = t.Wait()
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return t, nil
 }
 
 func (c *OVClient) PatchServerProfileTemplate(p ServerProfile, request []Options) error {
@@ -0,0 +1,275 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/HewlettPackard/oneview-golang/utils"
+)
+
+// severities of a ValidationIssue
+const (
+	VALIDATION_SEVERITY_ERROR   = "Error"
+	VALIDATION_SEVERITY_WARNING = "Warning"
+)
+
+// ValidationIssue is one problem found while validating a profile before submit,
+// Check names the validator that raised it
+type ValidationIssue struct {
+	Severity string `json:"severity"`
+	Check    string `json:"check"`
+	Message  string `json:"message"`
+}
+
+func (i ValidationIssue) String() string {
+	return fmt.Sprintf("%s [%s] %s", i.Severity, i.Check, i.Message)
+}
+
+// HasValidationErrors reports whether any of the issues would make the appliance refuse the profile
+func HasValidationErrors(issues []ValidationIssue) bool {
+	for _, issue := range issues {
+		if issue.Severity == VALIDATION_SEVERITY_ERROR {
+			return true
+		}
+	}
+	return false
+}
+
+func validationError(check string, format string, a ...interface{}) ValidationIssue {
+	return ValidationIssue{Severity: VALIDATION_SEVERITY_ERROR, Check: check, Message: fmt.Sprintf(format, a...)}
+}
+
+func validationWarning(check string, format string, a ...interface{}) ValidationIssue {
+	return ValidationIssue{Severity: VALIDATION_SEVERITY_WARNING, Check: check, Message: fmt.Sprintf(format, a...)}
+}
+
+// ValidateProfileDefinition runs the checks that need no appliance: boot order, connection ids
+// and names, user defined identifiers, SAN storage paths and the firmware baseline setting
+func ValidateProfileDefinition(p ServerProfile) []ValidationIssue {
+	var issues []ValidationIssue
+
+	if p.Boot.ManageBoot {
+		if err := ValidateBootOrder(p.BootMode.Mode, p.Boot.Order); err != nil {
+			issues = append(issues, validationError("boot", "%s", err))
+		}
+	}
+
+	ids := make(map[int]Connection)
+	names := make(map[string]bool)
+	for _, conn := range p.ConnectionSettings.Connections {
+		if conn.ID > 0 {
+			if _, ok := ids[conn.ID]; ok {
+				issues = append(issues, validationError("connections", "connection id %d is used more than once", conn.ID))
+			}
+			ids[conn.ID] = conn
+		}
+		if conn.Name != "" {
+			if names[strings.ToLower(conn.Name)] {
+				issues = append(issues, validationError("connections", "connection name %s is used more than once", conn.Name))
+			}
+			names[strings.ToLower(conn.Name)] = true
+		}
+		if conn.NetworkURI.IsNil() {
+			issues = append(issues, validationError("connections", "connection %d has no network", conn.ID))
+		}
+		if conn.MacType == "UserDefined" && conn.MAC.IsNil() {
+			issues = append(issues, validationError("identifiers", "connection %d has a user defined mac type but no mac", conn.ID))
+		}
+		if conn.WWPNType == "UserDefined" && (conn.WWPN.IsNil() || conn.WWNN.IsNil()) {
+			issues = append(issues, validationError("identifiers", "connection %d has a user defined wwpn type but no wwpn and wwnn", conn.ID))
+		}
+	}
+	if p.SerialNumberType == "UserDefined" && p.SerialNumber.IsNil() {
+		issues = append(issues, validationError("identifiers", "profile has a user defined serial number type but no serial number"))
+	}
+
+	if p.SanStorage.ManageSanStorage {
+		if p.SanStorage.HostOSType == "" {
+			issues = append(issues, validationError("san", "managed SAN storage needs a host OS type"))
+		}
+		for _, attachment := range p.SanStorage.VolumeAttachments {
+			for _, path := range attachment.StoragePaths {
+				conn, ok := ids[path.ConnectionID]
+				if !ok {
+					issues = append(issues, validationError("san", "volume attachment %d has a storage path on unknown connection %d", attachment.ID, path.ConnectionID))
+					continue
+				}
+				if conn.FunctionType != "FibreChannel" && conn.FunctionType != "iSCSI" {
+					issues = append(issues, validationError("san", "volume attachment %d has a storage path on %s connection %d", attachment.ID, conn.FunctionType, path.ConnectionID))
+				}
+			}
+		}
+	}
+
+	if p.Firmware.ManageFirmware && p.Firmware.FirmwareBaselineUri.IsNil() {
+		issues = append(issues, validationError("firmware", "managed firmware needs a firmware baseline"))
+	}
+	return issues
+}
+
+// ValidateProfile pre-flights a profile before submit. It runs ValidateProfileDefinition, checks the
+// profile type is the one expected by the client api version and then runs the checks that read the
+// appliance: server hardware type and its bios settings, assignment, connection bandwidth, network set
+// reachability, identifier pools, initial scopes and the firmware baseline. Every problem is returned
+// as a severity tagged issue, the error is only returned when the profile server hardware type can not
+// be read, in which case none of the appliance checks are run.
+func (c *OVClient) ValidateProfile(p ServerProfile) ([]ValidationIssue, error) {
+	issues := ValidateProfileDefinition(p)
+
+	if c.APIVersion != 0 {
+		if expected := DefaultServerProfileType(c.APIVersion); p.Type != expected {
+			issues = append(issues, validationError("type", "profile type %q does not match %s expected by api version %d", p.Type, expected, c.APIVersion))
+		}
+	}
+
+	if !p.ServerHardwareTypeURI.IsNil() {
+		sht, err := c.GetServerHardwareTypeByUri(p.ServerHardwareTypeURI)
+		if err != nil {
+			return issues, err
+		}
+		if p.Bios != nil {
+			if err := ValidateBiosSettings(sht, p.Bios.OverriddenSettings); err != nil {
+				issues = append(issues, validationError("bios", "%s", err))
+			}
+		}
+	}
+
+	if !p.ServerHardwareURI.IsNil() {
+		hardware, err := c.GetServerHardwareByUri(p.ServerHardwareURI)
+		if err != nil {
+			issues = append(issues, validationError("hardware", "server hardware %s could not be read: %s", p.ServerHardwareURI, err))
+		} else {
+			if !p.ServerHardwareTypeURI.IsNil() && hardware.ServerHardwareTypeURI != p.ServerHardwareTypeURI {
+				issues = append(issues, validationError("hardware", "server hardware %s is of type %s, the profile requires %s", hardware.Name, hardware.ServerHardwareTypeURI, p.ServerHardwareTypeURI))
+			}
+			if !hardware.ServerProfileURI.IsNil() && hardware.ServerProfileURI != p.URI {
+				issues = append(issues, validationError("hardware", "server hardware %s is already assigned to profile %s", hardware.Name, hardware.ServerProfileURI))
+			}
+		}
+	}
+
+	// sizing works on a copy so the caller's profile keeps its requested bandwidth
+	sized := p
+	sized.ConnectionSettings.Connections = append([]Connection(nil), p.ConnectionSettings.Connections...)
+	warnings, err := sized.AutoSizeBandwidth(c)
+	if err != nil {
+		issues = append(issues, validationError("bandwidth", "%s", err))
+	}
+	for _, w := range warnings {
+		issues = append(issues, validationWarning("bandwidth", "%s", w))
+	}
+
+	reachability, err := c.ValidateNetworkSetReachability(p)
+	if err != nil {
+		issues = append(issues, validationError("network sets", "%s", err))
+	}
+	for _, w := range reachability {
+		issues = append(issues, validationWarning("network sets", "%s", w))
+	}
+
+	if err := c.ValidateIdentifierPools(p); err != nil {
+		issues = append(issues, validationError("identifiers", "%s", err))
+	}
+
+	if err := c.ValidateResourceScopes(p.InitialScopeUris); err != nil {
+		issues = append(issues, validationError("scopes", "%s", err))
+	}
+
+	if p.Firmware.ManageFirmware && !p.Firmware.FirmwareBaselineUri.IsNil() {
+		c.RefreshLogin()
+		c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+		if _, err := c.RestAPICall(rest.GET, p.Firmware.FirmwareBaselineUri.String(), nil); err != nil {
+			issues = append(issues, validationError("firmware", "firmware baseline %s could not be found: %s", p.Firmware.FirmwareBaselineUri, err))
+		}
+	}
+	return issues, nil
+}
+
+// profileURIPrefixes maps each resource uri of a profile to the collection it must point into
+func profileURIPrefixes(p ServerProfile) map[string]utils.Nstring {
+	return map[string]utils.Nstring{
+		"/rest/server-hardware/":          p.ServerHardwareURI,
+		"/rest/server-hardware-types/":    p.ServerHardwareTypeURI,
+		"/rest/enclosure-groups/":         p.EnclosureGroupURI,
+		"/rest/enclosures/":               p.EnclosureURI,
+		"/rest/server-profile-templates/": p.ServerProfileTemplateURI,
+		"/rest/firmware-drivers/":         p.Firmware.FirmwareBaselineUri,
+	}
+}
+
+// ValidateProfileSubmission lints a profile body without touching the appliance, for example in a CI
+// pipeline. OneView has no validate only mode for server profile create, so the body is checked here
+// for required fields, resource uri formats and mutually exclusive options, on top of the
+// ValidateProfileDefinition errors. Warnings are not reported, every error is joined in the result.
+func ValidateProfileSubmission(p ServerProfile) error {
+	var problems []string
+	for _, issue := range ValidateProfileDefinition(p) {
+		if issue.Severity == VALIDATION_SEVERITY_ERROR {
+			problems = append(problems, issue.Message)
+		}
+	}
+
+	if p.Name == "" {
+		problems = append(problems, "profile has no name")
+	}
+	if p.ServerHardwareURI.IsNil() && p.ServerHardwareTypeURI.IsNil() {
+		problems = append(problems, "profile needs a server hardware or a server hardware type")
+	}
+	for prefix, uri := range profileURIPrefixes(p) {
+		if !uri.IsNil() && (!strings.HasPrefix(uri.String(), prefix) || len(uri.String()) == len(prefix)) {
+			problems = append(problems, fmt.Sprintf("%s is not a %s uri", uri, prefix))
+		}
+	}
+	for _, uri := range p.InitialScopeUris {
+		if !strings.HasPrefix(uri.String(), "/rest/scopes/") {
+			problems = append(problems, fmt.Sprintf("%s is not a /rest/scopes/ uri", uri))
+		}
+	}
+	for _, conn := range p.ConnectionSettings.Connections {
+		if !conn.NetworkURI.IsNil() && networkFunctionType(conn.NetworkURI) == "" {
+			problems = append(problems, fmt.Sprintf("connection %d network %s is not a network or network set uri", conn.ID, conn.NetworkURI))
+		}
+		if conn.MacType != "" && conn.MacType != "UserDefined" && !conn.MAC.IsNil() {
+			problems = append(problems, fmt.Sprintf("connection %d sets a mac with mac type %s, only UserDefined accepts a mac", conn.ID, conn.MacType))
+		}
+	}
+
+	if p.EnclosureBay > 0 && p.EnclosureURI.IsNil() {
+		problems = append(problems, "profile sets an enclosure bay without an enclosure")
+	}
+	if p.BootMode.Mode == BOOT_MODE_BIOS && !p.BootMode.PXEBootPolicy.IsNil() {
+		problems = append(problems, "pxe boot policy is not supported with boot mode BIOS")
+	}
+	if !p.Boot.ManageBoot && len(p.Boot.Order) > 0 {
+		problems = append(problems, "profile sets a boot order without managing boot")
+	}
+	if p.SerialNumberType != "" && p.SerialNumberType != "UserDefined" && !p.SerialNumber.IsNil() {
+		problems = append(problems, fmt.Sprintf("profile sets a serial number with serial number type %s, only UserDefined accepts a serial number", p.SerialNumberType))
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return errors.New("Error validating profile " + p.Name + ": " + strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -18,11 +18,13 @@ limitations under the License.
 package ov
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"reflect"
+	"strings"
 
 	"github.com/HewlettPackard/oneview-golang/rest"
 	"github.com/HewlettPackard/oneview-golang/utils"
@@ -86,6 +88,20 @@ type Options struct {
 	Value string `json:"value,omitempty"` // "value": "Compliant",
 }
 
+// ErrIncompatibleHardware is matched with errors.Is when the target server hardware type does not
+// match the server hardware type of the profile
+var ErrIncompatibleHardware = errors.New("server hardware type does not match the profile")
+
+// PatchOp is a single JSON Patch operation sent by PatchProfile, Value is ignored by remove
+type PatchOp struct {
+	Op    string      `json:"op"`              // "op": "replace",
+	Path  string      `json:"path"`            // "path": "/description",
+	Value interface{} `json:"value,omitempty"` // "value": "web tier",
+}
+
+// json patch operations accepted by PatchProfile
+var PatchOps = []string{"replace", "add", "remove"}
+
 type Servers struct {
 	EnclosureGroupName     string   `json:"enclosureGroupName,omitempty"`
 	EnclosureName          string   `json:"enclosureName,omitempty"`
@@ -106,6 +122,9 @@ type AvailableTarget struct {
 	Members []Servers `json:"targets,omitempty"`
 }
 
+// AvailableTargets - candidate enclosure bays and server hardware for a profile
+type AvailableTargets = AvailableTarget
+
 type KeyManager struct {
 	PrimaryServerAddress   string `json:"-"`
 	PrimaryServerPort      int    `json:"-"`
@@ -272,10 +291,15 @@ type ServerProfileList struct {
 
 // GetProfileByName gets a server profile by name
 func (c *OVClient) GetProfileByName(name string) (ServerProfile, error) {
+	return c.GetProfileByNameWithContext(context.Background(), name)
+}
+
+// GetProfileByNameWithContext gets a server profile by name, the request is cancelled with ctx
+func (c *OVClient) GetProfileByNameWithContext(ctx context.Context, name string) (ServerProfile, error) {
 	var (
 		profile ServerProfile
 	)
-	profiles, err := c.GetProfiles("", "", fmt.Sprintf("name matches '%s'", name), "name:asc", "")
+	profiles, err := c.GetProfilesWithContext(ctx, "", "", fmt.Sprintf("name matches '%s'", name), "name:asc", "")
 	if profiles.Total > 0 {
 		return profiles.Members[0], err
 	} else {
@@ -298,6 +322,11 @@ func (c *OVClient) GetProfileBySN(serialnum string) (ServerProfile, error) {
 
 // GetProfiles - get a server profiles
 func (c *OVClient) GetProfiles(start string, count string, filter string, sort string, scopeUris string) (ServerProfileList, error) {
+	return c.GetProfilesWithContext(context.Background(), start, count, filter, sort, scopeUris)
+}
+
+// GetProfilesWithContext - get a server profiles, the request is cancelled with ctx
+func (c *OVClient) GetProfilesWithContext(ctx context.Context, start string, count string, filter string, sort string, scopeUris string) (ServerProfileList, error) {
 	var (
 		uri      = "/rest/server-profiles"
 		q        map[string]interface{}
@@ -326,12 +355,7 @@ func (c *OVClient) GetProfiles(start string, count string, filter string, sort s
 
 	// refresh login
 	c.RefreshLogin()
-	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	// Setup query
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithOptions(ctx, rest.GET, uri, nil, rest.Options{Headers: c.GetAuthHeaderMap(), Query: q})
 	if err != nil {
 		return profiles, err
 	}
@@ -343,8 +367,88 @@ func (c *OVClient) GetProfiles(start string, count string, filter string, sort s
 	return profiles, nil
 }
 
+// GetAllProfiles - get every server profile matching filter, following nextPageUri until the last
+// page. The members of each page are merged as the page is read, Count is the number of merged
+// members and Total the total reported by OneView.
+func (c *OVClient) GetAllProfiles(filter string, sort string) (ServerProfileList, error) {
+	return c.GetAllProfilesWithContext(context.Background(), filter, sort)
+}
+
+// GetAllProfilesWithContext - get every server profile matching filter like GetAllProfiles,
+// the paging stops with ctx.Err() once ctx is done
+func (c *OVClient) GetAllProfilesWithContext(ctx context.Context, filter string, sort string) (ServerProfileList, error) {
+	profiles, err := c.GetProfilesWithContext(ctx, "", "", filter, sort, "")
+	if err != nil {
+		return profiles, err
+	}
+
+	next := profiles.NextPageURI
+	for !next.IsNil() {
+		path, q, err := splitPageURI(next, filter, sort)
+		if err != nil {
+			return profiles, err
+		}
+		c.RefreshLogin()
+		c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+		data, err := c.RestAPICallWithContext(ctx, rest.GET, path, nil, q)
+		if err != nil {
+			return profiles, err
+		}
+		log.Debugf("GetAllProfiles %s", data)
+
+		var page ServerProfileList
+		if err := json.Unmarshal([]byte(data), &page); err != nil {
+			return profiles, err
+		}
+		profiles.Members = append(profiles.Members, page.Members...)
+		if len(page.Members) == 0 || page.NextPageURI == next {
+			break
+		}
+		next = page.NextPageURI
+	}
+
+	profiles.Count = len(profiles.Members)
+	profiles.Start = 0
+	profiles.NextPageURI = ""
+	profiles.PrevPageURI = ""
+	return profiles, nil
+}
+
+// GetProfilesByScope - get every server profile in the scope at scopeURI, an empty list when the scope
+// holds no profiles
+func (c *OVClient) GetProfilesByScope(scopeURI utils.Nstring, sort string) (ServerProfileList, error) {
+	var profiles ServerProfileList
+	if !strings.HasPrefix(scopeURI.String(), "/rest/scopes/") || len(scopeURI.String()) == len("/rest/scopes/") {
+		return profiles, fmt.Errorf("Error getting profiles by scope, %q is not a scope URI", scopeURI)
+	}
+
+	q := map[string]interface{}{"query": fmt.Sprintf("scope:'%s'", scopeURI)}
+	if sort != "" {
+		q["sort"] = sort
+	}
+	err := c.Iterate("/rest/server-profiles", q, func(raw json.RawMessage) error {
+		var profile ServerProfile
+		if err := json.Unmarshal(raw, &profile); err != nil {
+			return err
+		}
+		profiles.Members = append(profiles.Members, profile)
+		return nil
+	})
+	if err != nil {
+		return profiles, err
+	}
+	profiles.Total = len(profiles.Members)
+	profiles.Count = len(profiles.Members)
+	return profiles, nil
+}
+
 // GetProfileByURI - get the profile from a uri
 func (c *OVClient) GetProfileByURI(uri utils.Nstring) (ServerProfile, error) {
+	return c.GetProfileByURIWithContext(context.Background(), uri)
+}
+
+// GetProfileByURIWithContext - get the profile from a uri, the request is cancelled with ctx
+func (c *OVClient) GetProfileByURIWithContext(ctx context.Context, uri utils.Nstring) (ServerProfile, error) {
 	var (
 		profile ServerProfile
 	)
@@ -352,7 +456,7 @@ func (c *OVClient) GetProfileByURI(uri utils.Nstring) (ServerProfile, error) {
 	// refresh login
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	data, err := c.RestAPICall(rest.GET, uri.String(), nil)
+	data, err := c.RestAPICallWithContext(ctx, rest.GET, uri.String(), nil)
 	if err != nil {
 		return profile, err
 	}
@@ -364,6 +468,50 @@ func (c *OVClient) GetProfileByURI(uri utils.Nstring) (ServerProfile, error) {
 	return profile, nil
 }
 
+// GetProfileAvailableTargets - get the enclosure bays and server hardware a profile can be placed on,
+// any of the uris may be empty. Targets outside enclosureGroupURI are left out when it is provided.
+func (c *OVClient) GetProfileAvailableTargets(profileTemplateURI, enclosureGroupURI, serverHardwareTypeURI utils.Nstring) (AvailableTargets, error) {
+	var (
+		uri     = "/rest/server-profiles/available-targets"
+		q       = make(map[string]interface{})
+		targets AvailableTargets
+	)
+	if !profileTemplateURI.IsNil() {
+		q["profileTemplateUri"] = profileTemplateURI.String()
+	}
+	if !enclosureGroupURI.IsNil() {
+		q["enclosureGroupUri"] = enclosureGroupURI.String()
+	}
+	if !serverHardwareTypeURI.IsNil() {
+		q["serverHardwareTypeUri"] = serverHardwareTypeURI.String()
+	}
+
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	data, err := c.RestAPICall(rest.GET, uri, nil, q)
+	if err != nil {
+		return targets, err
+	}
+
+	log.Debugf("GetProfileAvailableTargets %s", data)
+	if err := json.Unmarshal([]byte(data), &targets); err != nil {
+		return targets, err
+	}
+
+	if !enclosureGroupURI.IsNil() {
+		members := []Servers{}
+		for _, target := range targets.Members {
+			if target.EnclosureGroupUri == "" || target.EnclosureGroupUri == enclosureGroupURI.String() {
+				members = append(members, target)
+			}
+		}
+		targets.Members = members
+	}
+	return targets, nil
+}
+
 // GetAvailableServers - To fetch available server hardwares
 func (c *OVClient) GetAvailableServers(ServerHardwareUri string) (bool, error) {
 	var (
@@ -397,7 +545,16 @@ func (c *OVClient) GetAvailableServers(ServerHardwareUri string) (bool, error) {
 
 // SubmitNewProfile - submit new profile template
 func (c *OVClient) SubmitNewProfile(p ServerProfile) (err error) {
+	return c.SubmitNewProfileWithContext(context.Background(), p)
+}
+
+// SubmitNewProfileWithContext submits a new profile and waits for it like SubmitNewProfile,
+// returning ctx.Err() once ctx is done. A profile already submitted keeps being created.
+func (c *OVClient) SubmitNewProfileWithContext(ctx context.Context, p ServerProfile) (err error) {
 	log.Infof("Initializing creation of server profile for %s.", p.Name)
+	if err := c.ValidateResourceScopes(p.InitialScopeUris); err != nil {
+		return err
+	}
 	var (
 		uri    = "/rest/server-profiles"
 		server ServerHardware
@@ -450,7 +607,7 @@ func (c *OVClient) SubmitNewProfile(p ServerProfile) (err error) {
 		p.ManagementProcessor = mp
 	}
 
-	data, err := c.RestAPICall(rest.POST, uri, p)
+	data, err := c.RestAPICallWithContext(ctx, rest.POST, uri, p)
 	if err != nil {
 		t.TaskIsDone = true
 		log.Errorf("Error submitting new profile request: %s", err)
@@ -464,7 +621,7 @@ func (c *OVClient) SubmitNewProfile(p ServerProfile) (err error) {
 		return err
 	}
 
-	err = t.Wait()
+	err = t.WaitWithContext(ctx)
 	if err != nil {
 		return err
 	}
@@ -472,8 +629,54 @@ func (c *OVClient) SubmitNewProfile(p ServerProfile) (err error) {
 	return nil
 }
 
+// DefaultProfileDescriptionSeparator is placed between the template description and the
+// profile name when a profile is created from a template, see WithProfileDescriptionSeparator
+const DefaultProfileDescriptionSeparator = " "
+
+// NewProfileDescription returns the description for a profile named name created from template,
+// separator is placed between the description and the name, DefaultProfileDescriptionSeparator when empty.
+// The server profile description of a template is preferred over its own description, and when
+// the source is itself a profile the suffix added for its own name is dropped, so cloning a
+// clone never compounds names into the description.
+func NewProfileDescription(template ServerProfile, name string, separator string) string {
+	if separator == "" {
+		separator = DefaultProfileDescriptionSeparator
+	}
+	description := template.ServerProfileDescription
+	if description == "" {
+		description = template.Description
+		if template.Name != "" {
+			description = strings.TrimSuffix(description, separator+template.Name)
+		}
+	}
+	if description == "" {
+		return name
+	}
+	return description + separator + name
+}
+
 // create profile from template
 func (c *OVClient) CreateProfileFromTemplate(name string, template ServerProfile, blade ServerHardware) error {
+	return c.CreateProfileFromTemplateWithContext(context.Background(), name, template, blade)
+}
+
+// CreateProfileFromTemplateWithContext creates a profile like CreateProfileFromTemplate, returning ctx.Err() once ctx is done
+func (c *OVClient) CreateProfileFromTemplateWithContext(ctx context.Context, name string, template ServerProfile, blade ServerHardware) error {
+	new_template, err := c.newProfileFromTemplate(ctx, name, template, blade)
+	if err != nil {
+		return err
+	}
+
+	err = c.SubmitNewProfileWithContext(ctx, new_template)
+	return err
+}
+
+// NewProfileFromTemplate builds the profile CreateProfileFromTemplate submits, without submitting it
+func (c *OVClient) NewProfileFromTemplate(name string, template ServerProfile, blade ServerHardware) (ServerProfile, error) {
+	return c.newProfileFromTemplate(context.Background(), name, template, blade)
+}
+
+func (c *OVClient) newProfileFromTemplate(ctx context.Context, name string, template ServerProfile, blade ServerHardware) (ServerProfile, error) {
 	log.Debugf("TEMPLATE : %+v\n", template)
 	var (
 		new_template ServerProfile
@@ -482,29 +685,15 @@ func (c *OVClient) CreateProfileFromTemplate(name string, template ServerProfile
 
 	//GET on /rest/server-profile-templates/{id}new-profile
 	log.Debugf("getting profile by URI %+v, v2", template.URI)
-	new_template, err = c.GetProfileByURI(template.URI)
+	new_template, err = c.GetProfileByURIWithContext(ctx, template.URI)
 	if err != nil {
-		return err
+		return new_template, err
 	}
-	if c.APIVersion == 200 {
-		new_template.Type = "ServerProfileV5"
-	} else if c.APIVersion == 300 {
-		new_template.Type = "ServerProfileV6"
-	} else if c.APIVersion == 500 {
-		new_template.Type = "ServerProfileV7"
-	} else if c.APIVersion == 600 {
-		new_template.Type = "ServerProfileV8"
-	} else if c.APIVersion == 800 {
-		new_template.Type = "ServerProfileV9"
-	} else if c.APIVersion == 1000 {
-		new_template.Type = "ServerProfileV10"
-	} else if c.APIVersion == 1200 {
-		new_template.Type = "ServerProfileV11"
-	} else if c.APIVersion >= 1600 {
-		new_template.Type = "ServerProfileV12"
+	if c.APIVersion >= 200 {
+		new_template.Type = DefaultServerProfileType(c.APIVersion)
 	}
 	new_template.ServerProfileTemplateURI = template.URI // create relationship
-	new_template.Description = template.ServerProfileDescription
+	new_template.Description = NewProfileDescription(template, name, c.ProfileDescriptionSeparator)
 	new_template.ConnectionSettings = ConnectionSettings{
 		Connections: template.ConnectionSettings.Connections,
 	}
@@ -513,9 +702,113 @@ func (c *OVClient) CreateProfileFromTemplate(name string, template ServerProfile
 	new_template.ServerHardwareURI = blade.URI
 	new_template.Name = name
 	log.Debugf("new_template -> %+v", new_template)
+	return new_template, nil
+}
 
-	err = c.SubmitNewProfile(new_template)
-	return err
+// CreateOptions options for CreateProfileFromTemplateWithOptions
+type CreateOptions struct {
+	PowerOnAfterCreate bool // power on the assigned server once the profile is applied
+}
+
+// CreateProfileFromTemplateWithOptions creates a profile from template like CreateProfileFromTemplate,
+// then applies the options and returns the profile as OneView reports it at the end. PowerOnAfterCreate
+// needs a blade to power on, without a blade URI an error is returned before the profile is created.
+func (c *OVClient) CreateProfileFromTemplateWithOptions(name string, template ServerProfile, blade ServerHardware, opts CreateOptions) (ServerProfile, error) {
+	var (
+		profile ServerProfile
+	)
+	if opts.PowerOnAfterCreate && blade.URI.IsNil() {
+		return profile, fmt.Errorf("Error creating server profile %s, power on after create needs a server hardware URI", name)
+	}
+	if err := c.CreateProfileFromTemplate(name, template, blade); err != nil {
+		return profile, err
+	}
+
+	if opts.PowerOnAfterCreate {
+		hardware, err := c.GetServerHardwareByUri(blade.URI)
+		if err != nil {
+			return profile, err
+		}
+		pt, err := c.SetServerPowerState(hardware, P_ON.String(), "")
+		if err == nil {
+			err = pt.Wait()
+		}
+		if err != nil {
+			log.Errorf("Unable to power on server %s, Error: %s", hardware.Name, err)
+			return profile, err
+		}
+		state, err := hardware.GetPowerState()
+		if err != nil {
+			return profile, err
+		}
+		if state != P_ON {
+			return profile, fmt.Errorf("Error server %s did not power on after creating profile %s, power state is %s", hardware.Name, name, state)
+		}
+	}
+
+	return c.GetProfileByName(name)
+}
+
+// CreateProfileWithRollback creates the profile and returns it as OneView reports it. When the
+// create task fails after the profile was created, for example in the firmware step, the partial
+// profile is deleted and the original error returned. Rollback is best effort, when the delete
+// also fails both errors are reported. A profile with the same name must not exist beforehand.
+func (c *OVClient) CreateProfileWithRollback(p ServerProfile) (ServerProfile, error) {
+	var (
+		profile ServerProfile
+	)
+	if p.Name == "" {
+		return profile, errors.New("Error creating server profile, no name provided")
+	}
+	existing, err := c.GetProfileByName(p.Name)
+	if err != nil {
+		return profile, err
+	}
+	if existing.Name == p.Name {
+		return profile, fmt.Errorf("Error creating server profile, a profile named %s already exists", p.Name)
+	}
+
+	createErr := c.SubmitNewProfile(p)
+	if createErr == nil {
+		return c.GetProfileByName(p.Name)
+	}
+
+	partial, err := c.GetProfileByName(p.Name)
+	if err != nil {
+		return profile, fmt.Errorf("%s, rollback could not look up profile %s: %s", createErr, p.Name, err)
+	}
+	if partial.Name != p.Name || partial.URI.IsNil() {
+		return profile, createErr
+	}
+	log.Warnf("Creating server profile %s failed, rolling back: %s", p.Name, createErr)
+	t, err := c.SubmitDeleteProfile(partial)
+	if err == nil {
+		err = t.Wait()
+	}
+	if err != nil {
+		log.Errorf("Error rolling back server profile %s: %s", p.Name, err)
+		return profile, fmt.Errorf("%s, rollback of profile %s failed: %s", createErr, p.Name, err)
+	}
+	return profile, createErr
+}
+
+// RefreshProfileFromServer refreshes the server hardware assigned to the profile and returns the
+// profile as read afterwards, so profiles imported from existing hardware report its current state
+func (c *OVClient) RefreshProfileFromServer(profileUri string) (ServerProfile, error) {
+	profile, err := c.GetProfileByURI(utils.NewNstring(profileUri))
+	if err != nil {
+		return profile, err
+	}
+	if profile.ServerHardwareURI.IsNil() {
+		return profile, fmt.Errorf("Error refreshing server profile %s, no server hardware assigned", profile.Name)
+	}
+
+	id := strings.TrimPrefix(profile.ServerHardwareURI.String(), "/rest/server-hardware/")
+	if err := c.RefreshServerHardware(id, ServerHardware{RefreshState: "RefreshPending"}); err != nil {
+		log.Errorf("Error refreshing server hardware of profile %s: %s", profile.Name, err)
+		return profile, err
+	}
+	return c.GetProfileByURI(profile.URI)
 }
 
 func (c *OVClient) Cleanup(template *ServerProfile) {
@@ -536,6 +829,11 @@ func (c *OVClient) Cleanup(template *ServerProfile) {
 
 // submit new profile template
 func (c *OVClient) SubmitDeleteProfile(p ServerProfile) (t *Task, err error) {
+	return c.SubmitDeleteProfileWithContext(context.Background(), p)
+}
+
+// SubmitDeleteProfileWithContext submits the delete of a profile, the request is cancelled with ctx
+func (c *OVClient) SubmitDeleteProfileWithContext(ctx context.Context, p ServerProfile) (t *Task, err error) {
 	var (
 		uri = p.URI.String()
 	)
@@ -549,7 +847,7 @@ func (c *OVClient) SubmitDeleteProfile(p ServerProfile) (t *Task, err error) {
 		t.TaskIsDone = true
 		return t, err
 	}
-	data, err := c.RestAPICall(rest.DELETE, uri, nil)
+	data, err := c.RestAPICallWithContext(ctx, rest.DELETE, uri, nil)
 	if err != nil {
 		log.Errorf("Error submitting new profile request: %s", err)
 		t.TaskIsDone = true
@@ -568,6 +866,11 @@ func (c *OVClient) SubmitDeleteProfile(p ServerProfile) (t *Task, err error) {
 
 // delete a profile, assign the server and remove the profile from the system
 func (c *OVClient) DeleteProfile(name string) error {
+	return c.DeleteProfileWithContext(context.Background(), name)
+}
+
+// DeleteProfileWithContext deletes a profile like DeleteProfile, returning ctx.Err() once ctx is done
+func (c *OVClient) DeleteProfileWithContext(ctx context.Context, name string) error {
 	// get the profile for this server
 	var (
 		servernamemsg string
@@ -577,7 +880,7 @@ func (c *OVClient) DeleteProfile(name string) error {
 	)
 
 	servernamemsg = "'no server'"
-	profile, err = c.GetProfileByName(name)
+	profile, err = c.GetProfileByNameWithContext(ctx, name)
 	if err != nil {
 		return err
 	}
@@ -601,12 +904,12 @@ func (c *OVClient) DeleteProfile(name string) error {
 		}
 
 		// submit delete task
-		t, err := c.SubmitDeleteProfile(profile)
+		t, err := c.SubmitDeleteProfileWithContext(ctx, profile)
 		if err != nil {
 			return err
 		}
 
-		err = t.Wait()
+		err = t.WaitWithContext(ctx)
 		if err != nil {
 			return err
 		}
@@ -621,6 +924,11 @@ func (c *OVClient) DeleteProfile(name string) error {
 }
 
 func (c *OVClient) UpdateServerProfile(p ServerProfile) error {
+	return c.UpdateServerProfileWithContext(context.Background(), p)
+}
+
+// UpdateServerProfileWithContext updates a profile like UpdateServerProfile, returning ctx.Err() once ctx is done
+func (c *OVClient) UpdateServerProfileWithContext(ctx context.Context, p ServerProfile) error {
 	log.Infof("Initializing update of server profile for %s.", p.Name)
 	var (
 		uri = p.URI.String()
@@ -647,7 +955,7 @@ func (c *OVClient) UpdateServerProfile(p ServerProfile) error {
 		p.ManagementProcessor = mp
 	}
 
-	data, err := c.RestAPICall(rest.PUT, uri, p)
+	data, err := c.RestAPICallWithContext(ctx, rest.PUT, uri, p)
 	if err != nil {
 		t.TaskIsDone = true
 		log.Errorf("Error submitting update server profile request: %s", err)
@@ -661,7 +969,7 @@ func (c *OVClient) UpdateServerProfile(p ServerProfile) error {
 		return err
 	}
 
-	err = t.Wait()
+	err = t.WaitWithContext(ctx)
 	if err != nil {
 		return err
 	}
@@ -669,32 +977,137 @@ func (c *OVClient) UpdateServerProfile(p ServerProfile) error {
 	return nil
 }
 
-func (c *OVClient) PatchServerProfile(p ServerProfile, request []Options) error {
+// ErrStaleResource is matched with errors.Is when an update is rejected because the eTag sent
+// no longer matches the resource, refetch the resource or call RefreshETag and retry
+var ErrStaleResource = rest.ErrStaleResource
 
-	log.Infof("Initializing update of server profile for %s.", p.Name)
+// RefreshETag re-reads the profile from p.URI and updates only its ETAG field
+func (c *OVClient) RefreshETag(p *ServerProfile) error {
+	if p == nil || p.URI.IsNil() {
+		return errors.New("Error refreshing eTag, profile URI is empty")
+	}
+	current, err := c.GetProfileByURI(p.URI)
+	if err != nil {
+		return err
+	}
+	p.ETAG = current.ETAG
+	return nil
+}
+
+// UpdateProfile submits the modified profile to p.URI and returns the task without waiting on it.
+// The request carries the profile eTag in If-Match so the appliance rejects the update with
+// ErrStaleResource when the profile was changed since it was read, clear p.ETAG to overwrite regardless.
+func (c *OVClient) UpdateProfile(p ServerProfile) (*Task, error) {
+	return c.UpdateProfileWithContext(context.Background(), p)
+}
 
+// UpdateProfileWithContext submits the profile like UpdateProfile, returning ctx.Err() once ctx is done
+func (c *OVClient) UpdateProfileWithContext(ctx context.Context, p ServerProfile) (*Task, error) {
 	var (
 		uri = p.URI.String()
 		t   *Task
 	)
+	if uri == "" {
+		return nil, errors.New("Error updating server profile, profile URI is empty")
+	}
+	log.Infof("Initializing update of server profile for %s.", p.Name)
 
 	// refresh login
 	c.RefreshLogin()
-	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	headers := c.GetAuthHeaderMap()
+	if p.ETAG != "" {
+		headers["If-Match"] = p.ETAG
+	}
+
 	t = t.NewProfileTask(c)
 	t.ResetTask()
-	log.Debugf("REST : %s \n %+v\n", uri, request)
+	log.Debugf("REST : %s \n %+v\n", uri, p)
 	log.Debugf("task -> %+v", t)
-	data, err := c.RestAPICall(rest.PATCH, uri, request)
+
+	data, err := c.RestAPICallWithOptions(ctx, rest.PUT, uri, p, rest.Options{Headers: headers})
 	if err != nil {
 		t.TaskIsDone = true
 		log.Errorf("Error submitting update server profile request: %s", err)
-		return err
+		return t, err
 	}
+
 	log.Debugf("Response update ServerProfile %s", data)
 	if err := json.Unmarshal([]byte(data), &t); err != nil {
 		t.TaskIsDone = true
 		log.Errorf("Error with task un-marshal: %s", err)
+		return t, err
+	}
+	return t, nil
+}
+
+// ReassignProfile moves the profile to the target server hardware, for example from a failed blade to a
+// spare. The source and target servers are powered off, the profile is updated and waited on, then the
+// target server is powered on. The completed update task is returned. A source that cannot be powered
+// off, as a failed blade often cannot, only logs a warning, the target failing to power off is an error.
+func (c *OVClient) ReassignProfile(p ServerProfile, target ServerHardware) (*Task, error) {
+	if target.URI.IsNil() {
+		return nil, errors.New("Error reassigning server profile, target server hardware URI is empty")
+	}
+	if target.URI == p.ServerHardwareURI {
+		return nil, fmt.Errorf("Error reassigning server profile %s, it is already assigned to %s", p.Name, target.Name)
+	}
+
+	target, err := c.GetServerHardwareByUri(target.URI)
+	if err != nil {
+		return nil, err
+	}
+	if target.ServerHardwareTypeURI != p.ServerHardwareTypeURI {
+		return nil, fmt.Errorf("Error reassigning server profile %s to %s: %w, %s is not %s", p.Name, target.Name, ErrIncompatibleHardware, target.ServerHardwareTypeURI, p.ServerHardwareTypeURI)
+	}
+	if !target.ServerProfileURI.IsNil() {
+		return nil, fmt.Errorf("Error reassigning server profile %s, %s is already assigned to profile %s", p.Name, target.Name, target.ServerProfileURI)
+	}
+
+	if !p.ServerHardwareURI.IsNil() {
+		pt, err := c.PowerOffProfile(p)
+		if err == nil {
+			err = pt.Wait()
+		}
+		if err != nil {
+			log.Warnf("Unable to power off server %s, reassigning server profile %s regardless, Error: %s", p.ServerHardwareURI, p.Name, err)
+		}
+	}
+	pt, err := c.SetServerPowerState(target, P_OFF.String(), P_PRESSANDHOLD.String())
+	if err == nil {
+		err = pt.Wait()
+	}
+	if err != nil {
+		log.Errorf("Unable to power off server %s, Error: %s", target.Name, err)
+		return nil, err
+	}
+
+	log.Infof("Reassigning server profile %s to %s.", p.Name, target.Name)
+	p.ServerHardwareURI = target.URI
+	t, err := c.UpdateProfile(p)
+	if err != nil {
+		return t, err
+	}
+	if err := t.Wait(); err != nil {
+		return t, err
+	}
+
+	pt, err = c.PowerOnProfile(p)
+	if err == nil {
+		err = pt.Wait()
+	}
+	if err != nil {
+		log.Errorf("Unable to power on server %s, Error: %s", target.Name, err)
+		return t, err
+	}
+	return t, nil
+}
+
+func (c *OVClient) PatchServerProfile(p ServerProfile, request []Options) error {
+
+	log.Infof("Initializing update of server profile for %s.", p.Name)
+
+	t, err := c.submitPatchServerProfile(p, request)
+	if err != nil {
 		return err
 	}
 
@@ -705,3 +1118,81 @@ func (c *OVClient) PatchServerProfile(p ServerProfile, request []Options) error
 
 	return nil
 }
+
+// PatchProfile submits a JSON Patch document against the profile at uri and returns the task without
+// waiting on it. OneView only allows patching a few profile fields (for example /templateCompliance,
+// /refreshState, /serverHardwareUri), any other path is rejected and the appliance error is returned as is.
+func (c *OVClient) PatchProfile(uri utils.Nstring, ops []PatchOp) (*Task, error) {
+	return c.PatchProfileWithContext(context.Background(), uri, ops)
+}
+
+// PatchProfileWithContext submits the patch like PatchProfile, returning ctx.Err() once ctx is done
+func (c *OVClient) PatchProfileWithContext(ctx context.Context, uri utils.Nstring, ops []PatchOp) (*Task, error) {
+	var t *Task
+	if uri.IsNil() {
+		return nil, errors.New("Error patching server profile, profile URI is empty")
+	}
+	if len(ops) == 0 {
+		return nil, errors.New("Error patching server profile, no patch operations provided")
+	}
+	for _, op := range ops {
+		if !containsString(PatchOps, op.Op) {
+			return nil, fmt.Errorf("Error patch operation %q is not supported, supported operations are %s", op.Op, strings.Join(PatchOps, ","))
+		}
+		if !strings.HasPrefix(op.Path, "/") {
+			return nil, fmt.Errorf("Error patch path %q must begin with a slash", op.Path)
+		}
+	}
+
+	// refresh login
+	c.RefreshLogin()
+	headers := c.GetAuthHeaderMap()
+	headers["Content-Type"] = "application/json-patch+json"
+
+	t = t.NewProfileTask(c)
+	t.ResetTask()
+	log.Debugf("REST : %s \n %+v\n", uri, ops)
+	log.Debugf("task -> %+v", t)
+	data, err := c.RestAPICallWithOptions(ctx, rest.PATCH, uri.String(), ops, rest.Options{Headers: headers})
+	if err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error submitting patch server profile request: %s", err)
+		return t, err
+	}
+	log.Debugf("Response patch ServerProfile %s", data)
+	if err := json.Unmarshal([]byte(data), &t); err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with task un-marshal: %s", err)
+		return t, err
+	}
+	return t, nil
+}
+
+// submitPatchServerProfile submits a patch of a server profile and returns the task without waiting
+func (c *OVClient) submitPatchServerProfile(p ServerProfile, request []Options) (*Task, error) {
+	var (
+		uri = p.URI.String()
+		t   *Task
+	)
+
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	t = t.NewProfileTask(c)
+	t.ResetTask()
+	log.Debugf("REST : %s \n %+v\n", uri, request)
+	log.Debugf("task -> %+v", t)
+	data, err := c.RestAPICall(rest.PATCH, uri, request)
+	if err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error submitting update server profile request: %s", err)
+		return t, err
+	}
+	log.Debugf("Response update ServerProfile %s", data)
+	if err := json.Unmarshal([]byte(data), &t); err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with task un-marshal: %s", err)
+		return t, err
+	}
+	return t, nil
+}
@@ -94,7 +94,7 @@ func (c *OVClient) CreateProfileFromTemplateWithI3S(name string, template Server
 
 	new_template.ServerHardwareURI = blade.URI
 	new_template.ServerHardwareTypeURI = blade.ServerHardwareTypeURI
-	new_template.Description += " " + name
+	new_template.Description = NewProfileDescription(template, name, c.ProfileDescriptionSeparator)
 	new_template.Name = name
 
 	err = c.SubmitNewProfile(new_template)
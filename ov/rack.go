@@ -0,0 +1,203 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/docker/machine/libmachine/log"
+)
+
+// RackMount places an enclosure, server or power device in the rack, TopUSlot is the highest
+// rack unit it occupies counting from the bottom
+type RackMount struct {
+	Location      string        `json:"location,omitempty"`      // "location": "CenterFront",
+	MountUri      utils.Nstring `json:"mountUri,omitempty"`      // "mountUri": "/rest/enclosures/0000000000A66101",
+	RelativeOrder int           `json:"relativeOrder,omitempty"` // "relativeOrder": 1,
+	TopUSlot      int           `json:"topUSlot,omitempty"`      // "topUSlot": 20,
+	UHeight       int           `json:"uHeight,omitempty"`       // "uHeight": 10
+}
+
+// Rack physical rack, dimensions are in millimeters
+type Rack struct {
+	Category     string        `json:"category,omitempty"`     // "category": "racks",
+	Created      string        `json:"created,omitempty"`      // "created": "2021-03-10T10:20:11.000Z",
+	Depth        int           `json:"depth,omitempty"`        // "depth": 1000,
+	ETAG         string        `json:"eTag,omitempty"`         // "eTag": "1",
+	Height       int           `json:"height,omitempty"`       // "height": 2004,
+	ID           string        `json:"id,omitempty"`           // "id": "Rack-221",
+	Model        string        `json:"model,omitempty"`        // "model": "HPE 42U 600mmx1075mm G2 Kitted Advanced Shock Rack",
+	Modified     string        `json:"modified,omitempty"`     // "modified": "2021-03-10T10:20:11.000Z",
+	Name         string        `json:"name,omitempty"`         // "name": "Rack-221",
+	PartNumber   string        `json:"partNumber,omitempty"`   // "partNumber": "P9K10A",
+	RackMounts   []RackMount   `json:"rackMounts"`             // "rackMounts": [],
+	SerialNumber string        `json:"serialNumber,omitempty"` // "serialNumber": "2S12345678",
+	State        string        `json:"state,omitempty"`        // "state": "Monitored",
+	Status       string        `json:"status,omitempty"`       // "status": "OK",
+	ThermalLimit int           `json:"thermalLimit,omitempty"` // "thermalLimit": 10000,
+	Type         string        `json:"type,omitempty"`         // "type": "rack",
+	UHeight      int           `json:"uHeight,omitempty"`      // "uHeight": 42,
+	URI          utils.Nstring `json:"uri,omitempty"`          // "uri": "/rest/racks/Rack-221",
+	UUID         string        `json:"uuid,omitempty"`         // "uuid": "8a1b6b1c-1a7f-4c1e-a4b1-3e1ab0b0a9c1",
+	Width        int           `json:"width,omitempty"`        // "width": 600
+}
+
+// RackList list of racks
+type RackList struct {
+	Total       int           `json:"total,omitempty"`       // "total": 1,
+	Count       int           `json:"count,omitempty"`       // "count": 1,
+	Start       int           `json:"start,omitempty"`       // "start": 0,
+	PrevPageURI utils.Nstring `json:"prevPageUri,omitempty"` // "prevPageUri": null,
+	NextPageURI utils.Nstring `json:"nextPageUri,omitempty"` // "nextPageUri": null,
+	URI         utils.Nstring `json:"uri,omitempty"`         // "uri": "/rest/racks?start=0&count=10",
+	Members     []Rack        `json:"members,omitempty"`     // "members":[]
+}
+
+// GetRackMount returns where the resource is mounted in the rack, false when it is not in the rack
+func (r Rack) GetRackMount(mountUri utils.Nstring) (RackMount, bool) {
+	for _, mount := range r.RackMounts {
+		if mount.MountUri == mountUri {
+			return mount, true
+		}
+	}
+	return RackMount{}, false
+}
+
+// GetRackByName gets a rack by name, an empty Rack is returned when not found
+func (c *OVClient) GetRackByName(name string) (Rack, error) {
+	racks, err := c.GetRacks(fmt.Sprintf("name matches '%s'", name), "name:asc", "", "")
+	if racks.Total > 0 {
+		return racks.Members[0], err
+	}
+	return Rack{}, err
+}
+
+// GetRacks gets the racks matching filter
+func (c *OVClient) GetRacks(filter string, sort string, start string, count string) (RackList, error) {
+	var (
+		uri   = "/rest/racks"
+		q     = make(map[string]interface{})
+		racks RackList
+	)
+	if len(filter) > 0 {
+		q["filter"] = filter
+	}
+	if sort != "" {
+		q["sort"] = sort
+	}
+	if start != "" {
+		q["start"] = start
+	}
+	if count != "" {
+		q["count"] = count
+	}
+
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
+	if err != nil {
+		return racks, err
+	}
+
+	log.Debugf("GetRacks %s", data)
+	if err := json.Unmarshal(data, &racks); err != nil {
+		return racks, err
+	}
+	return racks, nil
+}
+
+// CreateRack creates a rack with the resources mounted in RackMounts
+func (c *OVClient) CreateRack(rack Rack) error {
+	log.Infof("Initializing creation of rack %s.", rack.Name)
+	var (
+		uri = "/rest/racks"
+	)
+	if rack.Name == "" {
+		return errors.New("Error creating rack, no name provided")
+	}
+	if rack.RackMounts == nil {
+		rack.RackMounts = []RackMount{}
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	log.Debugf("REST : %s \n %+v\n", uri, rack)
+	data, err := c.RestAPICall(rest.POST, uri, rack)
+	if err != nil {
+		log.Errorf("Error submitting create rack request: %s", err)
+		return err
+	}
+	log.Debugf("Response create rack %s", data)
+	return nil
+}
+
+// UpdateRack updates a rack, RackMounts replaces the resources mounted in it
+func (c *OVClient) UpdateRack(rack Rack) error {
+	log.Infof("Initializing update of rack %s.", rack.Name)
+	var (
+		uri = rack.URI.String()
+	)
+	if rack.URI.IsNil() {
+		return errors.New("Error updating rack, rack URI is empty")
+	}
+	if rack.RackMounts == nil {
+		rack.RackMounts = []RackMount{}
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	log.Debugf("REST : %s \n %+v\n", uri, rack)
+	data, err := c.RestAPICall(rest.PUT, uri, rack)
+	if err != nil {
+		log.Errorf("Error submitting update rack request: %s", err)
+		return err
+	}
+	log.Debugf("Response update rack %s", data)
+	return nil
+}
+
+// DeleteRack deletes a rack, a rack that does not exist is skipped
+func (c *OVClient) DeleteRack(name string) error {
+	rack, err := c.GetRackByName(name)
+	if err != nil {
+		return err
+	}
+	if rack.URI.IsNil() {
+		log.Infof("Rack could not be found to delete, %s, skipping delete ...", name)
+		return nil
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	log.Debugf("REST : %s \n", rack.URI)
+	data, err := c.RestAPICall(rest.DELETE, rack.URI.String(), nil)
+	if err != nil {
+		log.Errorf("Error submitting delete rack request: %s", err)
+		return err
+	}
+	log.Debugf("Response delete rack %s", data)
+	return nil
+}
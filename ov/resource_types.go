@@ -0,0 +1,83 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+// versionedType is the resource type string used from a minimum api version onwards
+type versionedType struct {
+	minAPIVersion int
+	typeName      string
+}
+
+// enclosureGroupTypes lists the enclosure group type for each api version, oldest first
+var enclosureGroupTypes = []versionedType{
+	{200, "EnclosureGroupV200"},
+	{300, "EnclosureGroupV300"},
+	{500, "EnclosureGroupV400"},
+	{600, "EnclosureGroupV7"},
+	{1000, "EnclosureGroupV8"},
+}
+
+// ligTypes lists the logical interconnect group type for each api version, oldest first
+var ligTypes = []versionedType{
+	{200, "logical-interconnect-groupV3"},
+	{300, "logical-interconnect-groupV300"},
+	{600, "logical-interconnect-groupV4"},
+	{800, "logical-interconnect-groupV5"},
+	{1000, "logical-interconnect-groupV6"},
+	{1200, "logical-interconnect-groupV7"},
+	{1600, "logical-interconnect-groupV8"},
+}
+
+// serverProfileTypes lists the server profile type for each api version, oldest first
+var serverProfileTypes = []versionedType{
+	{200, "ServerProfileV5"},
+	{300, "ServerProfileV6"},
+	{500, "ServerProfileV7"},
+	{600, "ServerProfileV8"},
+	{800, "ServerProfileV9"},
+	{1000, "ServerProfileV10"},
+	{1200, "ServerProfileV11"},
+	{1600, "ServerProfileV12"},
+}
+
+// typeForVersion returns the type of the newest entry supported by the api version,
+// versions older than the first entry get the first type
+func typeForVersion(types []versionedType, apiVersion int) string {
+	typeName := types[0].typeName
+	for _, t := range types {
+		if apiVersion >= t.minAPIVersion {
+			typeName = t.typeName
+		}
+	}
+	return typeName
+}
+
+// DefaultEnclosureGroupType returns the enclosure group type expected by the api version
+func DefaultEnclosureGroupType(apiVersion int) string {
+	return typeForVersion(enclosureGroupTypes, apiVersion)
+}
+
+// DefaultLIGType returns the logical interconnect group type expected by the api version
+func DefaultLIGType(apiVersion int) string {
+	return typeForVersion(ligTypes, apiVersion)
+}
+
+// DefaultServerProfileType returns the server profile type expected by the api version
+func DefaultServerProfileType(apiVersion int) string {
+	return typeForVersion(serverProfileTypes, apiVersion)
+}
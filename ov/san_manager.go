@@ -0,0 +1,329 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/docker/machine/libmachine/log"
+)
+
+// SanConnectionInfo name and value pair used to connect to a SAN manager,
+// e.g. Host, Port, Username, Password and UseSsl
+type SanConnectionInfo struct {
+	Name        string      `json:"name,omitempty"`        // "name": "Host",
+	DisplayName string      `json:"displayName,omitempty"` // "displayName": "Host",
+	Required    bool        `json:"required,omitempty"`    // "required": true,
+	Value       interface{} `json:"value,omitempty"`       // "value": "172.18.15.1",
+	ValueFormat string      `json:"valueFormat,omitempty"` // "valueFormat": "IPAddressOrHostname",
+	ValueType   string      `json:"valueType,omitempty"`   // "valueType": "String"
+}
+
+// SanManager device manager of a SAN, such as a Brocade Network Advisor or a Cisco switch
+type SanManager struct {
+	Category             string              `json:"category,omitempty"`             // "category": "fc-device-managers",
+	ConnectionInfo       []SanConnectionInfo `json:"connectionInfo,omitempty"`       // "connectionInfo": [],
+	Created              string              `json:"created,omitempty"`              // "created": "2021-03-10T10:20:11.000Z",
+	Description          utils.Nstring       `json:"description,omitempty"`          // "description": null,
+	DeviceManagerVersion string              `json:"deviceManagerVersion,omitempty"` // "deviceManagerVersion": "14.4.1",
+	ETAG                 string              `json:"eTag,omitempty"`                 // "eTag": "2021-03-10T10:20:11.000Z",
+	IsInternal           bool                `json:"isInternal,omitempty"`           // "isInternal": false,
+	Modified             string              `json:"modified,omitempty"`             // "modified": "2021-03-10T10:20:11.000Z",
+	Name                 string              `json:"name,omitempty"`                 // "name": "172.18.15.1",
+	ProviderDisplayName  string              `json:"providerDisplayName,omitempty"`  // "providerDisplayName": "Brocade Network Advisor",
+	ProviderUri          utils.Nstring       `json:"providerUri,omitempty"`          // "providerUri": "/rest/fc-sans/providers/1",
+	RefreshState         string              `json:"refreshState,omitempty"`         // "refreshState": "NotRefreshing",
+	State                string              `json:"state,omitempty"`                // "state": "Managed",
+	Status               string              `json:"status,omitempty"`               // "status": "OK",
+	Type                 string              `json:"type,omitempty"`                 // "type": "FCDeviceManagerV2",
+	URI                  utils.Nstring       `json:"uri,omitempty"`                  // "uri": "/rest/fc-sans/device-managers/1"
+}
+
+// SanManagerList list of SAN managers
+type SanManagerList struct {
+	Total       int           `json:"total,omitempty"`       // "total": 1,
+	Count       int           `json:"count,omitempty"`       // "count": 1,
+	Start       int           `json:"start,omitempty"`       // "start": 0,
+	PrevPageURI utils.Nstring `json:"prevPageUri,omitempty"` // "prevPageUri": null,
+	NextPageURI utils.Nstring `json:"nextPageUri,omitempty"` // "nextPageUri": null,
+	URI         utils.Nstring `json:"uri,omitempty"`         // "uri": "/rest/fc-sans/device-managers?start=0&count=10",
+	Members     []SanManager  `json:"members,omitempty"`     // "members":[]
+}
+
+// ZoningPolicy automatic zoning settings of a managed SAN
+type ZoningPolicy struct {
+	EnableAliasing        bool   `json:"enableAliasing,omitempty"`        // "enableAliasing": true,
+	InitiatorNameFormat   string `json:"initiatorNameFormat,omitempty"`   // "initiatorNameFormat": "{hostName}_{initiatorWwn}",
+	TargetGroupNameFormat string `json:"targetGroupNameFormat,omitempty"` // "targetGroupNameFormat": "{storageSystemName}_{targetGroupName}",
+	TargetNameFormat      string `json:"targetNameFormat,omitempty"`      // "targetNameFormat": "{storageSystemName}_{targetName}",
+	ZoneNameFormat        string `json:"zoneNameFormat,omitempty"`        // "zoneNameFormat": "{hostName}_{initiatorWwn}",
+	ZoningType            string `json:"zoningType,omitempty"`            // "zoningType": "SingleInitiatorAllTargets"
+}
+
+// ManagedSan SAN discovered through a SAN manager
+type ManagedSan struct {
+	Category         string          `json:"category,omitempty"`         // "category": "fc-sans",
+	Created          string          `json:"created,omitempty"`          // "created": "2021-03-10T10:20:11.000Z",
+	Description      utils.Nstring   `json:"description,omitempty"`      // "description": null,
+	DeviceManagerUri utils.Nstring   `json:"deviceManagerUri,omitempty"` // "deviceManagerUri": "/rest/fc-sans/device-managers/1",
+	ETAG             string          `json:"eTag,omitempty"`             // "eTag": "2021-03-10T10:20:11.000Z",
+	FabricType       string          `json:"fabricType,omitempty"`       // "fabricType": "FabricAttach",
+	Imported         bool            `json:"imported,omitempty"`         // "imported": true,
+	IsInternal       bool            `json:"isInternal,omitempty"`       // "isInternal": false,
+	Modified         string          `json:"modified,omitempty"`         // "modified": "2021-03-10T10:20:11.000Z",
+	Name             string          `json:"name,omitempty"`             // "name": "SAN1_0",
+	NetworkUris      []utils.Nstring `json:"networkUris,omitempty"`      // "networkUris": [],
+	PrincipalSwitch  string          `json:"principalSwitch,omitempty"`  // "principalSwitch": "10:00:c4:f5:7c:12:34:56",
+	RefreshState     string          `json:"refreshState,omitempty"`     // "refreshState": "NotRefreshing",
+	SanType          string          `json:"sanType,omitempty"`          // "sanType": "Fabric",
+	State            string          `json:"state,omitempty"`            // "state": "Managed",
+	Status           string          `json:"status,omitempty"`           // "status": "OK",
+	Type             string          `json:"type,omitempty"`             // "type": "FcSanV4",
+	URI              utils.Nstring   `json:"uri,omitempty"`              // "uri": "/rest/fc-sans/managed-sans/1",
+	ZoningPolicy     *ZoningPolicy   `json:"zoningPolicy,omitempty"`     // "zoningPolicy": {},
+	ZoningState      string          `json:"zoningState,omitempty"`      // "zoningState": "Automated",
+}
+
+// ManagedSanList list of managed SANs
+type ManagedSanList struct {
+	Total       int           `json:"total,omitempty"`       // "total": 1,
+	Count       int           `json:"count,omitempty"`       // "count": 1,
+	Start       int           `json:"start,omitempty"`       // "start": 0,
+	PrevPageURI utils.Nstring `json:"prevPageUri,omitempty"` // "prevPageUri": null,
+	NextPageURI utils.Nstring `json:"nextPageUri,omitempty"` // "nextPageUri": null,
+	URI         utils.Nstring `json:"uri,omitempty"`         // "uri": "/rest/fc-sans/managed-sans?start=0&count=10",
+	Members     []ManagedSan  `json:"members,omitempty"`     // "members":[]
+}
+
+// SanEndpoint initiator or target port logged in to a managed SAN
+type SanEndpoint struct {
+	Alias      string        `json:"alias,omitempty"`      // "alias": "host1_1000000000000001",
+	Category   string        `json:"category,omitempty"`   // "category": "fc-sans",
+	Created    string        `json:"created,omitempty"`    // "created": "2021-03-10T10:20:11.000Z",
+	ETAG       string        `json:"eTag,omitempty"`       // "eTag": "2021-03-10T10:20:11.000Z",
+	FabricName string        `json:"fabricName,omitempty"` // "fabricName": "SAN1_0",
+	Modified   string        `json:"modified,omitempty"`   // "modified": "2021-03-10T10:20:11.000Z",
+	Name       string        `json:"name,omitempty"`       // "name": "10:00:00:00:00:00:00:01",
+	Type       string        `json:"type,omitempty"`       // "type": "FcEndpoint",
+	URI        utils.Nstring `json:"uri,omitempty"`        // "uri": "/rest/fc-sans/endpoints/1",
+	WWN        string        `json:"wwn,omitempty"`        // "wwn": "10:00:00:00:00:00:00:01",
+	Zones      []string      `json:"zones,omitempty"`      // "zones": ["host1_1000000000000001"]
+}
+
+// SanEndpoints list of the endpoints of a managed SAN
+type SanEndpoints struct {
+	Total       int           `json:"total,omitempty"`       // "total": 1,
+	Count       int           `json:"count,omitempty"`       // "count": 1,
+	Start       int           `json:"start,omitempty"`       // "start": 0,
+	PrevPageURI utils.Nstring `json:"prevPageUri,omitempty"` // "prevPageUri": null,
+	NextPageURI utils.Nstring `json:"nextPageUri,omitempty"` // "nextPageUri": null,
+	URI         utils.Nstring `json:"uri,omitempty"`         // "uri": "/rest/fc-sans/managed-sans/1/endpoints?start=0&count=10",
+	Members     []SanEndpoint `json:"members,omitempty"`     // "members":[]
+}
+
+// GetSanManagers gets every SAN manager
+func (c *OVClient) GetSanManagers(filter string, sort string) (SanManagerList, error) {
+	var (
+		sanManagers SanManagerList
+		q           = make(map[string]interface{})
+	)
+	if filter != "" {
+		q["filter"] = filter
+	}
+	if sort != "" {
+		q["sort"] = sort
+	}
+	err := c.Iterate("/rest/fc-sans/device-managers", q, func(raw json.RawMessage) error {
+		var sanManager SanManager
+		if err := json.Unmarshal(raw, &sanManager); err != nil {
+			return err
+		}
+		sanManagers.Members = append(sanManagers.Members, sanManager)
+		return nil
+	})
+	if err != nil {
+		return sanManagers, err
+	}
+	sanManagers.Total = len(sanManagers.Members)
+	sanManagers.Count = len(sanManagers.Members)
+	return sanManagers, nil
+}
+
+// GetSanManagerByName gets a SAN manager by name, an empty SanManager is returned when not found
+func (c *OVClient) GetSanManagerByName(name string) (SanManager, error) {
+	var (
+		sanManager SanManager
+	)
+	sanManagers, err := c.GetSanManagers(fmt.Sprintf("name='%s'", name), "name:asc")
+	if sanManagers.Total > 0 {
+		return sanManagers.Members[0], err
+	}
+	return sanManager, err
+}
+
+// AddSanManager adds a SAN manager through a SAN provider, such as
+// /rest/fc-sans/providers/{id}. The task is returned without waiting.
+func (c *OVClient) AddSanManager(providerURI utils.Nstring, connectionInfo []SanConnectionInfo) (*Task, error) {
+	var (
+		uri = providerURI.String() + "/device-managers"
+		t   *Task
+	)
+	if providerURI.IsNil() {
+		return t, errors.New("Error adding SAN manager, no provider uri provided")
+	}
+	if len(connectionInfo) == 0 {
+		return t, errors.New("Error adding SAN manager, no connection info provided")
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	t = t.NewProfileTask(c)
+	t.ResetTask()
+	log.Debugf("REST : %s \n", uri)
+	data, err := c.RestAPICall(rest.POST, uri, SanManager{ConnectionInfo: connectionInfo})
+	if err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error submitting add SAN manager request: %s", err)
+		return t, err
+	}
+
+	log.Debugf("Response add SAN manager %s", data)
+	if err := json.Unmarshal([]byte(data), &t); err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with task un-marshal: %s", err)
+		return t, err
+	}
+	return t, nil
+}
+
+// RefreshSanManager requests a refresh of a SAN manager and the SANs it manages,
+// the task is returned without waiting
+func (c *OVClient) RefreshSanManager(sanManager SanManager) (*Task, error) {
+	var (
+		t *Task
+	)
+	if sanManager.URI.IsNil() {
+		return t, errors.New("Error refreshing SAN manager, no uri provided")
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	t = t.NewProfileTask(c)
+	t.ResetTask()
+	log.Debugf("REST : %s \n", sanManager.URI)
+	data, err := c.RestAPICall(rest.PUT, sanManager.URI.String(), map[string]string{"refreshState": "RefreshPending"})
+	if err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error submitting refresh SAN manager request: %s", err)
+		return t, err
+	}
+
+	log.Debugf("Response refresh SAN manager %s", data)
+	if err := json.Unmarshal([]byte(data), &t); err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with task un-marshal: %s", err)
+		return t, err
+	}
+	return t, nil
+}
+
+// GetManagedSans gets every managed SAN
+func (c *OVClient) GetManagedSans(filter string, sort string) (ManagedSanList, error) {
+	var (
+		managedSans ManagedSanList
+		q           = make(map[string]interface{})
+	)
+	if filter != "" {
+		q["filter"] = filter
+	}
+	if sort != "" {
+		q["sort"] = sort
+	}
+	err := c.Iterate("/rest/fc-sans/managed-sans", q, func(raw json.RawMessage) error {
+		var managedSan ManagedSan
+		if err := json.Unmarshal(raw, &managedSan); err != nil {
+			return err
+		}
+		managedSans.Members = append(managedSans.Members, managedSan)
+		return nil
+	})
+	if err != nil {
+		return managedSans, err
+	}
+	managedSans.Total = len(managedSans.Members)
+	managedSans.Count = len(managedSans.Members)
+	return managedSans, nil
+}
+
+// GetManagedSanByName gets a managed SAN by name, an empty ManagedSan is returned when not found
+func (c *OVClient) GetManagedSanByName(name string) (ManagedSan, error) {
+	var (
+		managedSan ManagedSan
+	)
+	managedSans, err := c.GetManagedSans(fmt.Sprintf("name='%s'", name), "name:asc")
+	if managedSans.Total > 0 {
+		return managedSans.Members[0], err
+	}
+	return managedSan, err
+}
+
+// GetManagedSanEndpoints gets every endpoint logged in to a managed SAN
+func (c *OVClient) GetManagedSanEndpoints(sanURI utils.Nstring) (SanEndpoints, error) {
+	var (
+		endpoints SanEndpoints
+	)
+	if sanURI.IsNil() {
+		return endpoints, errors.New("Error getting managed SAN endpoints, no SAN uri provided")
+	}
+	err := c.Iterate(sanURI.String()+"/endpoints", nil, func(raw json.RawMessage) error {
+		var endpoint SanEndpoint
+		if err := json.Unmarshal(raw, &endpoint); err != nil {
+			return err
+		}
+		endpoints.Members = append(endpoints.Members, endpoint)
+		return nil
+	})
+	if err != nil {
+		return endpoints, err
+	}
+	endpoints.Total = len(endpoints.Members)
+	endpoints.Count = len(endpoints.Members)
+	return endpoints, nil
+}
+
+// GetManagedSanZones groups the endpoints of a managed SAN by the zones they are members of,
+// endpoints that are not zoned are left out
+func (c *OVClient) GetManagedSanZones(sanURI utils.Nstring) (map[string][]SanEndpoint, error) {
+	zones := make(map[string][]SanEndpoint)
+	endpoints, err := c.GetManagedSanEndpoints(sanURI)
+	if err != nil {
+		return zones, err
+	}
+	for _, endpoint := range endpoints.Members {
+		for _, zone := range endpoint.Zones {
+			zones[zone] = append(zones[zone], endpoint)
+		}
+	}
+	return zones, nil
+}
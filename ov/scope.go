@@ -2,10 +2,13 @@ package ov
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/HewlettPackard/oneview-golang/rest"
 	"github.com/HewlettPackard/oneview-golang/utils"
 	"github.com/docker/machine/libmachine/log"
+	"strconv"
+	"strings"
 )
 
 type Scope struct {
@@ -56,55 +59,268 @@ func (c *OVClient) GetScopeByName(name string) (Scope, error) {
 	}
 }
 
+// ScopeQueryOptions are the query parameters accepted when listing scopes, zero values are not sent
+type ScopeQueryOptions struct {
+	Query  string // full text query, e.g. name matches 'prod%'
+	Filter string // attribute filter, e.g. "name='prod'"
+	View   string // named view of the scopes
+	Sort   string // attribute and direction, e.g. name:asc
+	Count  int    // page size, the appliance default when 0
+	Start  int    // index of the first scope returned
+}
+
+// GetScopes lists scopes, count and start must be numbers when given
 func (c *OVClient) GetScopes(count string, query string, start string, view string, sort string) (ScopeList, error) {
+	opts := ScopeQueryOptions{Query: query, View: view, Sort: sort}
+	var err error
+	if count != "" {
+		if opts.Count, err = strconv.Atoi(count); err != nil {
+			return ScopeList{}, fmt.Errorf("Error invalid scope count %q: %s", count, err)
+		}
+	}
+	if start != "" {
+		if opts.Start, err = strconv.Atoi(start); err != nil {
+			return ScopeList{}, fmt.Errorf("Error invalid scope start %q: %s", start, err)
+		}
+	}
+	return c.GetScopesWithOptions(opts)
+}
+
+// GetScopesWithOptions lists scopes using the named query options
+func (c *OVClient) GetScopesWithOptions(opts ScopeQueryOptions) (ScopeList, error) {
 	var (
 		uri    = "/rest/scopes"
-		q      map[string]interface{}
 		Scopes ScopeList
 	)
-	q = make(map[string]interface{})
-	if len(query) > 0 {
-		q["query"] = query
+	q, err := opts.query()
+	if err != nil {
+		return Scopes, err
 	}
 
-	if sort != "" {
-		q["sort"] = sort
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
+	if err != nil {
+		return Scopes, err
 	}
 
-	if start != "" {
-		q["start"] = start
+	log.Debugf("GetScopes %s", data)
+	if err := json.Unmarshal([]byte(data), &Scopes); err != nil {
+		return Scopes, err
 	}
+	return Scopes, nil
+}
 
-	if count != "" {
-		q["count"] = count
+// GetAllScopes lists every scope matching the query options, following nextPageUri until the last
+// page. Count is the size of each page and Start the index of the first scope, Total and Count of
+// the result are the number of scopes returned.
+func (c *OVClient) GetAllScopes(opts ScopeQueryOptions) (ScopeList, error) {
+	var scopes ScopeList
+	q, err := opts.query()
+	if err != nil {
+		return scopes, err
+	}
+	err = c.Iterate("/rest/scopes", q, func(raw json.RawMessage) error {
+		var scope Scope
+		if err := json.Unmarshal(raw, &scope); err != nil {
+			return err
+		}
+		scopes.Members = append(scopes.Members, scope)
+		return nil
+	})
+	if err != nil {
+		return scopes, err
 	}
+	scopes.Total = len(scopes.Members)
+	scopes.Count = len(scopes.Members)
+	return scopes, nil
+}
 
-	if view != "" {
-		q["view"] = view
+// query validates the options and returns the query parameters to send
+func (opts ScopeQueryOptions) query() (map[string]interface{}, error) {
+	if opts.Count < 0 {
+		return nil, fmt.Errorf("Error scope count must not be negative, got %d", opts.Count)
+	}
+	if opts.Start < 0 {
+		return nil, fmt.Errorf("Error scope start must not be negative, got %d", opts.Start)
+	}
+	q := make(map[string]interface{})
+	if len(opts.Query) > 0 {
+		q["query"] = opts.Query
 	}
 
-	// refresh login
-	c.RefreshLogin()
-	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	// Setup query
-	if len(q) > 0 {
-		c.SetQueryString(q)
+	if opts.Filter != "" {
+		q["filter"] = opts.Filter
 	}
 
-	data, err := c.RestAPICall(rest.GET, uri, nil)
-	if err != nil {
-		return Scopes, err
+	if opts.Sort != "" {
+		q["sort"] = opts.Sort
 	}
 
-	log.Debugf("GetScopes %s", data)
-	if err := json.Unmarshal([]byte(data), &Scopes); err != nil {
-		return Scopes, err
+	if opts.Start > 0 {
+		q["start"] = strconv.Itoa(opts.Start)
 	}
-	return Scopes, nil
+
+	if opts.Count > 0 {
+		q["count"] = strconv.Itoa(opts.Count)
+	}
+
+	if opts.View != "" {
+		q["view"] = opts.View
+	}
+	return q, nil
+}
+
+// ScopeAssignableResourceTypes lists the resource collections whose members can be assigned to a scope
+var ScopeAssignableResourceTypes = []string{
+	"/rest/enclosures",
+	"/rest/enclosure-groups",
+	"/rest/ethernet-networks",
+	"/rest/fc-networks",
+	"/rest/fcoe-networks",
+	"/rest/firmware-drivers",
+	"/rest/hypervisor-cluster-profiles",
+	"/rest/hypervisor-managers",
+	"/rest/interconnects",
+	"/rest/logical-enclosures",
+	"/rest/logical-interconnect-groups",
+	"/rest/logical-interconnects",
+	"/rest/logical-switch-groups",
+	"/rest/logical-switches",
+	"/rest/network-sets",
+	"/rest/os-deployment-plans",
+	"/rest/rack-managers",
+	"/rest/sas-interconnects",
+	"/rest/sas-logical-interconnect-groups",
+	"/rest/sas-logical-interconnects",
+	"/rest/server-hardware",
+	"/rest/server-profile-templates",
+	"/rest/server-profiles",
+	"/rest/storage-pools",
+	"/rest/storage-systems",
+	"/rest/storage-volume-templates",
+	"/rest/storage-volumes",
+	"/rest/switches",
+	"/rest/uplink-sets",
+}
+
+// scopeResourceTypeVersions is the api version a resource collection became scope assignable,
+// collections not listed have been assignable since scopes were introduced
+var scopeResourceTypeVersions = map[string]int{
+	"/rest/firmware-drivers":                500,
+	"/rest/logical-switch-groups":           500,
+	"/rest/logical-switches":                500,
+	"/rest/os-deployment-plans":             500,
+	"/rest/sas-interconnects":               500,
+	"/rest/sas-logical-interconnect-groups": 500,
+	"/rest/sas-logical-interconnects":       500,
+	"/rest/server-profile-templates":        500,
+	"/rest/server-profiles":                 500,
+	"/rest/storage-pools":                   500,
+	"/rest/storage-systems":                 500,
+	"/rest/storage-volume-templates":        500,
+	"/rest/storage-volumes":                 500,
+	"/rest/switches":                        500,
+	"/rest/uplink-sets":                     600,
+	"/rest/hypervisor-cluster-profiles":     800,
+	"/rest/hypervisor-managers":             800,
+	"/rest/rack-managers":                   1600,
+}
+
+// ScopeAssignableResourceTypesForVersion returns the categories of the resources that can be
+// assigned to a scope on an appliance with the api version, e.g. ethernet-networks
+func ScopeAssignableResourceTypesForVersion(apiVersion int) []string {
+	var categories []string
+	if apiVersion < 300 {
+		return categories
+	}
+	for _, resourceType := range ScopeAssignableResourceTypes {
+		if apiVersion >= scopeResourceTypeVersions[resourceType] {
+			categories = append(categories, strings.TrimPrefix(resourceType, "/rest/"))
+		}
+	}
+	return categories
+}
+
+// GetScopeAssignableResourceTypes returns the categories of the resources that can be assigned
+// to a scope on the appliance. OneView does not publish this list, so it is derived from the
+// api version of the appliance.
+func (c *OVClient) GetScopeAssignableResourceTypes() ([]string, error) {
+	if c.APIVersion == 0 {
+		if err := c.RefreshVersion(); err != nil {
+			return nil, err
+		}
+	}
+	return ScopeAssignableResourceTypesForVersion(c.APIVersion), nil
+}
+
+// isScopeAssignableResourceUri checks the uri is a member of a scope assignable resource collection
+func isScopeAssignableResourceUri(uri string) bool {
+	for _, resourceType := range ScopeAssignableResourceTypes {
+		if strings.HasPrefix(uri, resourceType+"/") && len(uri) > len(resourceType)+1 {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateScopeResourceUris checks every uri is a scope assignable resource type and that the
+// resource exists, returning a single error listing each invalid uri. Errors other than a
+// resource not being found are returned unchanged.
+func (c *OVClient) ValidateScopeResourceUris(uris []utils.Nstring) error {
+	var problems []string
+	for _, uri := range uris {
+		if !isScopeAssignableResourceUri(uri.String()) {
+			problems = append(problems, fmt.Sprintf("%s is not a scope assignable resource", uri))
+			continue
+		}
+		c.RefreshLogin()
+		c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+		if _, err := c.RestAPICall(rest.GET, uri.String(), nil); err != nil {
+			if !rest.IsNotFound(err) {
+				return err
+			}
+			problems = append(problems, fmt.Sprintf("%s could not be found", uri))
+		}
+	}
+	if len(problems) > 0 {
+		return errors.New("Error validating scope resources: " + strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// ValidateResourceScopes checks every scope uri given as initial scopes of a new resource
+// still resolves, returning a single error listing the missing scopes. Errors other than
+// a scope not being found are returned unchanged.
+func (c *OVClient) ValidateResourceScopes(uris []utils.Nstring) error {
+	var missing []string
+	for _, uri := range uris {
+		if !strings.HasPrefix(uri.String(), "/rest/scopes/") {
+			missing = append(missing, uri.String())
+			continue
+		}
+		c.RefreshLogin()
+		c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+		if _, err := c.RestAPICall(rest.GET, uri.String(), nil); err != nil {
+			if !rest.IsNotFound(err) {
+				return err
+			}
+			log.Debugf("ValidateResourceScopes %s: %s", uri, err)
+			missing = append(missing, uri.String())
+		}
+	}
+	if len(missing) > 0 {
+		return errors.New("Error validating scopes, scopes not found: " + strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 func (c *OVClient) CreateScope(scp Scope) error {
 	log.Infof("Initializing creation of scope for %s.", scp.Name)
+	if err := c.ValidateScopeResourceUris(scp.AddedResourceUris); err != nil {
+		return err
+	}
 	var (
 		uri = "/rest/scopes"
 		t   *Task
@@ -308,3 +524,167 @@ func (c *OVClient) GetScopeByUri(uri string) (Scope, error) {
 	}
 	return scope, nil
 }
+
+// resourceInScope checks whether the resource is already assigned to the scope
+func (c *OVClient) resourceInScope(scopeURI, resourceURI utils.Nstring) (bool, error) {
+	assigned, err := c.GetScopeFromResource(resourceURI.String())
+	if err != nil {
+		return false, err
+	}
+	for _, uri := range assigned.ScopeUris {
+		if uri == scopeURI.String() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// validateScopeAssignment checks the scope and resource uris before changing the scope resource assignments
+func validateScopeAssignment(scopeURI, resourceURI utils.Nstring) error {
+	if !strings.HasPrefix(scopeURI.String(), "/rest/scopes/") {
+		return fmt.Errorf("Error %q is not a scope uri", scopeURI)
+	}
+	if !isScopeAssignableResourceUri(resourceURI.String()) {
+		return fmt.Errorf("Error %s is not a scope assignable resource", resourceURI)
+	}
+	return nil
+}
+
+// ScopeResourceAssignments resources added to and removed from a scope in one resource assignments request
+type ScopeResourceAssignments struct {
+	AddedResourceUris   []utils.Nstring `json:"addedResourceUris,omitempty"`   // "addedResourceUris": ["/rest/ethernet-networks/6d0f7c41-9d1d-4de4-92ef-21a15bb0e8d0"],
+	RemovedResourceUris []utils.Nstring `json:"removedResourceUris,omitempty"` // "removedResourceUris": ["/rest/ethernet-networks/6d0f7c41-9d1d-4de4-92ef-21a15bb0e8d0"]
+}
+
+// updateScopeResourceAssignments submits the assignments to the resource assignments of the scope
+// and waits on the task when the appliance returns one
+func (c *OVClient) updateScopeResourceAssignments(scopeURI utils.Nstring, assignments ScopeResourceAssignments) error {
+	var (
+		uri = scopeURI.String() + "/resource-assignments"
+		t   *Task
+	)
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	t = t.NewProfileTask(c)
+	t.ResetTask()
+	log.Debugf("REST : %s \n %+v\n", uri, assignments)
+	log.Debugf("task -> %+v", t)
+	data, err := c.RestAPICall(rest.PATCH, uri, assignments)
+	if err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error submitting scope resource assignments request: %s", err)
+		return err
+	}
+
+	log.Debugf("Response scope resource assignments %s", data)
+	if err := json.Unmarshal([]byte(data), &t); err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with task un-marshal: %s", err)
+		return err
+	}
+	if !strings.HasPrefix(t.URI.String(), "/rest/tasks/") {
+		// the updated scope was returned rather than a task
+		return nil
+	}
+	return t.Wait()
+}
+
+// AddResourceToScope assigns the resource to an existing scope, an error is returned when the
+// resource is already in the scope
+func (c *OVClient) AddResourceToScope(scopeURI, resourceURI utils.Nstring) error {
+	if err := validateScopeAssignment(scopeURI, resourceURI); err != nil {
+		return err
+	}
+	found, err := c.resourceInScope(scopeURI, resourceURI)
+	if err != nil {
+		return err
+	}
+	if found {
+		return fmt.Errorf("Error resource %s is already in scope %s", resourceURI, scopeURI)
+	}
+	log.Infof("Adding %s to scope %s.", resourceURI, scopeURI)
+	return c.updateScopeResourceAssignments(scopeURI, ScopeResourceAssignments{AddedResourceUris: []utils.Nstring{resourceURI}})
+}
+
+// RemoveResourceFromScope unassigns the resource from an existing scope, an error is returned when
+// the resource is not in the scope
+func (c *OVClient) RemoveResourceFromScope(scopeURI, resourceURI utils.Nstring) error {
+	if err := validateScopeAssignment(scopeURI, resourceURI); err != nil {
+		return err
+	}
+	found, err := c.resourceInScope(scopeURI, resourceURI)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("Error resource %s is not in scope %s", resourceURI, scopeURI)
+	}
+	log.Infof("Removing %s from scope %s.", resourceURI, scopeURI)
+	return c.updateScopeResourceAssignments(scopeURI, ScopeResourceAssignments{RemovedResourceUris: []utils.Nstring{resourceURI}})
+}
+
+// DefaultScopeAssignmentBatchSize is the most resource uris AddResourcesToScope sends in one resource
+// assignments request unless WithScopeAssignmentBatchSize sets another size. OneView does not document
+// a limit, the batches only bound what one rejected request fails, as the appliance rejects a request
+// as a whole when any of its uris is invalid.
+const DefaultScopeAssignmentBatchSize = 100
+
+// ScopeAssignmentError is returned by AddResourcesToScope when some batches were rejected,
+// Succeeded lists the uris of the batches that were accepted
+type ScopeAssignmentError struct {
+	Succeeded []utils.Nstring
+	Failed    []utils.Nstring
+	Err       error
+}
+
+func (e *ScopeAssignmentError) Error() string {
+	failed := make([]string, len(e.Failed))
+	for i, uri := range e.Failed {
+		failed[i] = uri.String()
+	}
+	return fmt.Sprintf("Error assigning %d of %d resources to scope: %s: %s", len(e.Failed), len(e.Failed)+len(e.Succeeded), strings.Join(failed, ", "), e.Err)
+}
+
+func (e *ScopeAssignmentError) Unwrap() error {
+	return e.Err
+}
+
+// AddResourcesToScope assigns the resources to an existing scope, sending one resource assignments
+// request per batch of at most the client ScopeAssignmentBatchSize uris, DefaultScopeAssignmentBatchSize
+// when unset. A *ScopeAssignmentError is returned when the appliance rejects some of the batches.
+func (c *OVClient) AddResourcesToScope(scopeURI utils.Nstring, resourceURIs []utils.Nstring) error {
+	for _, uri := range resourceURIs {
+		if err := validateScopeAssignment(scopeURI, uri); err != nil {
+			return err
+		}
+	}
+	var (
+		result  ScopeAssignmentError
+		lastErr error
+	)
+	size := c.ScopeAssignmentBatchSize
+	if size <= 0 {
+		size = DefaultScopeAssignmentBatchSize
+	}
+	for start := 0; start < len(resourceURIs); start += size {
+		end := start + size
+		if end > len(resourceURIs) {
+			end = len(resourceURIs)
+		}
+		batch := resourceURIs[start:end]
+		log.Infof("Adding %d resources to scope %s.", len(batch), scopeURI)
+		if err := c.updateScopeResourceAssignments(scopeURI, ScopeResourceAssignments{AddedResourceUris: batch}); err != nil {
+			result.Failed = append(result.Failed, batch...)
+			lastErr = err
+			continue
+		}
+		result.Succeeded = append(result.Succeeded, batch...)
+	}
+	if lastErr != nil {
+		result.Err = lastErr
+		return &result
+	}
+	return nil
+}
@@ -416,7 +416,7 @@ func (c *OVClient) GetServerHardwareList(filters []string, sort string, start st
 
 	if count == "" {
 		total := strconv.Itoa(serverlist.Total)
-		return c.GetServerHardwareList(filters, "", "", total, "")
+		return c.GetServerHardwareList(filters, sort, "", total, expand)
 	}
 
 	for i := 0; i < serverlist.Count; i++ {
@@ -607,6 +607,57 @@ func (c *OVClient) SetOneTimeBoot(serverHardwareId string, value string) error {
 	return nil
 }
 
+// OneTimeBootDevices lists the devices accepted by SetServerHardwareOneTimeBoot, mapped to the
+// server hardware type boot capability they require
+var OneTimeBootDevices = map[string]string{
+	"Normal":  "",
+	"CD":      "CD",
+	"Floppy":  "FloppyDrive",
+	"USB":     "USB",
+	"HDD":     "HardDisk",
+	"Network": "PXE",
+}
+
+// oneTimeBootAliases maps boot order device names to their one time boot device
+var oneTimeBootAliases = map[string]string{
+	"PXE":      "Network",
+	"HardDisk": "HDD",
+}
+
+// SetServerHardwareOneTimeBoot sets the device the server boots from on its next boot only,
+// the persistent boot order of the profile is left unchanged. Boot order names PXE and HardDisk
+// are accepted for Network and HDD, and Normal clears the override. The device is checked
+// against the boot capabilities of the server hardware type. The task is returned without waiting.
+func (c *OVClient) SetServerHardwareOneTimeBoot(uri string, device string) (*Task, error) {
+	var (
+		t *Task
+	)
+	if alias, ok := oneTimeBootAliases[device]; ok {
+		device = alias
+	}
+	capability, ok := OneTimeBootDevices[device]
+	if !ok {
+		return t, fmt.Errorf("Error unknown one time boot device %q, valid devices are Normal, CD, Floppy, USB, HDD and Network", device)
+	}
+
+	hardware, err := c.GetServerHardwareByUri(utils.NewNstring(uri))
+	if err != nil {
+		return t, err
+	}
+	if capability != "" && !hardware.ServerHardwareTypeURI.IsNil() {
+		sht, err := c.GetServerHardwareTypeByUri(hardware.ServerHardwareTypeURI)
+		if err != nil {
+			return t, err
+		}
+		if len(sht.BootCapabilities) > 0 && !containsString(sht.BootCapabilities, capability) {
+			return t, fmt.Errorf("Error server hardware %s does not support one time boot from %s, boot capabilities are %s", hardware.Name, device, strings.Join(sht.BootCapabilities, ","))
+		}
+	}
+
+	operation := []PatchData{{Op: "replace", Path: "/oneTimeBoot", Value: device}}
+	return c.submitServerHardwarePatch(hardware.URI.String(), operation)
+}
+
 func (c *OVClient) PatchPowerState(id string, operation []PatchPowerData) error {
 	var (
 		uri = "/rest/server-hardware/" + id
@@ -643,9 +694,23 @@ func (c *OVClient) PatchPowerState(id string, operation []PatchPowerData) error
 
 // Performs patch operation to update SH attributes
 func (c *OVClient) Patch(id string, operation []PatchData) error {
+	t, err := c.submitServerHardwarePatch("/rest/server-hardware/"+id, operation)
+	if err != nil {
+		return err
+	}
+
+	err = t.Wait()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// submitServerHardwarePatch submits a patch of a server hardware and returns the task without waiting
+func (c *OVClient) submitServerHardwarePatch(uri string, operation []PatchData) (*Task, error) {
 	var (
-		uri = "/rest/server-hardware/" + id
-		t   *Task
+		t *Task
 	)
 	// refresh login
 	c.RefreshLogin()
@@ -658,22 +723,16 @@ func (c *OVClient) Patch(id string, operation []PatchData) error {
 	if err != nil {
 		t.TaskIsDone = true
 		log.Errorf("Error while doing Patch: %s", err)
-		return err
+		return t, err
 	}
 
 	log.Debugf("Response of Patch %s", data)
 	if err := json.Unmarshal([]byte(data), &t); err != nil {
 		t.TaskIsDone = true
 		log.Errorf("Error with task un-marshal: %s", err)
-		return err
+		return t, err
 	}
-
-	err = t.Wait()
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return t, nil
 }
 
 // Update the server into/out of maintenance mode
@@ -694,6 +753,60 @@ func (c *OVClient) SetMaintenanceMode(serverHardwareId string, value string) err
 	return nil
 }
 
+// MaintenanceModeMinimumAPIVersion is the first api version with the native maintenanceMode patch
+var MaintenanceModeMinimumAPIVersion = 1200
+
+// MaintenanceLabel marks server hardware in maintenance on appliances without native maintenance mode
+const MaintenanceLabel = "maintenance"
+
+// SetServerHardwareMaintenanceMode puts the server hardware into or out of maintenance mode,
+// suppressing alerts while work is done on it. The patch task is returned without waiting.
+// Appliances older than MaintenanceModeMinimumAPIVersion have no maintenance mode, there the
+// MaintenanceLabel label is added or removed instead and a nil task is returned. The label
+// only records the state for automation, the appliance keeps raising alerts.
+func (c *OVClient) SetServerHardwareMaintenanceMode(uri string, enabled bool) (*Task, error) {
+	var (
+		t *Task
+	)
+	if uri == "" {
+		return t, errors.New("Error setting maintenance mode, no server hardware uri provided")
+	}
+	if c.APIVersion == 0 {
+		if err := c.RefreshVersion(); err != nil {
+			return t, err
+		}
+	}
+	if c.APIVersion >= MaintenanceModeMinimumAPIVersion {
+		operation := []PatchData{{Op: "replace", Path: "/maintenanceMode", Value: strconv.FormatBool(enabled)}}
+		return c.submitServerHardwarePatch(uri, operation)
+	}
+
+	log.Warnf("Maintenance mode is not supported by api version %d, labelling %s instead", c.APIVersion, uri)
+	assigned, err := c.GetAssignedLabels(utils.NewNstring(uri))
+	if err != nil {
+		return t, err
+	}
+	labels := []Label{}
+	for _, label := range assigned.Labels {
+		if label.Name != MaintenanceLabel {
+			labels = append(labels, label)
+		}
+	}
+	if enabled {
+		labels = append(labels, Label{Name: MaintenanceLabel})
+	}
+	if len(labels) == len(assigned.Labels) && !enabled {
+		return t, nil
+	}
+	assigned.ResourceUri = utils.NewNstring(uri)
+	assigned.Labels = labels
+	if _, err := c.UpdateAssignedLabels(assigned); err != nil {
+		log.Errorf("Error while labelling maintenance mode: %s", err)
+		return t, err
+	}
+	return t, nil
+}
+
 // Turn the server UID light On/Off
 func (c *OVClient) SetUidState(serverHardwareId string, value string) error {
 	patchOperation := PatchData{
@@ -0,0 +1,109 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/docker/machine/libmachine/log"
+)
+
+// PsuInfo power supply details reported in the environmental configuration
+type PsuInfo struct {
+	InputVoltage    int    `json:"inputVoltage,omitempty"`    // "inputVoltage": 200,
+	PsuId           int    `json:"psuId,omitempty"`           // "psuId": 1,
+	PsuModel        string `json:"psuModel,omitempty"`        // "psuModel": "800W FS Plat Ht Plg Pwr Supply Kit",
+	PsuSerialNumber string `json:"psuSerialNumber,omitempty"` // "psuSerialNumber": "5BXRA0ALL7S2NB",
+	PsuRating       int    `json:"psuRating,omitempty"`       // "psuRating": 800,
+}
+
+// ServerHardwareEnvironmentalConfiguration calibrated power and thermal settings of a server hardware
+type ServerHardwareEnvironmentalConfiguration struct {
+	CalibratedMaxPower           int           `json:"calibratedMaxPower,omitempty"`           // "calibratedMaxPower": 450,
+	CapHistorySupported          bool          `json:"capHistorySupported,omitempty"`          // "capHistorySupported": true,
+	HistoryBufferSize            int           `json:"historyBufferSize,omitempty"`            // "historyBufferSize": 288,
+	HistorySampleIntervalSeconds int           `json:"historySampleIntervalSeconds,omitempty"` // "historySampleIntervalSeconds": 300,
+	IdleMaxPower                 int           `json:"idleMaxPower,omitempty"`                 // "idleMaxPower": 120,
+	LicenseRequiresUpgrade       bool          `json:"licenseRequiresUpgrade,omitempty"`       // "licenseRequiresUpgrade": false,
+	PowerHistorySupported        bool          `json:"powerHistorySupported,omitempty"`        // "powerHistorySupported": true,
+	PsuList                      []PsuInfo     `json:"psuList,omitempty"`                      // "psuList": [],
+	ThermalHistorySupported      bool          `json:"thermalHistorySupported,omitempty"`      // "thermalHistorySupported": true,
+	URI                          utils.Nstring `json:"uri,omitempty"`                          // "uri": "/rest/server-hardware/30373237-3132-4D32-3235-303930524D57/environmentalConfiguration"
+}
+
+// GetServerHardwareEnvironmentalConfiguration gets the environmental configuration of a server hardware
+func (c *OVClient) GetServerHardwareEnvironmentalConfiguration(uri utils.Nstring) (ServerHardwareEnvironmentalConfiguration, error) {
+	var (
+		envConfig ServerHardwareEnvironmentalConfiguration
+	)
+	if uri.IsNil() {
+		return envConfig, errors.New("Error getting environmental configuration, no server hardware uri provided")
+	}
+
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	data, err := c.RestAPICall(rest.GET, uri.String()+"/environmentalConfiguration", nil)
+	if err != nil {
+		return envConfig, err
+	}
+
+	log.Debugf("GetServerHardwareEnvironmentalConfiguration %s", data)
+	if err := json.Unmarshal([]byte(data), &envConfig); err != nil {
+		return envConfig, err
+	}
+	return envConfig, nil
+}
+
+// SetServerHardwareEnvironmentalConfiguration sets the calibrated maximum power (watts) used
+// for power capping of a server hardware and returns the updated environmental configuration
+func (c *OVClient) SetServerHardwareEnvironmentalConfiguration(uri utils.Nstring, envConfig ServerHardwareEnvironmentalConfiguration) (ServerHardwareEnvironmentalConfiguration, error) {
+	var (
+		updated ServerHardwareEnvironmentalConfiguration
+	)
+	if uri.IsNil() {
+		return updated, errors.New("Error setting environmental configuration, no server hardware uri provided")
+	}
+	if envConfig.CalibratedMaxPower <= 0 {
+		return updated, fmt.Errorf("Error setting environmental configuration, calibrated max power must be greater than 0, got %d", envConfig.CalibratedMaxPower)
+	}
+
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	// only the calibrated max power can be changed
+	request := ServerHardwareEnvironmentalConfiguration{CalibratedMaxPower: envConfig.CalibratedMaxPower}
+	log.Debugf("REST : %s \n %+v\n", uri.String()+"/environmentalConfiguration", request)
+	data, err := c.RestAPICall(rest.PUT, uri.String()+"/environmentalConfiguration", request)
+	if err != nil {
+		log.Errorf("Error submitting update environmental configuration request: %s", err)
+		return updated, err
+	}
+
+	log.Debugf("Response update environmental configuration %s", data)
+	if err := json.Unmarshal([]byte(data), &updated); err != nil {
+		return updated, err
+	}
+	return updated, nil
+}
@@ -0,0 +1,155 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// inventory export formats accepted by ExportServerHardwareInventory
+const (
+	INVENTORY_FORMAT_CSV  = "csv"
+	INVENTORY_FORMAT_JSON = "json"
+)
+
+// ServerHardwareInventoryRecord is the flattened view of one server hardware in an inventory export
+type ServerHardwareInventoryRecord struct {
+	Name               string `json:"name"`
+	SerialNumber       string `json:"serialNumber"`
+	Model              string `json:"model"`
+	ServerHardwareType string `json:"serverHardwareType"`
+	Enclosure          string `json:"enclosure"`
+	Bay                int    `json:"bay,omitempty"`
+	PowerState         string `json:"powerState"`
+	ServerProfile      string `json:"serverProfile"`
+}
+
+// inventoryCsvHeader is the header row of a csv inventory export
+var inventoryCsvHeader = []string{"name", "serialNumber", "model", "serverHardwareType", "enclosure", "bay", "powerState", "serverProfile"}
+
+// MarshalServerHardwareInventory encodes inventory records as csv or json
+func MarshalServerHardwareInventory(records []ServerHardwareInventoryRecord, format string) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case INVENTORY_FORMAT_JSON:
+		if records == nil {
+			records = []ServerHardwareInventoryRecord{}
+		}
+		return json.MarshalIndent(records, "", "  ")
+	case INVENTORY_FORMAT_CSV:
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		if err := w.Write(inventoryCsvHeader); err != nil {
+			return nil, err
+		}
+		for _, r := range records {
+			bay := ""
+			if r.Bay > 0 {
+				bay = strconv.Itoa(r.Bay)
+			}
+			row := []string{r.Name, r.SerialNumber, r.Model, r.ServerHardwareType, r.Enclosure, bay, r.PowerState, r.ServerProfile}
+			if err := w.Write(row); err != nil {
+				return nil, err
+			}
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	return nil, fmt.Errorf("Error unknown inventory format %q, valid formats are csv and json", format)
+}
+
+// GetServerHardwareInventory lists all server hardware with the names of the
+// server hardware type, enclosure and assigned profile resolved
+func (c *OVClient) GetServerHardwareInventory() ([]ServerHardwareInventoryRecord, error) {
+	var records []ServerHardwareInventoryRecord
+
+	hardware, err := c.GetServerHardwareList([]string{}, "name:asc", "", "", "")
+	if err != nil {
+		return records, err
+	}
+
+	all, err := c.GetAllProfiles("", "name:asc")
+	if err != nil {
+		return records, err
+	}
+	profiles := make(map[string]string)
+	for _, p := range all.Members {
+		profiles[p.URI.String()] = p.Name
+	}
+
+	types := make(map[string]string)
+	enclosures := make(map[string]string)
+	for _, h := range hardware.Members {
+		record := ServerHardwareInventoryRecord{
+			Name:          h.Name,
+			SerialNumber:  h.SerialNumber.String(),
+			Model:         h.Model,
+			PowerState:    h.PowerState,
+			ServerProfile: profiles[h.ServerProfileURI.String()],
+		}
+		if !h.ServerHardwareTypeURI.IsNil() {
+			name, ok := types[h.ServerHardwareTypeURI.String()]
+			if !ok {
+				sht, err := c.GetServerHardwareTypeByUri(h.ServerHardwareTypeURI)
+				if err != nil {
+					return records, err
+				}
+				name = sht.Name
+				types[h.ServerHardwareTypeURI.String()] = name
+			}
+			record.ServerHardwareType = name
+		}
+		if strings.HasPrefix(h.LocationURI.String(), "/rest/enclosures/") {
+			name, ok := enclosures[h.LocationURI.String()]
+			if !ok {
+				enclosure, err := c.GetEnclosurebyUri(h.LocationURI)
+				if err != nil {
+					return records, err
+				}
+				name = enclosure.Name
+				enclosures[h.LocationURI.String()] = name
+			}
+			record.Enclosure = name
+			record.Bay = h.Position
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// ExportServerHardwareInventory exports all server hardware as a flattened csv or json document
+// with name, serial number, model, server hardware type, enclosure and bay, power state and profile
+func (c *OVClient) ExportServerHardwareInventory(format string) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case INVENTORY_FORMAT_CSV, INVENTORY_FORMAT_JSON:
+	default:
+		return nil, fmt.Errorf("Error unknown inventory format %q, valid formats are csv and json", format)
+	}
+	records, err := c.GetServerHardwareInventory()
+	if err != nil {
+		return nil, err
+	}
+	return MarshalServerHardwareInventory(records, format)
+}
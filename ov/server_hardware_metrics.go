@@ -0,0 +1,170 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/docker/machine/libmachine/log"
+)
+
+// MinimumMetricsInterval is the shortest interval StreamServerHardwareMetrics polls at,
+// shorter intervals are raised to it to keep the load on the appliance down
+var MinimumMetricsInterval = 10 * time.Second
+
+// UtilizationMetric samples of one metric, each sample is a [timestamp in ms, value] pair
+type UtilizationMetric struct {
+	MetricCapacity int         `json:"metricCapacity,omitempty"` // "metricCapacity": 100,
+	MetricName     string      `json:"metricName,omitempty"`     // "metricName": "CpuUtilization",
+	MetricSamples  [][]float64 `json:"metricSamples,omitempty"`  // "metricSamples": [[1441036118675, 12]],
+}
+
+// ServerHardwareUtilization utilization metrics of a server hardware
+type ServerHardwareUtilization struct {
+	IsFresh          bool                `json:"isFresh,omitempty"`          // "isFresh": true,
+	MetricList       []UtilizationMetric `json:"metricList,omitempty"`       // "metricList": [],
+	NewestSampleTime string              `json:"newestSampleTime,omitempty"` // "newestSampleTime": "2015-08-31T15:48:35.250Z",
+	OldestSampleTime string              `json:"oldestSampleTime,omitempty"` // "oldestSampleTime": "2015-08-30T15:48:35.250Z",
+	Resolution       int                 `json:"resolution,omitempty"`       // "resolution": 300000,
+	SliceEndTime     string              `json:"sliceEndTime,omitempty"`     // "sliceEndTime": "2015-08-31T15:50:00.000Z",
+	SliceStartTime   string              `json:"sliceStartTime,omitempty"`   // "sliceStartTime": "2015-08-31T15:45:00.000Z",
+	URI              utils.Nstring       `json:"uri,omitempty"`              // "uri": "/rest/server-hardware/30373237-3132-4D32-3235-303930524D57/utilization"
+}
+
+// MetricSample one sample emitted by StreamServerHardwareMetrics, Err is set instead of the
+// sample values when polling the server hardware failed
+type MetricSample struct {
+	ServerHardwareUri string
+	Metric            string
+	Timestamp         time.Time
+	Value             float64
+	Err               error
+}
+
+// GetServerHardwareUtilization gets the utilization of a server hardware, fields selects the
+// metrics such as CpuUtilization, AveragePower or AmbientTemperature, all metrics when empty
+func (c *OVClient) GetServerHardwareUtilization(uri utils.Nstring, fields []string) (ServerHardwareUtilization, error) {
+	var (
+		q           = make(map[string]interface{})
+		utilization ServerHardwareUtilization
+	)
+	if len(fields) > 0 {
+		q["fields"] = strings.Join(fields, ",")
+	}
+
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	data, err := c.RestAPICall(rest.GET, uri.String()+"/utilization", nil, q)
+	if err != nil {
+		return utilization, err
+	}
+
+	log.Debugf("GetServerHardwareUtilization %s", data)
+	if err := json.Unmarshal([]byte(data), &utilization); err != nil {
+		return utilization, err
+	}
+	return utilization, nil
+}
+
+// GetUtilization gets the utilization of the server hardware, see GetServerHardwareUtilization
+func (s ServerHardware) GetUtilization(fields []string) (ServerHardwareUtilization, error) {
+	if s.Client == nil {
+		return ServerHardwareUtilization{}, errors.New("Error getting utilization, server hardware has no client")
+	}
+	return s.Client.GetServerHardwareUtilization(s.URI, fields)
+}
+
+// StreamServerHardwareMetrics polls the utilization of the server hardware every interval and
+// emits the samples not seen before on the returned channel until ctx is canceled, the channel
+// is closed once ctx is done. Each poll makes one request per server hardware for all metrics,
+// one after the other, and the interval is raised to MinimumMetricsInterval when shorter.
+func (c *OVClient) StreamServerHardwareMetrics(ctx context.Context, uris []string, metrics []string, interval time.Duration) (<-chan MetricSample, error) {
+	var (
+		servers []string
+		seen    = make(map[string]bool)
+	)
+	for _, uri := range uris {
+		if uri != "" && !seen[uri] {
+			seen[uri] = true
+			servers = append(servers, uri)
+		}
+	}
+	if len(servers) == 0 {
+		return nil, errors.New("Error streaming server hardware metrics, no server hardware uri provided")
+	}
+	if interval < MinimumMetricsInterval {
+		interval = MinimumMetricsInterval
+	}
+
+	samples := make(chan MetricSample)
+	go func() {
+		defer close(samples)
+		newest := make(map[string]float64) // uri + metric -> newest timestamp emitted
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			for _, uri := range servers {
+				utilization, err := c.GetServerHardwareUtilization(utils.NewNstring(uri), metrics)
+				if err != nil {
+					select {
+					case samples <- MetricSample{ServerHardwareUri: uri, Err: err}:
+					case <-ctx.Done():
+						return
+					}
+					continue
+				}
+				for _, metric := range utilization.MetricList {
+					key := uri + "|" + metric.MetricName
+					last := newest[key]
+					// samples are listed newest first
+					for i := len(metric.MetricSamples) - 1; i >= 0; i-- {
+						sample := metric.MetricSamples[i]
+						if len(sample) < 2 || sample[0] <= last {
+							continue
+						}
+						newest[key] = sample[0]
+						s := MetricSample{
+							ServerHardwareUri: uri,
+							Metric:            metric.MetricName,
+							Timestamp:         time.Unix(0, int64(sample[0])*int64(time.Millisecond)),
+							Value:             sample[1],
+						}
+						select {
+						case samples <- s:
+						case <-ctx.Done():
+							return
+						}
+					}
+				}
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return samples, nil
+}
@@ -0,0 +1,146 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/docker/machine/libmachine/log"
+)
+
+// OneView has no native reservation for server hardware, so a reservation is
+// recorded as a label on the hardware ("reserved:<reservationId>") which is
+// visible to every automation run talking to the appliance. Each client also
+// tracks the reservations it made in its own registry, so concurrent callers
+// sharing the client are serialized without an extra round trip.
+//
+// Checking the labels and adding the reservation label are separate requests
+// and the appliance does not make them atomic. The registry only guards callers
+// sharing one client in one process, two clients or processes reserving the
+// same hardware at the same moment can both succeed.
+
+// ReservationLabelPrefix prefixes the label name used to mark reserved hardware
+const ReservationLabelPrefix = "reserved:"
+
+// GetServerHardwareReservation returns the reservation id held on the server hardware,
+// or an empty string when the hardware is not reserved
+func (c *OVClient) GetServerHardwareReservation(uri string) (string, error) {
+	labels, err := c.GetAssignedLabels(utils.NewNstring(uri))
+	if err != nil {
+		return "", err
+	}
+	for _, label := range labels.Labels {
+		if strings.HasPrefix(label.Name, ReservationLabelPrefix) {
+			return strings.TrimPrefix(label.Name, ReservationLabelPrefix), nil
+		}
+	}
+	return "", nil
+}
+
+// ReserveServerHardware reserves free server hardware for the given reservation id.
+// Returns an error when the hardware already has a profile assigned or is reserved
+// under a different reservation id. Reserving twice with the same id is a no-op.
+// Only callers sharing this client are serialized, another client or process reserving
+// the same hardware at the same moment can also succeed.
+func (c *OVClient) ReserveServerHardware(uri string, reservationId string) error {
+	if uri == "" {
+		return errors.New("Error reserving server hardware, no uri provided")
+	}
+	if reservationId == "" {
+		return errors.New("Error reserving server hardware, no reservation id provided")
+	}
+
+	c.reservationLock.Lock()
+	defer c.reservationLock.Unlock()
+
+	if c.reservations == nil {
+		c.reservations = make(map[string]string)
+	}
+	if owner, ok := c.reservations[uri]; ok {
+		if owner == reservationId {
+			return nil
+		}
+		return fmt.Errorf("Server hardware %s is already reserved by %s", uri, owner)
+	}
+
+	hardware, err := c.GetServerHardwareByUri(utils.NewNstring(uri))
+	if err != nil {
+		return err
+	}
+	if !hardware.ServerProfileURI.IsNil() {
+		return fmt.Errorf("Server hardware %s is already assigned to profile %s", hardware.Name, hardware.ServerProfileURI)
+	}
+
+	assigned, err := c.GetAssignedLabels(hardware.URI)
+	if err != nil {
+		return err
+	}
+	for _, label := range assigned.Labels {
+		if strings.HasPrefix(label.Name, ReservationLabelPrefix) {
+			owner := strings.TrimPrefix(label.Name, ReservationLabelPrefix)
+			if owner != reservationId {
+				return fmt.Errorf("Server hardware %s is already reserved by %s", hardware.Name, owner)
+			}
+			c.reservations[uri] = reservationId
+			return nil
+		}
+	}
+
+	assigned.ResourceUri = hardware.URI
+	assigned.Labels = append(assigned.Labels, Label{Name: ReservationLabelPrefix + reservationId})
+	if _, err := c.UpdateAssignedLabels(assigned); err != nil {
+		log.Errorf("Error reserving server hardware %s: %s", hardware.Name, err)
+		return err
+	}
+	c.reservations[uri] = reservationId
+	return nil
+}
+
+// ReleaseServerHardware removes any reservation held on the server hardware
+func (c *OVClient) ReleaseServerHardware(uri string) error {
+	if uri == "" {
+		return errors.New("Error releasing server hardware, no uri provided")
+	}
+
+	c.reservationLock.Lock()
+	defer c.reservationLock.Unlock()
+
+	assigned, err := c.GetAssignedLabels(utils.NewNstring(uri))
+	if err != nil {
+		return err
+	}
+	labels := []Label{}
+	for _, label := range assigned.Labels {
+		if !strings.HasPrefix(label.Name, ReservationLabelPrefix) {
+			labels = append(labels, label)
+		}
+	}
+	if len(labels) != len(assigned.Labels) {
+		assigned.ResourceUri = utils.NewNstring(uri)
+		assigned.Labels = labels
+		if _, err := c.UpdateAssignedLabels(assigned); err != nil {
+			log.Errorf("Error releasing server hardware %s: %s", uri, err)
+			return err
+		}
+	}
+	delete(c.reservations, uri)
+	return nil
+}
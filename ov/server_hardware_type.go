@@ -2,8 +2,10 @@ package ov
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/HewlettPackard/oneview-golang/rest"
 	"github.com/HewlettPackard/oneview-golang/utils"
@@ -208,12 +210,7 @@ func (c *OVClient) GetServerHardwareTypes(start int, count int, filter string, s
 	// refresh login
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	// Setup query
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
 	if err != nil {
 		return serverHardwareTypes, err
 	}
@@ -224,3 +221,49 @@ func (c *OVClient) GetServerHardwareTypes(start int, count int, filter string, s
 	}
 	return serverHardwareTypes, nil
 }
+
+// GetBiosSetting finds the bios setting of the server hardware type by id
+func (sht ServerHardwareType) GetBiosSetting(id string) (BiosSetting, bool) {
+	for _, setting := range sht.BiosSettings {
+		if setting.ID == id {
+			return setting, true
+		}
+	}
+	return BiosSetting{}, false
+}
+
+// ValidateBiosSettings checks every overridden bios setting is offered by the server hardware type
+// and, for settings with options or integer bounds, that the value is allowed, returning a single
+// error listing each invalid setting
+func ValidateBiosSettings(sht ServerHardwareType, settings []BiosSettings) error {
+	var problems []string
+	for _, override := range settings {
+		setting, ok := sht.GetBiosSetting(override.ID)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("setting %q is not available for server hardware type %s", override.ID, sht.Name))
+			continue
+		}
+		if len(setting.Options) > 0 {
+			var ids []string
+			valid := false
+			for _, option := range setting.Options {
+				ids = append(ids, option.ID)
+				valid = valid || option.ID == override.Value
+			}
+			if !valid {
+				problems = append(problems, fmt.Sprintf("setting %s (%s) value %q is not one of %s", setting.ID, setting.Name, override.Value, strings.Join(ids, ",")))
+			}
+			continue
+		}
+		if setting.UpperBound > setting.LowerBound {
+			value, err := strconv.Atoi(override.Value)
+			if err != nil || value < setting.LowerBound || value > setting.UpperBound {
+				problems = append(problems, fmt.Sprintf("setting %s (%s) value %q is not between %d and %d", setting.ID, setting.Name, override.Value, setting.LowerBound, setting.UpperBound))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return errors.New("Error validating bios settings: " + strings.Join(problems, ", "))
+	}
+	return nil
+}
@@ -154,12 +154,7 @@ func (c *OVClient) GetStorageVolumes(filter string, sort string) (StorageVolumes
 	// refresh login
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	// Setup query
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
 	if err != nil {
 		return sVols, err
 	}
@@ -209,11 +204,18 @@ func (c *OVClient) CreateStorageVolume(sVol StorageVolume) error {
 }
 
 func (c *OVClient) DeleteStorageVolume(name string) error {
+	return c.DeleteStorageVolumeWithOptions(name, false)
+}
+
+// DeleteStorageVolumeWithOptions deletes a storage volume, when exportOnly is true the volume
+// is only removed from OneView and is left on the storage system.
+func (c *OVClient) DeleteStorageVolumeWithOptions(name string, exportOnly bool) error {
 	var (
 		sVol StorageVolume
 		err  error
 		t    *Task
 		uri  string
+		q    map[string]interface{}
 	)
 
 	sVol, err = c.GetStorageVolumeByName(name)
@@ -231,7 +233,11 @@ func (c *OVClient) DeleteStorageVolume(name string) error {
 			t.TaskIsDone = true
 			return err
 		}
-		data, err := c.RestAPICall(rest.DELETE, uri, nil)
+		q = make(map[string]interface{})
+		if exportOnly {
+			q["suppressDeviceUpdates"] = "true"
+		}
+		data, err := c.RestAPICall(rest.DELETE, uri, nil, q)
 		if err != nil {
 			log.Errorf("Error submitting delete storage volume request: %s", err)
 			t.TaskIsDone = true
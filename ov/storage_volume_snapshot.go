@@ -0,0 +1,144 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/docker/machine/libmachine/log"
+)
+
+// VolumeTemplate storage volume template, see StorageVolumeTemplate
+type VolumeTemplate = StorageVolumeTemplate
+
+// Snapshot point in time copy of a storage volume
+type Snapshot struct {
+	Category           string        `json:"category,omitempty"`           // "category": "snapshots",
+	Created            string        `json:"created,omitempty"`            // "created": "2021-03-10T10:20:11.000Z",
+	Description        utils.Nstring `json:"description,omitempty"`        // "description": "before upgrade",
+	DeviceSnapshotName string        `json:"deviceSnapshotName,omitempty"` // "deviceSnapshotName": "vol1-snap",
+	ETAG               string        `json:"eTag,omitempty"`               // "eTag": "2021-03-10T10:20:11.000Z",
+	Modified           string        `json:"modified,omitempty"`           // "modified": "2021-03-10T10:20:11.000Z",
+	Name               string        `json:"name,omitempty"`               // "name": "vol1-snap",
+	State              string        `json:"state,omitempty"`              // "state": "Configured",
+	Status             string        `json:"status,omitempty"`             // "status": "OK",
+	StorageVolumeUri   utils.Nstring `json:"storageVolumeUri,omitempty"`   // "storageVolumeUri": "/rest/storage-volumes/527801AC-B6B6-4A63-8510-D32906C9C57B",
+	Type               string        `json:"type,omitempty"`               // "type": "Snapshot",
+	URI                utils.Nstring `json:"uri,omitempty"`                // "uri": "/rest/storage-volumes/527801AC-B6B6-4A63-8510-D32906C9C57B/snapshots/1"
+}
+
+// SnapshotList list of snapshots of a storage volume
+type SnapshotList struct {
+	Total       int           `json:"total,omitempty"`       // "total": 1,
+	Count       int           `json:"count,omitempty"`       // "count": 1,
+	Start       int           `json:"start,omitempty"`       // "start": 0,
+	PrevPageURI utils.Nstring `json:"prevPageUri,omitempty"` // "prevPageUri": null,
+	NextPageURI utils.Nstring `json:"nextPageUri,omitempty"` // "nextPageUri": null,
+	URI         utils.Nstring `json:"uri,omitempty"`         // "uri": "/rest/storage-volumes/527801AC-B6B6-4A63-8510-D32906C9C57B/snapshots",
+	Members     []Snapshot    `json:"members,omitempty"`     // "members":[]
+}
+
+// GetStorageVolumeSnapshots gets every snapshot of a storage volume
+func (c *OVClient) GetStorageVolumeSnapshots(volumeURI utils.Nstring) (SnapshotList, error) {
+	var (
+		snapshots SnapshotList
+	)
+	if volumeURI.IsNil() {
+		return snapshots, errors.New("Error getting storage volume snapshots, no volume uri provided")
+	}
+	err := c.Iterate(volumeURI.String()+"/snapshots", nil, func(raw json.RawMessage) error {
+		var snapshot Snapshot
+		if err := json.Unmarshal(raw, &snapshot); err != nil {
+			return err
+		}
+		snapshots.Members = append(snapshots.Members, snapshot)
+		return nil
+	})
+	if err != nil {
+		return snapshots, err
+	}
+	snapshots.Total = len(snapshots.Members)
+	snapshots.Count = len(snapshots.Members)
+	return snapshots, nil
+}
+
+// CreateStorageVolumeSnapshot creates a snapshot of a storage volume, the task is returned without waiting
+func (c *OVClient) CreateStorageVolumeSnapshot(volumeURI utils.Nstring, snap Snapshot) (*Task, error) {
+	var (
+		uri = volumeURI.String() + "/snapshots"
+		t   *Task
+	)
+	if volumeURI.IsNil() {
+		return t, errors.New("Error creating storage volume snapshot, no volume uri provided")
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	t = t.NewProfileTask(c)
+	t.ResetTask()
+	log.Debugf("REST : %s \n %+v\n", uri, snap)
+	data, err := c.RestAPICall(rest.POST, uri, snap)
+	if err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error submitting create storage volume snapshot request: %s", err)
+		return t, err
+	}
+
+	log.Debugf("Response create storage volume snapshot %s", data)
+	if err := json.Unmarshal([]byte(data), &t); err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with task un-marshal: %s", err)
+		return t, err
+	}
+	return t, nil
+}
+
+// DeleteStorageVolumeSnapshot deletes a snapshot by its uri, the task is returned without waiting
+func (c *OVClient) DeleteStorageVolumeSnapshot(snapshotURI utils.Nstring) (*Task, error) {
+	var (
+		t *Task
+	)
+	if snapshotURI.IsNil() {
+		return t, errors.New("Error deleting storage volume snapshot, no snapshot uri provided")
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	t = t.NewProfileTask(c)
+	t.ResetTask()
+	log.Debugf("REST : %s \n", snapshotURI)
+	data, err := c.RestAPICall(rest.DELETE, snapshotURI.String(), nil)
+	if err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error submitting delete storage volume snapshot request: %s", err)
+		return t, err
+	}
+
+	log.Debugf("Response delete storage volume snapshot %s", data)
+	if err := json.Unmarshal([]byte(data), &t); err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with task un-marshal: %s", err)
+		return t, err
+	}
+	return t, nil
+}
@@ -150,12 +150,7 @@ func (c *OVClient) GetStorageVolumeTemplates(filter string, sort string, start s
 	// refresh login
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	// Setup query
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
 
 	if err != nil {
 		return sVolTemplates, err
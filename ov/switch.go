@@ -0,0 +1,226 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/docker/machine/libmachine/log"
+)
+
+// Switch top of rack switch managed through a logical switch
+type Switch struct {
+	Category         string        `json:"category,omitempty"`         // "category": "switches",
+	ChassisId        string        `json:"chassisId,omitempty"`        // "chassisId": "a4:8c:db:73:aa:b0",
+	Created          string        `json:"created,omitempty"`          // "created": "20150831T154835.250Z",
+	Description      utils.Nstring `json:"description,omitempty"`      // "description": "Top of rack switch",
+	ETAG             string        `json:"eTag,omitempty"`             // "eTag": "1441036118675/8",
+	FirmwareVersion  string        `json:"firmwareVersion,omitempty"`  // "firmwareVersion": "7.0(3)I4(2)",
+	IPV4             string        `json:"ipV4,omitempty"`             // "ipV4": "172.18.20.1",
+	LogicalSwitchUri utils.Nstring `json:"logicalSwitchUri,omitempty"` // "logicalSwitchUri": "/rest/logical-switches/d4f3f5f3-bb4c-4e3a-a6c8-c9d1cb8d0fb1",
+	ModelName        string        `json:"modelName,omitempty"`        // "modelName": "Cisco Nexus 56xx",
+	Modified         string        `json:"modified,omitempty"`         // "modified": "20150831T154835.250Z",
+	Name             string        `json:"name,omitempty"`             // "name": "172.18.20.1",
+	Ports            []SwitchPort  `json:"ports,omitempty"`            // "ports": [],
+	Role             string        `json:"role,omitempty"`             // "role": "Master",
+	ScopesUri        utils.Nstring `json:"scopesUri,omitempty"`        // "scopesUri": "/rest/scopes/resources/rest/switches/a4f8e2b6-3d31-4f33-a9f9-25b5d3e8b2c0",
+	SerialNumber     string        `json:"serialNumber,omitempty"`     // "serialNumber": "FOC1832R0YH",
+	State            string        `json:"state,omitempty"`            // "state": "Configured",
+	Status           string        `json:"status,omitempty"`           // "status": "OK",
+	SwitchTypeUri    utils.Nstring `json:"switchTypeUri,omitempty"`    // "switchTypeUri": "/rest/switch-types/a2bc8f42-8bb8-4560-b80f-6c3c0e0d66e0",
+	Type             string        `json:"type,omitempty"`             // "type": "switch",
+	URI              utils.Nstring `json:"uri,omitempty"`              // "uri": "/rest/switches/a4f8e2b6-3d31-4f33-a9f9-25b5d3e8b2c0"
+}
+
+// SwitchPort port of a top of rack switch
+type SwitchPort struct {
+	Name          string        `json:"name,omitempty"`          // "name": "Ethernet1/1",
+	OperStatus    string        `json:"operStatus,omitempty"`    // "operStatus": "Up",
+	PortName      string        `json:"portName,omitempty"`      // "portName": "1/1",
+	PortType      string        `json:"portType,omitempty"`      // "portType": "Uplink",
+	Speed         string        `json:"speed,omitempty"`         // "speed": "Speed10G",
+	Status        string        `json:"status,omitempty"`        // "status": "OK",
+	ConnectorType string        `json:"connectorType,omitempty"` // "connectorType": "SFP+",
+	URI           utils.Nstring `json:"uri,omitempty"`           // "uri": "/rest/switches/a4f8e2b6-3d31-4f33-a9f9-25b5d3e8b2c0/ports/Ethernet1-1"
+}
+
+// SwitchList a page of switches
+type SwitchList struct {
+	Total       int           `json:"total,omitempty"`       // "total": 1,
+	Count       int           `json:"count,omitempty"`       // "count": 1,
+	Start       int           `json:"start,omitempty"`       // "start": 0,
+	PrevPageURI utils.Nstring `json:"prevPageUri,omitempty"` // "prevPageUri": null,
+	NextPageURI utils.Nstring `json:"nextPageUri,omitempty"` // "nextPageUri": null,
+	URI         utils.Nstring `json:"uri,omitempty"`         // "uri": "/rest/switches?start=0&count=10",
+	Members     []Switch      `json:"members,omitempty"`     // "members":[]
+}
+
+// CommonPortStatistics rfc 1213 counters of a port
+type CommonPortStatistics struct {
+	RFC1213IfInDiscards    string `json:"rfc1213IfInDiscards,omitempty"`
+	RFC1213IfInErrors      string `json:"rfc1213IfInErrors,omitempty"`
+	RFC1213IfInNUcastPkts  string `json:"rfc1213IfInNUcastPkts,omitempty"`
+	RFC1213IfInOctets      string `json:"rfc1213IfInOctets,omitempty"`
+	RFC1213IfInUcastPkts   string `json:"rfc1213IfInUcastPkts,omitempty"`
+	RFC1213IfOutDiscards   string `json:"rfc1213IfOutDiscards,omitempty"`
+	RFC1213IfOutErrors     string `json:"rfc1213IfOutErrors,omitempty"`
+	RFC1213IfOutNUcastPkts string `json:"rfc1213IfOutNUcastPkts,omitempty"`
+	RFC1213IfOutOctets     string `json:"rfc1213IfOutOctets,omitempty"`
+	RFC1213IfOutUcastPkts  string `json:"rfc1213IfOutUcastPkts,omitempty"`
+}
+
+// SwitchPortStatistics statistics of a switch port
+type SwitchPortStatistics struct {
+	CommonStatistics CommonPortStatistics `json:"commonStatistics,omitempty"`
+	PortName         string               `json:"portName,omitempty"`   // "portName": "1/1",
+	PortStatus       string               `json:"portStatus,omitempty"` // "portStatus": "Linked",
+	PortType         string               `json:"portType,omitempty"`   // "portType": "Uplink",
+}
+
+// SwitchModuleStatistics switch wide statistics
+type SwitchModuleStatistics struct {
+	CpuUsage      string `json:"cpuUsage,omitempty"`      // "cpuUsage": "5",
+	MemoryUsage   string `json:"memoryUsage,omitempty"`   // "memoryUsage": "38",
+	UpTime        string `json:"upTime,omitempty"`        // "upTime": "10 days 4 hours",
+	ModuleName    string `json:"moduleName,omitempty"`    // "moduleName": "172.18.20.1",
+	ModuleStatus  string `json:"moduleStatus,omitempty"`  // "moduleStatus": "OK",
+	ModuleVersion string `json:"moduleVersion,omitempty"` // "moduleVersion": "7.0(3)I4(2)",
+}
+
+// SwitchStatistics statistics of a switch and its ports
+type SwitchStatistics struct {
+	ModuleStatistics   SwitchModuleStatistics `json:"moduleStatistics,omitempty"`
+	PortStatisticsList []SwitchPortStatistics `json:"portStatisticsList,omitempty"`
+}
+
+func (c *OVClient) GetSwitches(start string, count string, filter string, sort string) (SwitchList, error) {
+	var (
+		uri      = "/rest/switches"
+		q        map[string]interface{}
+		switches SwitchList
+	)
+	q = make(map[string]interface{})
+	if len(filter) > 0 {
+		q["filter"] = filter
+	}
+
+	if sort != "" {
+		q["sort"] = sort
+	}
+
+	if start != "" {
+		q["start"] = start
+	}
+
+	if count != "" {
+		q["count"] = count
+	}
+
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
+	if err != nil {
+		return switches, err
+	}
+
+	log.Debugf("GetSwitches %s", data)
+	if err := json.Unmarshal([]byte(data), &switches); err != nil {
+		return switches, err
+	}
+	return switches, nil
+}
+
+func (c *OVClient) GetSwitchByName(name string) (Switch, error) {
+	var (
+		sw Switch
+	)
+	switches, err := c.GetSwitches("", "", fmt.Sprintf("name matches '%s'", name), "name:asc")
+	if switches.Total > 0 {
+		return switches.Members[0], err
+	} else {
+		return sw, err
+	}
+}
+
+func (c *OVClient) GetSwitchByUri(uri utils.Nstring) (Switch, error) {
+	var (
+		sw Switch
+	)
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	data, err := c.RestAPICall(rest.GET, uri.String(), nil)
+	if err != nil {
+		return sw, err
+	}
+	log.Debugf("GetSwitchByUri %s", data)
+	if err := json.Unmarshal([]byte(data), &sw); err != nil {
+		return sw, err
+	}
+	return sw, nil
+}
+
+// GetSwitchStatistics gets the module and port statistics of a switch
+func (c *OVClient) GetSwitchStatistics(uri utils.Nstring) (SwitchStatistics, error) {
+	var (
+		stats SwitchStatistics
+	)
+	if uri.IsNil() {
+		return stats, errors.New("Error getting switch statistics, no switch uri provided")
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	data, err := c.RestAPICall(rest.GET, uri.String()+"/statistics", nil)
+	if err != nil {
+		return stats, err
+	}
+	log.Debugf("GetSwitchStatistics %s", data)
+	if err := json.Unmarshal([]byte(data), &stats); err != nil {
+		return stats, err
+	}
+	return stats, nil
+}
+
+// GetSwitchPortStatistics gets the statistics of a single port of a switch
+func (c *OVClient) GetSwitchPortStatistics(uri utils.Nstring, portName string) (SwitchPortStatistics, error) {
+	var (
+		stats SwitchPortStatistics
+	)
+	if uri.IsNil() || portName == "" {
+		return stats, errors.New("Error getting switch port statistics, a switch uri and port name are required")
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	data, err := c.RestAPICall(rest.GET, uri.String()+"/statistics/"+url.PathEscape(portName), nil)
+	if err != nil {
+		return stats, err
+	}
+	log.Debugf("GetSwitchPortStatistics %s", data)
+	if err := json.Unmarshal([]byte(data), &stats); err != nil {
+		return stats, err
+	}
+	return stats, nil
+}
@@ -18,8 +18,10 @@ limitations under the License.
 package ov
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -98,6 +100,25 @@ func (tt TaskType) String() string { return tasktype[tt-1] }
 // Equal type
 func (tt TaskType) Equal(s string) bool { return (strings.ToUpper(s) == strings.ToUpper(tt.String())) }
 
+// TaskFailedError is returned while waiting on a task that reports task errors
+type TaskFailedError struct {
+	TaskErrors []TaskError
+}
+
+func (e *TaskFailedError) Error() string {
+	var errmsg string
+	for _, te := range e.TaskErrors {
+		errmsg += te.Message + " \n" + strings.Join(te.RecommendedActions, " ")
+	}
+	return errmsg
+}
+
+// IsTaskError - true when err was reported by a task rather than by the rest call that started it
+func IsTaskError(err error) bool {
+	var taskErr *TaskFailedError
+	return errors.As(err, &taskErr)
+}
+
 // TaskError struct
 type TaskError struct {
 	Data               map[string]interface{} `json:"data,omitempty"`               // "data":{},
@@ -149,7 +170,9 @@ type Task struct {
 	IsCancellable           bool
 	Timeout                 int           // time before timeout on Executor
 	WaitTime                time.Duration // time between task checks
+	PollInterval            time.Duration `json:"-"` // time between task checks, overrides WaitTime seconds when set
 	Client                  *OVClient
+	progress                chan int
 }
 
 // TaskServer Example:
@@ -173,6 +196,9 @@ type TasksList struct {
 	Members     []Task        `json:"members,omitempty"`     // "members":[]
 }
 
+// TaskList - a page of tasks, same as TasksList
+type TaskList = TasksList
+
 // NewProfileTask - Create New Task
 func (t *Task) NewProfileTask(c *OVClient) *Task {
 	return &Task{TaskIsDone: false,
@@ -194,13 +220,18 @@ func (t *Task) ResetTask() {
 
 // GetCurrentTaskStatus - Get the current status
 func (t *Task) GetCurrentTaskStatus() error {
+	return t.GetCurrentTaskStatusWithContext(context.Background())
+}
+
+// GetCurrentTaskStatusWithContext - Get the current status, the request is cancelled with ctx
+func (t *Task) GetCurrentTaskStatusWithContext(ctx context.Context) error {
 	log.Debugf("Working on getting current task status")
 	var (
 		uri = t.URI
 	)
 	if uri != "" {
 		log.Debugf(uri.String())
-		data, err := t.Client.RestAPICall(rest.GET, uri.String(), nil)
+		data, err := t.Client.RestAPICallWithContext(ctx, rest.GET, uri.String(), nil)
 		if err != nil {
 			return err
 		}
@@ -212,16 +243,44 @@ func (t *Task) GetCurrentTaskStatus() error {
 		log.Debugf("Unable to get current task, no URI found")
 	}
 	if len(t.TaskErrors) > 0 {
-		var errmsg string
-		errmsg = ""
-		for _, te := range t.TaskErrors {
-			errmsg += te.Message + " \n" + strings.Join(te.RecommendedActions, " ")
-		}
-		return errors.New(errmsg)
+		return &TaskFailedError{TaskErrors: t.TaskErrors}
 	}
 	return nil
 }
 
+// TaskErrorDetails - flattened list of the task errors, their nested errors and the errors of
+// any child tasks the task spawned, walked recursively. Child tasks are looked up through the
+// task client, a failed lookup is logged and skipped.
+func (t *Task) TaskErrorDetails() []TaskError {
+	details := flattenTaskErrors(nil, t.TaskErrors)
+	if t.Client == nil || t.URI.IsNil() {
+		return details
+	}
+	children, err := t.Client.GetTasks(fmt.Sprintf("parentTaskUri='%s'", t.URI), "", "", "", "", "")
+	if err != nil {
+		log.Debugf("Unable to get child tasks of %s: %s", t.URI, err)
+		return details
+	}
+	for _, child := range children.Members {
+		if child.URI == t.URI {
+			continue
+		}
+		child.Client = t.Client
+		details = append(details, child.TaskErrorDetails()...)
+	}
+	return details
+}
+
+func flattenTaskErrors(details []TaskError, taskErrors []TaskError) []TaskError {
+	for _, te := range taskErrors {
+		nested := te.NestedErrors
+		te.NestedErrors = nil
+		details = append(details, te)
+		details = flattenTaskErrors(details, nested)
+	}
+	return details
+}
+
 // GetLastStatusUpdate - get last detail updates from task
 func (t *Task) GetLastStatusUpdate() string {
 	if len(t.ProgressUpdates) > 0 {
@@ -241,23 +300,91 @@ func (t *Task) GetLastStatusUpdate() string {
 
 // Wait - wait on task to complete
 func (t *Task) Wait() error {
+	return t.WaitWithContext(context.Background())
+}
+
+// ErrTaskTimeout is returned by WaitWithTimeout when the task is still running at the deadline,
+// the task keeps running on the appliance
+var ErrTaskTimeout = errors.New("timed out waiting on task")
+
+// WaitWithContext - wait on task to complete, returns ctx.Err() as soon as ctx is done.
+// The task keeps running on the appliance when the wait is cancelled.
+func (t *Task) WaitWithContext(ctx context.Context) error {
+	return t.wait(ctx, true)
+}
+
+// WaitWithTimeout - wait on task to complete for at most timeout, polling every PollInterval.
+// ErrTaskTimeout is returned when the task is still running at the deadline.
+func (t *Task) WaitWithTimeout(timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	err := t.wait(ctx, false)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrTaskTimeout
+	}
+	return err
+}
+
+// Progress - channel receiving the task percent complete after every poll of a wait, it is closed
+// once a wait sees the task done. Updates are dropped while the receiver is not ready. Once the task
+// is done a closed channel is returned, so ranging over it after the wait returns at once.
+func (t *Task) Progress() <-chan int {
+	if t.progress == nil && t.TaskIsDone {
+		done := make(chan int)
+		close(done)
+		return done
+	}
+	if t.progress == nil {
+		t.progress = make(chan int, 1)
+	}
+	return t.progress
+}
+
+// pollInterval - time between task checks
+func (t *Task) pollInterval() time.Duration {
+	if t.PollInterval > 0 {
+		return t.PollInterval
+	}
+	return time.Millisecond * (1000 * t.WaitTime)
+}
+
+// wait polls the task until it is done or ctx is done, bounded stops after Timeout polls like Wait always did
+func (t *Task) wait(ctx context.Context, bounded bool) error {
 	var (
 		currenttime int
 	)
+	defer func() {
+		if t.TaskIsDone && t.progress != nil {
+			close(t.progress)
+			t.progress = nil
+		}
+	}()
 	log.Debugf("task : %+v", t)
 	if t.Timeout < t.ExpectedDuration {
 		t.Timeout = t.ExpectedDuration
 		log.Debugf("assign timeout %d", t.Timeout)
 	}
 	log.Debugf("task timeout is : %d", t.Timeout)
-	for !t.TaskIsDone && (currenttime < t.Timeout) {
-		if err := t.GetCurrentTaskStatus(); err != nil {
+	for !t.TaskIsDone && (!bounded || currenttime < t.Timeout) {
+		if err := t.GetCurrentTaskStatusWithContext(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
 			t.TaskIsDone = true
 			return err
 		}
 		if t.URI != "" && T_COMPLETED.Equal(t.TaskState) {
 			t.TaskIsDone = true
 		}
+		if t.progress != nil {
+			select {
+			case t.progress <- t.ComputedPercentComplete:
+			default:
+			}
+		}
+		if t.TaskIsDone {
+			break
+		}
 		if t.URI != "" {
 			log.Debugf("Waiting for task to complete, for %s ", t.Name)
 			log.Debugf("Waiting on, %s, %d%%, %s, %d, %d", t.Name, t.ComputedPercentComplete, t.GetLastStatusUpdate(), currenttime, t.ExpectedDuration)
@@ -267,7 +394,11 @@ func (t *Task) Wait() error {
 		}
 
 		// wait time before next check
-		time.Sleep(time.Millisecond * (1000 * t.WaitTime)) // wait 10sec before checking the status again
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(t.pollInterval()): // wait 10sec before checking the status again
+		}
 		currenttime++
 		if t.Timeout < t.ExpectedDuration {
 			t.Timeout = t.ExpectedDuration
@@ -317,12 +448,7 @@ func (c *OVClient) GetTasks(filter string, sort string, count string, view strin
 	// refresh login
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	// Setup query
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
 	if err != nil {
 		return tasks, err
 	}
@@ -334,6 +460,43 @@ func (c *OVClient) GetTasks(filter string, sort string, count string, view strin
 	return tasks, nil
 }
 
+// GetTaskHistory gets every task matching the filter, following all pages. GetTasks already
+// takes the view and count parameters so the history query is kept under its own name.
+func (c *OVClient) GetTaskHistory(filter string, sort string) (TaskList, error) {
+	var (
+		tasks TaskList
+		q     = make(map[string]interface{})
+	)
+	if filter != "" {
+		q["filter"] = filter
+	}
+	if sort != "" {
+		q["sort"] = sort
+	}
+	err := c.Iterate("/rest/tasks", q, func(raw json.RawMessage) error {
+		var task Task
+		if err := json.Unmarshal(raw, &task); err != nil {
+			return err
+		}
+		tasks.Members = append(tasks.Members, task)
+		return nil
+	})
+	if err != nil {
+		return tasks, err
+	}
+	tasks.Total = len(tasks.Members)
+	tasks.Count = len(tasks.Members)
+	return tasks, nil
+}
+
+// GetTasksByResource gets the tasks run against a resource, newest first
+func (c *OVClient) GetTasksByResource(resourceURI utils.Nstring) (TaskList, error) {
+	if resourceURI.IsNil() {
+		return TaskList{}, errors.New("Error getting tasks, no resource uri provided")
+	}
+	return c.GetTaskHistory(fmt.Sprintf("associatedResource.resourceUri='%s'", resourceURI), "created:descending")
+}
+
 func (c *OVClient) GetTasksById(filter string, sort string, count string, view string, id string) (Task, error) {
 	var (
 		uri   = "/rest/tasks/"
@@ -361,12 +524,7 @@ func (c *OVClient) GetTasksById(filter string, sort string, count string, view s
 	// refresh login
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	// Setup query
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
 	if err != nil {
 		return tasks, err
 	}
@@ -408,3 +566,47 @@ func (c *OVClient) PatchTask(uri string) error {
 
 	return nil
 }
+
+// CancelTask asks the appliance to cancel a running task by moving it to the Cancelling state, the
+// local task state is updated from the response. Cancelling a task that already finished is a no-op.
+func (c *OVClient) CancelTask(t *Task) error {
+	if t == nil || t.URI.IsNil() {
+		return errors.New("Error cancelling task, no task uri provided")
+	}
+	if t.Client == nil {
+		t.Client = c
+	}
+	if err := t.GetCurrentTaskStatus(); err != nil && !IsTaskError(err) {
+		return err
+	}
+	for _, state := range []TaskState{T_COMPLETED, T_ERROR, T_INERRUPTED, T_KILLED, T_TERMINATED, T_WARNING} {
+		if state.Equal(t.TaskState) {
+			log.Infof("Task %s already finished with state %s, nothing to cancel", t.URI, t.TaskState)
+			t.TaskIsDone = true
+			return nil
+		}
+	}
+	if !t.IsCancellable {
+		return fmt.Errorf("Error cancelling task %s, task is not cancellable", t.URI)
+	}
+
+	c.RefreshLogin()
+	headers := c.GetAuthHeaderMap()
+	headers["Content-Type"] = "application/json-patch+json"
+
+	ops := []PatchOp{{Op: "replace", Path: "/taskState", Value: "Cancelling"}}
+	data, err := c.RestAPICallWithOptions(context.Background(), rest.PATCH, t.URI.String(), ops, rest.Options{Headers: headers})
+	if err != nil {
+		log.Errorf("Error submitting cancel task request: %s", err)
+		return err
+	}
+	log.Debugf("Cancel Task %s", data)
+	t.TaskState = "Cancelling"
+	if len(data) > 0 {
+		if err := json.Unmarshal([]byte(data), &t); err != nil {
+			log.Errorf("Error with task un-marshal: %s", err)
+			return err
+		}
+	}
+	return nil
+}
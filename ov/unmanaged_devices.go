@@ -0,0 +1,209 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/docker/machine/libmachine/log"
+)
+
+// UnmanagedDevice equipment in a rack that OneView does not manage, recorded to account for its space and power
+type UnmanagedDevice struct {
+	Category   string        `json:"category,omitempty"`       // "category": "unmanaged-devices",
+	Created    string        `json:"created,omitempty"`        // "created": "2021-03-10T10:20:11.000Z",
+	DeviceType string        `json:"deviceType,omitempty"`     // "deviceType": "Unknown",
+	ETAG       string        `json:"eTag,omitempty"`           // "eTag": "1",
+	Height     int           `json:"height,omitempty"`         // "height": 2, in rack units
+	ID         string        `json:"id,omitempty"`             // "id": "1",
+	Mac        string        `json:"mac,omitempty"`            // "mac": "00:11:22:33:44:55",
+	MaxPower   int           `json:"maxPwrConsumed,omitempty"` // "maxPwrConsumed": 600, in watts
+	Model      string        `json:"model,omitempty"`          // "model": "Procurve 4200VL",
+	Modified   string        `json:"modified,omitempty"`       // "modified": "2021-03-10T10:20:11.000Z",
+	Name       string        `json:"name,omitempty"`           // "name": "core-switch",
+	State      string        `json:"state,omitempty"`          // "state": "Unmanaged",
+	Status     string        `json:"status,omitempty"`         // "status": "Disabled",
+	Type       string        `json:"type,omitempty"`           // "type": "UnmanagedDevice",
+	URI        utils.Nstring `json:"uri,omitempty"`            // "uri": "/rest/unmanaged-devices/1"
+}
+
+// UnmanagedDeviceList list of unmanaged devices
+type UnmanagedDeviceList struct {
+	Total       int               `json:"total,omitempty"`       // "total": 1,
+	Count       int               `json:"count,omitempty"`       // "count": 1,
+	Start       int               `json:"start,omitempty"`       // "start": 0,
+	PrevPageURI utils.Nstring     `json:"prevPageUri,omitempty"` // "prevPageUri": null,
+	NextPageURI utils.Nstring     `json:"nextPageUri,omitempty"` // "nextPageUri": null,
+	URI         utils.Nstring     `json:"uri,omitempty"`         // "uri": "/rest/unmanaged-devices?start=0&count=10",
+	Members     []UnmanagedDevice `json:"members,omitempty"`     // "members":[]
+}
+
+// GetUnmanagedDevices gets every unmanaged device
+func (c *OVClient) GetUnmanagedDevices(filter string, sort string) (UnmanagedDeviceList, error) {
+	var (
+		devices UnmanagedDeviceList
+		q       = make(map[string]interface{})
+	)
+	if filter != "" {
+		q["filter"] = filter
+	}
+	if sort != "" {
+		q["sort"] = sort
+	}
+	err := c.Iterate("/rest/unmanaged-devices", q, func(raw json.RawMessage) error {
+		var device UnmanagedDevice
+		if err := json.Unmarshal(raw, &device); err != nil {
+			return err
+		}
+		devices.Members = append(devices.Members, device)
+		return nil
+	})
+	if err != nil {
+		return devices, err
+	}
+	devices.Total = len(devices.Members)
+	devices.Count = len(devices.Members)
+	return devices, nil
+}
+
+// GetUnmanagedDeviceByName gets an unmanaged device by name, an empty UnmanagedDevice is returned when not found
+func (c *OVClient) GetUnmanagedDeviceByName(name string) (UnmanagedDevice, error) {
+	var (
+		device UnmanagedDevice
+	)
+	devices, err := c.GetUnmanagedDevices(fmt.Sprintf("name='%s'", name), "name:asc")
+	if devices.Total > 0 {
+		return devices.Members[0], err
+	}
+	return device, err
+}
+
+// CreateUnmanagedDevice records an unmanaged device
+func (c *OVClient) CreateUnmanagedDevice(device UnmanagedDevice) error {
+	log.Infof("Initializing creation of unmanaged device %s.", device.Name)
+	var (
+		uri = "/rest/unmanaged-devices"
+	)
+	if device.Name == "" {
+		return errors.New("Error creating unmanaged device, no name provided")
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	log.Debugf("REST : %s \n %+v\n", uri, device)
+	data, err := c.RestAPICall(rest.POST, uri, device)
+	if err != nil {
+		log.Errorf("Error submitting create unmanaged device request: %s", err)
+		return err
+	}
+	log.Debugf("Response create unmanaged device %s", data)
+	return nil
+}
+
+// UpdateUnmanagedDevice updates an unmanaged device
+func (c *OVClient) UpdateUnmanagedDevice(device UnmanagedDevice) error {
+	log.Infof("Initializing update of unmanaged device %s.", device.Name)
+	var (
+		uri = device.URI.String()
+	)
+	if device.URI.IsNil() {
+		return errors.New("Error updating unmanaged device, unmanaged device URI is empty")
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	log.Debugf("REST : %s \n %+v\n", uri, device)
+	data, err := c.RestAPICall(rest.PUT, uri, device)
+	if err != nil {
+		log.Errorf("Error submitting update unmanaged device request: %s", err)
+		return err
+	}
+	log.Debugf("Response update unmanaged device %s", data)
+	return nil
+}
+
+// DeleteUnmanagedDevice deletes an unmanaged device, a device that does not exist is skipped
+func (c *OVClient) DeleteUnmanagedDevice(name string) error {
+	device, err := c.GetUnmanagedDeviceByName(name)
+	if err != nil {
+		return err
+	}
+	if device.URI.IsNil() {
+		log.Infof("Unmanaged device could not be found to delete, %s, skipping delete ...", name)
+		return nil
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	log.Debugf("REST : %s \n", device.URI)
+	data, err := c.RestAPICall(rest.DELETE, device.URI.String(), nil)
+	if err != nil {
+		log.Errorf("Error submitting delete unmanaged device request: %s", err)
+		return err
+	}
+	log.Debugf("Response delete unmanaged device %s", data)
+	return nil
+}
+
+// DeleteAllUnmanagedDevices deletes every unmanaged device matching filter and waits on the task,
+// the filter is required so all devices are not deleted by mistake
+func (c *OVClient) DeleteAllUnmanagedDevices(filter string) error {
+	var (
+		uri = "/rest/unmanaged-devices"
+		q   = map[string]interface{}{"filter": filter}
+		t   *Task
+	)
+	if filter == "" {
+		return errors.New("Error deleting unmanaged devices, no filter provided")
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	t = t.NewProfileTask(c)
+	t.ResetTask()
+	log.Debugf("REST : %s \n %s\n", uri, filter)
+	data, err := c.RestAPICallWithQuery(rest.DELETE, uri, q, nil)
+	if err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error submitting delete unmanaged devices request: %s", err)
+		return err
+	}
+
+	log.Debugf("Response delete unmanaged devices %s", data)
+	if len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, &t); err != nil {
+		t.TaskIsDone = true
+		log.Errorf("Error with task un-marshal: %s", err)
+		return err
+	}
+	if !strings.HasPrefix(t.URI.String(), "/rest/tasks/") {
+		return nil
+	}
+	return t.Wait()
+}
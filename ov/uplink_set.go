@@ -2,7 +2,9 @@ package ov
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/HewlettPackard/oneview-golang/rest"
 	"github.com/HewlettPackard/oneview-golang/utils"
@@ -120,11 +122,7 @@ func (c *OVClient) GetUplinkSets(start string, count string, filter string, sort
 	// refresh login
 	c.RefreshLogin()
 	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
-	// Setup query
-	if len(q) > 0 {
-		c.SetQueryString(q)
-	}
-	data, err := c.RestAPICall(rest.GET, uri, nil)
+	data, err := c.RestAPICallWithQuery(rest.GET, uri, q, nil)
 	if err != nil {
 		return uplinkSets, err
 	}
@@ -155,8 +153,64 @@ func (c *OVClient) GetUplinkSetById(id string) ([]string, error) {
 	return *uplinkSetId, nil
 }
 
+// ValidateUplinkSetNetworks checks the ethernet networks of the uplink set have the uplink set
+// ethernetNetworkType, Untagged and Tunnel uplink sets carry a single network. The networks are read
+// in one filtered list request. Returns a single error listing every mismatched or missing network,
+// nothing is checked when the type is not set.
+func (c *OVClient) ValidateUplinkSetNetworks(upSet UplinkSet) error {
+	if upSet.EthernetNetworkType == "" || (upSet.NetworkType != "" && upSet.NetworkType != "Ethernet") {
+		return nil
+	}
+	var (
+		problems []string
+		filters  []string
+		wanted   = make(map[utils.Nstring]bool)
+		found    = make(map[utils.Nstring]bool)
+	)
+	if upSet.EthernetNetworkType != "Tagged" && len(upSet.NetworkURIs) > 1 {
+		problems = append(problems, fmt.Sprintf("a %s uplink set carries a single network, got %d", upSet.EthernetNetworkType, len(upSet.NetworkURIs)))
+	}
+	for _, uri := range upSet.NetworkURIs {
+		if !strings.HasPrefix(uri.String(), "/rest/ethernet-networks/") || wanted[uri] {
+			continue
+		}
+		wanted[uri] = true
+		filters = append(filters, fmt.Sprintf("uri='%s'", uri))
+	}
+	if len(filters) > 0 {
+		q := map[string]interface{}{"filter": strings.Join(filters, " OR ")}
+		err := c.Iterate("/rest/ethernet-networks", q, func(raw json.RawMessage) error {
+			var network EthernetNetwork
+			if err := json.Unmarshal(raw, &network); err != nil {
+				return err
+			}
+			found[network.URI] = true
+			if network.EthernetNetworkType != upSet.EthernetNetworkType {
+				problems = append(problems, fmt.Sprintf("network %s is %s, the uplink set is %s", network.Name, network.EthernetNetworkType, upSet.EthernetNetworkType))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for _, uri := range upSet.NetworkURIs {
+		if wanted[uri] && !found[uri] {
+			problems = append(problems, fmt.Sprintf("%s could not be found", uri))
+			found[uri] = true
+		}
+	}
+	if len(problems) > 0 {
+		return errors.New("Error validating uplink set networks: " + strings.Join(problems, ", "))
+	}
+	return nil
+}
+
 func (c *OVClient) CreateUplinkSet(upSet UplinkSet) error {
 	log.Infof("Initializing creation of uplink-set for %s.", upSet.Name)
+	if err := c.ValidateUplinkSetNetworks(upSet); err != nil {
+		return err
+	}
 	var (
 		uri = "/rest/uplink-sets"
 		t   *Task
@@ -239,6 +293,9 @@ func (c *OVClient) DeleteUplinkSet(name string) error {
 
 func (c *OVClient) UpdateUplinkSet(upSet UplinkSet) error {
 	log.Infof("Initializing update of uplink-set for %s.", upSet.Name)
+	if err := c.ValidateUplinkSetNetworks(upSet); err != nil {
+		return err
+	}
 	var (
 		uri = upSet.URI.String()
 		t   *Task
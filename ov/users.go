@@ -0,0 +1,227 @@
+/*
+(c) Copyright [2021] Hewlett Packard Enterprise Development LP
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ov -
+package ov
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/docker/machine/libmachine/log"
+)
+
+// Permission grants a role to a user, limited to a scope when ScopeUri is set
+type Permission struct {
+	RoleName string        `json:"roleName,omitempty"` // "roleName": "Server administrator",
+	ScopeUri utils.Nstring `json:"scopeUri,omitempty"` // "scopeUri": "/rest/scopes/1"
+}
+
+// User local appliance user, Password is only sent on create and update
+type User struct {
+	Category     string        `json:"category,omitempty"`     // "category": "users",
+	Created      string        `json:"created,omitempty"`      // "created": "2021-03-10T10:20:11.000Z",
+	EmailAddress string        `json:"emailAddress,omitempty"` // "emailAddress": "svc@example.com",
+	Enabled      bool          `json:"enabled"`                // "enabled": true,
+	ETAG         string        `json:"eTag,omitempty"`         // "eTag": "2021-03-10T10:20:11.000Z",
+	FullName     string        `json:"fullName,omitempty"`     // "fullName": "Service account",
+	MobilePhone  string        `json:"mobilePhone,omitempty"`  // "mobilePhone": "",
+	Modified     string        `json:"modified,omitempty"`     // "modified": "2021-03-10T10:20:11.000Z",
+	OfficePhone  string        `json:"officePhone,omitempty"`  // "officePhone": "",
+	Password     string        `json:"password,omitempty"`     // "password": "secret",
+	Permissions  []Permission  `json:"permissions,omitempty"`  // "permissions": [],
+	Type         string        `json:"type,omitempty"`         // "type": "UserAndPermissions",
+	URI          utils.Nstring `json:"uri,omitempty"`          // "uri": "/rest/users/svc-deploy",
+	UserName     string        `json:"userName,omitempty"`     // "userName": "svc-deploy"
+}
+
+// UserList list of local users
+type UserList struct {
+	Total       int           `json:"total,omitempty"`       // "total": 1,
+	Count       int           `json:"count,omitempty"`       // "count": 1,
+	Start       int           `json:"start,omitempty"`       // "start": 0,
+	PrevPageURI utils.Nstring `json:"prevPageUri,omitempty"` // "prevPageUri": null,
+	NextPageURI utils.Nstring `json:"nextPageUri,omitempty"` // "nextPageUri": null,
+	URI         utils.Nstring `json:"uri,omitempty"`         // "uri": "/rest/users?start=0&count=10",
+	Members     []User        `json:"members,omitempty"`     // "members":[]
+}
+
+// Role permission role that can be granted to a user
+type Role struct {
+	Category string        `json:"category,omitempty"` // "category": "roles",
+	RoleName string        `json:"roleName,omitempty"` // "roleName": "Infrastructure administrator",
+	Type     string        `json:"type,omitempty"`     // "type": "RoleV2",
+	URI      utils.Nstring `json:"uri,omitempty"`      // "uri": "/rest/roles/Infrastructure administrator"
+}
+
+// RoleList list of permission roles
+type RoleList struct {
+	Total       int           `json:"total,omitempty"`       // "total": 1,
+	Count       int           `json:"count,omitempty"`       // "count": 1,
+	Start       int           `json:"start,omitempty"`       // "start": 0,
+	PrevPageURI utils.Nstring `json:"prevPageUri,omitempty"` // "prevPageUri": null,
+	NextPageURI utils.Nstring `json:"nextPageUri,omitempty"` // "nextPageUri": null,
+	URI         utils.Nstring `json:"uri,omitempty"`         // "uri": "/rest/roles?start=0&count=10",
+	Members     []Role        `json:"members,omitempty"`     // "members":[]
+}
+
+// GetUsers gets every local user
+func (c *OVClient) GetUsers() (UserList, error) {
+	var (
+		users UserList
+	)
+	err := c.Iterate("/rest/users", nil, func(raw json.RawMessage) error {
+		var user User
+		if err := json.Unmarshal(raw, &user); err != nil {
+			return err
+		}
+		users.Members = append(users.Members, user)
+		return nil
+	})
+	if err != nil {
+		return users, err
+	}
+	users.Total = len(users.Members)
+	users.Count = len(users.Members)
+	return users, nil
+}
+
+// GetUserByName gets a local user by user name, an empty User is returned when not found
+func (c *OVClient) GetUserByName(name string) (User, error) {
+	var (
+		user User
+	)
+	if name == "" {
+		return user, errors.New("Error getting user, no user name provided")
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+	data, err := c.RestAPICall(rest.GET, "/rest/users/"+name, nil)
+	if err != nil {
+		if rest.IsNotFound(err) {
+			return user, nil
+		}
+		return user, err
+	}
+	log.Debugf("GetUserByName %s", data)
+	if err := json.Unmarshal(data, &user); err != nil {
+		return user, err
+	}
+	return user, nil
+}
+
+// CreateUser creates a local user with a password and its permissions
+func (c *OVClient) CreateUser(user User) error {
+	log.Infof("Initializing creation of user %s.", user.UserName)
+	var (
+		uri = "/rest/users"
+	)
+	if user.UserName == "" || user.Password == "" {
+		return errors.New("Error creating user, a user name and password are required")
+	}
+	if len(user.Permissions) == 0 {
+		return errors.New("Error creating user, at least one permission is required")
+	}
+	if user.Type == "" {
+		user.Type = "UserAndPermissions"
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	log.Debugf("REST : %s \n", uri)
+	data, err := c.RestAPICall(rest.POST, uri, user)
+	if err != nil {
+		log.Errorf("Error submitting create user request: %s", err)
+		return err
+	}
+	log.Debugf("Response create user %s", data)
+	return nil
+}
+
+// UpdateUser updates a local user, the password is only changed when set
+func (c *OVClient) UpdateUser(user User) error {
+	log.Infof("Initializing update of user %s.", user.UserName)
+	var (
+		uri = "/rest/users"
+	)
+	if user.UserName == "" {
+		return errors.New("Error updating user, no user name provided")
+	}
+	if user.Type == "" {
+		user.Type = "UserAndPermissions"
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	log.Debugf("REST : %s \n", uri)
+	data, err := c.RestAPICall(rest.PUT, uri, user)
+	if err != nil {
+		log.Errorf("Error submitting update user request: %s", err)
+		return err
+	}
+	log.Debugf("Response update user %s", data)
+	return nil
+}
+
+// DeleteUser deletes a local user, a user that does not exist is skipped
+func (c *OVClient) DeleteUser(name string) error {
+	user, err := c.GetUserByName(name)
+	if err != nil {
+		return err
+	}
+	if user.UserName == "" {
+		log.Infof("User could not be found to delete, %s, skipping delete ...", name)
+		return nil
+	}
+	// refresh login
+	c.RefreshLogin()
+	c.SetAuthHeaderOptions(c.GetAuthHeaderMap())
+
+	log.Debugf("REST : %s \n", "/rest/users/"+user.UserName)
+	data, err := c.RestAPICall(rest.DELETE, "/rest/users/"+user.UserName, nil)
+	if err != nil {
+		log.Errorf("Error submitting delete user request: %s", err)
+		return err
+	}
+	log.Debugf("Response delete user %s", data)
+	return nil
+}
+
+// GetRoles gets the permission roles that can be granted to users
+func (c *OVClient) GetRoles() (RoleList, error) {
+	var (
+		roles RoleList
+	)
+	err := c.Iterate("/rest/roles", nil, func(raw json.RawMessage) error {
+		var role Role
+		if err := json.Unmarshal(raw, &role); err != nil {
+			return err
+		}
+		roles.Members = append(roles.Members, role)
+		return nil
+	})
+	if err != nil {
+		return roles, err
+	}
+	roles.Total = len(roles.Members)
+	roles.Count = len(roles.Members)
+	return roles, nil
+}
@@ -0,0 +1,59 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrStaleResource is wrapped by the error returned when the appliance answers 412 Precondition Failed,
+// the If-Match eTag sent with the request no longer matches the resource
+var ErrStaleResource = errors.New("resource was modified since it was read")
+
+// ApiError - error returned by RestAPICall for any response outside the ok status codes,
+// the fields are parsed from the standard OneView error payload
+type ApiError struct {
+	StatusCode         int      `json:"-"`
+	Status             string   `json:"-"`
+	ErrorCode          string   `json:"errorCode,omitempty"`          // "errorCode": "RESOURCE_NOT_FOUND",
+	Message            string   `json:"message,omitempty"`            // "message": "The requested resource could not be found.",
+	Details            string   `json:"details,omitempty"`            // "details": "",
+	RecommendedActions []string `json:"recommendedActions,omitempty"` // "recommendedActions": ["Verify parameters and try again."],
+}
+
+func (e *ApiError) Error() string {
+	return fmt.Sprintf("Error in response: %s\n Response Status: %s\n Response Details: %s", e.Message, e.Status, e.Details)
+}
+
+// Unwrap lets errors.Is match ErrStaleResource on 412 Precondition Failed
+func (e *ApiError) Unwrap() error {
+	if e.StatusCode == http.StatusPreconditionFailed {
+		return ErrStaleResource
+	}
+	return nil
+}
+
+// statusCode returns the status code of an ApiError found in the err chain, 0 otherwise
+func statusCode(err error) int {
+	var apiErr *ApiError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound - true when err is an ApiError for 404 Not Found
+func IsNotFound(err error) bool {
+	return statusCode(err) == http.StatusNotFound
+}
+
+// IsUnauthorized - true when err is an ApiError for 401 Unauthorized, the session expired or was never valid
+func IsUnauthorized(err error) bool {
+	return statusCode(err) == http.StatusUnauthorized
+}
+
+// IsServerError - true when err is an ApiError for a 5xx status, the request may succeed when retried
+func IsServerError(err error) bool {
+	code := statusCode(err)
+	return code >= http.StatusInternalServerError && code < 600
+}
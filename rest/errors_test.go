@@ -0,0 +1,55 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestApiError(t *testing.T) {
+	ts, endpoint, path := getServer(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errorCode": "RESOURCE_NOT_FOUND", "message": "The requested resource could not be found.", "recommendedActions": ["Verify the URI and try again."]}`))
+	})
+	defer ts.Close()
+
+	c := empty.NewClient("", "", endpoint)
+	_, err := c.RestAPICall(GET, path, nil)
+
+	var apiErr *ApiError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected an ApiError, received %v", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.ErrorCode != "RESOURCE_NOT_FOUND" || len(apiErr.RecommendedActions) != 1 {
+		t.Logf("ApiError was not parsed from the response: %+v", apiErr)
+		t.Fail()
+	}
+	if !strings.Contains(err.Error(), "The requested resource could not be found.") {
+		t.Logf("Expected the message in the error, received %q", err.Error())
+		t.Fail()
+	}
+	if !IsNotFound(err) || IsUnauthorized(err) || IsServerError(err) {
+		t.Logf("Expected only IsNotFound to match %v", err)
+		t.Fail()
+	}
+	if IsNotFound(errors.New("not an api error")) {
+		t.Logf("IsNotFound should not match a plain error")
+		t.Fail()
+	}
+}
+
+func TestApiErrorServerError(t *testing.T) {
+	ts, endpoint, path := getServer(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer ts.Close()
+
+	c := empty.NewClient("", "", endpoint)
+	_, err := c.RestAPICall(GET, path, nil)
+
+	if !IsServerError(err) {
+		t.Logf("Expected IsServerError to match %v", err)
+		t.Fail()
+	}
+}
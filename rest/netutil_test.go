@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -54,6 +55,21 @@ func Test404Request(t *testing.T) {
 	}
 }
 
+func Test412Request(t *testing.T) {
+	ts, endpoint, path := getServer(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPreconditionFailed)
+	})
+	defer ts.Close()
+
+	c := empty.NewClient("", "", endpoint)
+	_, err := c.RestAPICall(PUT, path, nil)
+
+	if !errors.Is(err, ErrStaleResource) {
+		t.Logf("Expected ErrStaleResource, received %v", err)
+		t.Fail()
+	}
+}
+
 func TestHeaderAuthenticaiton(t *testing.T) {
 	var (
 		token = "abcdef123"
@@ -100,6 +116,54 @@ func TestHeaderAuthenticaiton(t *testing.T) {
 	}
 }
 
+func TestRestAPICallWithQuery(t *testing.T) {
+	var queries []string
+	ts, endpoint, _ := getServer(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+	})
+	defer ts.Close()
+
+	c := empty.NewClient("", "", endpoint)
+	c.SetQueryString(map[string]interface{}{"filter": "stale"})
+	if _, err := c.RestAPICallWithQuery(GET, "/rest/items", map[string]interface{}{"filter": "name='a b'"}, nil); err != nil {
+		t.Logf("Unexpected error: %s", err.Error())
+		t.Fail()
+	}
+	if _, err := c.RestAPICallWithQuery(GET, "/rest/items", nil, nil); err != nil {
+		t.Logf("Unexpected error: %s", err.Error())
+		t.Fail()
+	}
+	// the query string set on the client applies to the next RestAPICall only
+	c.SetQueryString(map[string]interface{}{"sort": "name:asc"})
+	c.RestAPICall(GET, "/rest/items", nil)
+	c.RestAPICall(GET, "/rest/items", nil)
+
+	expected := []string{"filter=name%3D%27a+b%27", "", "sort=name%3Aasc", ""}
+	if fmt.Sprint(queries) != fmt.Sprint(expected) {
+		t.Logf("Expected queries %q, got %q", expected, queries)
+		t.Fail()
+	}
+}
+
+func TestRestAPICallEscapedPath(t *testing.T) {
+	var paths []string
+	ts, endpoint, _ := getServer(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+	})
+	defer ts.Close()
+
+	c := empty.NewClient("", "", endpoint)
+	c.RestAPICall(GET, "/rest/items/1/statistics/"+url.PathEscape("1/1"), nil)
+	c.RestAPICall(GET, "/rest/items/a b", nil)
+
+	// escaped segments go on the wire once, unescaped ones are still escaped
+	expected := []string{"/rest/items/1/statistics/1%2F1", "/rest/items/a%20b"}
+	if fmt.Sprint(paths) != fmt.Sprint(expected) {
+		t.Logf("Expected paths %q, got %q", expected, paths)
+		t.Fail()
+	}
+}
+
 func getServer(h func(http.ResponseWriter, *http.Request)) (*httptest.Server, string, string) {
 	ts := httptest.NewServer(http.HandlerFunc(h))
 	endpoint, path := getEndpointAndPath(ts)
@@ -0,0 +1,83 @@
+package rest
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryConfig - retry policy consulted by RestAPICall, a nil policy never retries.
+// GET requests are retried automatically, other methods only when listed in Methods.
+type RetryConfig struct {
+	MaxRetries int                   // retries after the first attempt
+	BaseDelay  time.Duration         // delay before the first retry, doubled on every retry
+	MaxDelay   time.Duration         // upper bound of the delay, 0 means no bound
+	Retryable  func(status int) bool // status codes worth retrying, defaults to RetryableStatus
+	Methods    []Method              // non idempotent methods that may also be retried, e.g. POST, DELETE
+}
+
+// RetryableStatus - default retry predicate, true for 429 and the 502, 503 and 504 gateway errors
+func RetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retries - true when requests with method may be retried
+func (r *RetryConfig) retries(method Method) bool {
+	if method == GET {
+		return true
+	}
+	for _, m := range r.Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// retryDelay - returns how long to wait before retrying the request that got resp,
+// false when the request should not be retried
+func (r *RetryConfig) retryDelay(method Method, attempt int, resp *http.Response) (time.Duration, bool) {
+	if r == nil || attempt >= r.MaxRetries || !r.retries(method) {
+		return 0, false
+	}
+	retryable := r.Retryable
+	if retryable == nil {
+		retryable = RetryableStatus
+	}
+	if !retryable(resp.StatusCode) {
+		return 0, false
+	}
+
+	// honor the appliance Retry-After, either in seconds or as an http date
+	if after := resp.Header.Get("Retry-After"); after != "" {
+		if seconds, err := strconv.Atoi(after); err == nil && seconds >= 0 {
+			return r.capDelay(time.Duration(seconds) * time.Second), true
+		}
+		if at, err := http.ParseTime(after); err == nil {
+			delay := time.Until(at)
+			if delay < 0 {
+				delay = 0
+			}
+			return r.capDelay(delay), true
+		}
+	}
+
+	// exponential backoff with jitter, between half and the full delay
+	delay := r.capDelay(r.BaseDelay << uint(attempt))
+	if delay > 1 {
+		delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+	}
+	return delay, true
+}
+
+func (r *RetryConfig) capDelay(delay time.Duration) time.Duration {
+	if r.MaxDelay > 0 && (delay > r.MaxDelay || delay < 0) {
+		return r.MaxDelay
+	}
+	return delay
+}
@@ -0,0 +1,102 @@
+package rest
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryTransientFailure(t *testing.T) {
+	calls := 0
+	ts, endpoint, path := getServer(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("Ok!"))
+	})
+	defer ts.Close()
+
+	c := empty.NewClient("", "", endpoint)
+	c.Retry = &RetryConfig{MaxRetries: 3, BaseDelay: time.Millisecond}
+	res, err := c.RestAPICall(GET, path, nil)
+
+	if err != nil || string(res) != "Ok!" {
+		t.Logf("Expected the third attempt to succeed, received %q, %v", string(res), err)
+		t.Fail()
+	}
+	if calls != 3 {
+		t.Logf("Expected 3 calls, got %d", calls)
+		t.Fail()
+	}
+}
+
+func TestRetryOnlyIdempotentByDefault(t *testing.T) {
+	calls := 0
+	ts, endpoint, path := getServer(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer ts.Close()
+
+	c := empty.NewClient("", "", endpoint)
+	c.Retry = &RetryConfig{MaxRetries: 2, BaseDelay: time.Millisecond}
+	if _, err := c.RestAPICall(POST, path, nil); !IsServerError(err) {
+		t.Logf("Expected a server error, received %v", err)
+		t.Fail()
+	}
+	if calls != 1 {
+		t.Logf("POST should not be retried unless opted in, got %d calls", calls)
+		t.Fail()
+	}
+
+	calls = 0
+	c.Retry.Methods = []Method{POST}
+	c.RestAPICall(POST, path, nil)
+	if calls != 3 {
+		t.Logf("Expected POST to be retried twice once opted in, got %d calls", calls)
+		t.Fail()
+	}
+}
+
+func TestRetryNotRetryableStatus(t *testing.T) {
+	calls := 0
+	ts, endpoint, path := getServer(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	defer ts.Close()
+
+	c := empty.NewClient("", "", endpoint)
+	c.Retry = &RetryConfig{MaxRetries: 3, BaseDelay: time.Millisecond}
+	c.RestAPICall(GET, path, nil)
+	if calls != 1 {
+		t.Logf("400 should not be retried, got %d calls", calls)
+		t.Fail()
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	r := &RetryConfig{MaxRetries: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+	resp := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}
+	for attempt, max := range []time.Duration{100, 200, 300, 300} {
+		delay, ok := r.retryDelay(GET, attempt, resp)
+		if !ok || delay < max*time.Millisecond/2 || delay > max*time.Millisecond {
+			t.Logf("attempt %d: delay %s is outside the jittered backoff up to %dms", attempt, delay, max)
+			t.Fail()
+		}
+	}
+	if _, ok := r.retryDelay(GET, 5, resp); ok {
+		t.Logf("Expected no retry after MaxRetries")
+		t.Fail()
+	}
+
+	resp.Header.Set("Retry-After", "2")
+	r.MaxDelay = 0
+	if delay, _ := r.retryDelay(GET, 0, resp); delay != 2*time.Second {
+		t.Logf("Expected Retry-After to be honored, got %s", delay)
+		t.Fail()
+	}
+}
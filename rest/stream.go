@@ -0,0 +1,98 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/docker/machine/libmachine/log"
+)
+
+// RestAPIDownload - GET path and copy the response body to w as it arrives,
+// for large files such as backups that should not be held in memory
+func (c *Client) RestAPIDownload(path string, w io.Writer) error {
+	resp, err := c.doStream(GET, path, nil, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// RestAPIUpload - POST r to path as the file part of a multipart form, the form is
+// written to the request while it is sent so r is never read into memory
+func (c *Client) RestAPIUpload(path string, fileName string, r io.Reader) ([]byte, error) {
+	pr, pw := io.Pipe()
+	form := multipart.NewWriter(pw)
+	go func() {
+		part, err := form.CreateFormFile("file", fileName)
+		if err == nil {
+			_, err = io.Copy(part, r)
+		}
+		if err == nil {
+			err = form.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	resp, err := c.doStream(POST, path, pr, form.FormDataContentType())
+	pr.Close()
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 && resp.Header.Get("Location") != "" {
+		data = []byte(`{"URI":"` + resp.Header.Get("Location") + `"}`)
+	}
+	return data, nil
+}
+
+// doStream - send a request with an unbuffered body, the caller closes the response body
+func (c *Client) doStream(method Method, path string, body io.Reader, contentType string) (*http.Response, error) {
+	log.Debugf("RestAPIStream %s - %s%s", method, utils.Sanatize(c.Endpoint), path)
+	Url, err := url.Parse(utils.Sanatize(c.Endpoint))
+	if err != nil {
+		return nil, err
+	}
+	joinPath(Url, path)
+
+	req, err := http.NewRequest(method.String(), Url.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("Error with request: %v - %q", Url, err)
+	}
+
+	for k, v := range c.takeOptions().Headers {
+		req.Header.Add(k, v)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	// no overall time limit, a large archive may take longer than any request timeout
+	t, err := c.transport()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := (&http.Client{Transport: t}).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if !c.isOkStatus(resp.StatusCode) {
+		defer resp.Body.Close()
+		apiErr := &ApiError{StatusCode: resp.StatusCode, Status: resp.Status}
+		data, _ := ioutil.ReadAll(resp.Body)
+		json.Unmarshal(data, apiErr)
+		return nil, apiErr
+	}
+	return resp, nil
+}
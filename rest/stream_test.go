@@ -0,0 +1,63 @@
+package rest
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRestAPIDownload(t *testing.T) {
+	ts, endpoint, path := getServer(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("backup contents"))
+	})
+	defer ts.Close()
+
+	c := empty.NewClient("", "", endpoint)
+	var buf bytes.Buffer
+	if err := c.RestAPIDownload(path, &buf); err != nil || buf.String() != "backup contents" {
+		t.Logf("Expected the body to be copied to the writer, received %q, %v", buf.String(), err)
+		t.Fail()
+	}
+}
+
+func TestRestAPIDownloadError(t *testing.T) {
+	ts, endpoint, path := getServer(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer ts.Close()
+
+	c := empty.NewClient("", "", endpoint)
+	var buf bytes.Buffer
+	if err := c.RestAPIDownload(path, &buf); statusCode(err) != http.StatusNotFound {
+		t.Logf("Expected a 404 ApiError, received %v", err)
+		t.Fail()
+	}
+}
+
+func TestRestAPIUpload(t *testing.T) {
+	ts, endpoint, path := getServer(func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Logf("Expected a multipart file, %v", err)
+			t.Fail()
+			return
+		}
+		data, _ := ioutil.ReadAll(file)
+		if header.Filename != "backup.bkp" || string(data) != "backup contents" {
+			t.Logf("Unexpected upload %q, %q", header.Filename, string(data))
+			t.Fail()
+		}
+		w.Header().Set("Location", "/rest/tasks/1")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	defer ts.Close()
+
+	c := empty.NewClient("", "", endpoint)
+	res, err := c.RestAPIUpload(path, "backup.bkp", strings.NewReader("backup contents"))
+	if err != nil || string(res) != `{"URI":"/rest/tasks/1"}` {
+		t.Logf("Expected the task location, received %q, %v", string(res), err)
+		t.Fail()
+	}
+}
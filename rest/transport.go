@@ -0,0 +1,78 @@
+package rest
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// timeouts applied when Client.Timeout and Client.DialTimeout are not set
+const (
+	DefaultTimeout     = 2 * time.Minute
+	DefaultDialTimeout = 30 * time.Second
+)
+
+// transportKey - settings that require a dedicated transport
+type transportKey struct {
+	proxyURL    string
+	caPool      *x509.CertPool
+	dialTimeout time.Duration
+}
+
+// transport - transport for the settings of c. Without a ProxyURL, CACertPool or DialTimeout the shared
+// transport is used, it honors HTTPS_PROXY, HTTP_PROXY and NO_PROXY and skips certificate verification.
+// Otherwise the transport is built on first use and kept on the client, so its connection pool is reused
+// by later requests and released with the client. It is rebuilt when the settings change.
+func (c *Client) transport() (*http.Transport, error) {
+	key := transportKey{proxyURL: c.ProxyURL, caPool: c.CACertPool, dialTimeout: c.DialTimeout}
+	if key == (transportKey{}) {
+		return tr, nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.tr != nil && c.trKey == key {
+		return c.tr, nil
+	}
+
+	t := tr.Clone()
+	if key.proxyURL != "" {
+		proxy, err := url.Parse(key.proxyURL)
+		if err != nil || proxy.Host == "" {
+			return nil, fmt.Errorf("Error with proxy: %q is not a valid proxy url", key.proxyURL)
+		}
+		// user info in the url is sent as Proxy-Authorization by the transport
+		t.Proxy = http.ProxyURL(proxy)
+	}
+	if key.caPool != nil {
+		// the appliance certificate must chain to the pool, verification is never skipped
+		t.TLSClientConfig = &tls.Config{RootCAs: key.caPool, InsecureSkipVerify: false}
+	}
+	if key.dialTimeout > 0 {
+		t.DialContext = (&net.Dialer{Timeout: key.dialTimeout, KeepAlive: 30 * time.Second}).DialContext
+	}
+	if c.tr != nil {
+		c.tr.CloseIdleConnections()
+	}
+	c.tr, c.trKey = t, key
+	return t, nil
+}
+
+// httpClient - http client for a single request, bounded by Client.Timeout.
+// Task polling issues one request per check, so only each check is bounded, not the wait.
+func (c *Client) httpClient() (*http.Client, error) {
+	t, err := c.transport()
+	if err != nil {
+		return nil, err
+	}
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	} else if timeout < 0 {
+		timeout = 0
+	}
+	return &http.Client{Transport: t, Timeout: timeout}, nil
+}
@@ -0,0 +1,117 @@
+package rest
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestProxyURL(t *testing.T) {
+	proxied := false
+	ts, _, _ := getServer(func(w http.ResponseWriter, r *http.Request) {
+		proxied = true
+		auth := "Basic " + base64.StdEncoding.EncodeToString([]byte("user:secret"))
+		if r.Host != "appliance.invalid" || r.Header.Get("Proxy-Authorization") != auth {
+			t.Logf("Unexpected proxied request to %q with authorization %q", r.Host, r.Header.Get("Proxy-Authorization"))
+			t.Fail()
+		}
+		w.Write([]byte("Ok!"))
+	})
+	defer ts.Close()
+
+	c := empty.NewClient("", "", "http://appliance.invalid")
+	c.ProxyURL = "http://user:secret@" + ts.Listener.Addr().String()
+	res, err := c.RestAPICall(GET, "/rest/version", nil)
+	if err != nil || string(res) != "Ok!" || !proxied {
+		t.Logf("Expected the request to go through the proxy, received %q, %v", string(res), err)
+		t.Fail()
+	}
+}
+
+func TestProxyURLInvalid(t *testing.T) {
+	c := empty.NewClient("", "", "http://appliance.invalid")
+	c.ProxyURL = "://bad"
+	if _, err := c.RestAPICall(GET, "/rest/version", nil); err == nil {
+		t.Log("Expected an invalid proxy url to be refused")
+		t.Fail()
+	}
+}
+
+func TestCACertPool(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Ok!"))
+	}))
+	defer ts.Close()
+
+	trusted := x509.NewCertPool()
+	trusted.AddCert(ts.Certificate())
+	c := empty.NewClient("", "", ts.URL)
+	c.CACertPool = trusted
+	if res, err := c.RestAPICall(GET, "/rest/version", nil); err != nil || string(res) != "Ok!" {
+		t.Logf("Expected the appliance certificate to verify against the pool, received %q, %v", string(res), err)
+		t.Fail()
+	}
+
+	c = empty.NewClient("", "", ts.URL)
+	c.CACertPool = x509.NewCertPool()
+	if _, err := c.RestAPICall(GET, "/rest/version", nil); err == nil {
+		t.Log("Expected a certificate outside the pool to be refused")
+		t.Fail()
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	ts, endpoint, path := getServer(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte("Ok!"))
+	})
+	defer ts.Close()
+
+	c := empty.NewClient("", "", endpoint)
+	c.Timeout = 10 * time.Millisecond
+	if _, err := c.RestAPICall(GET, path, nil); err == nil {
+		t.Log("Expected a slow response to time out")
+		t.Fail()
+	}
+
+	c.Timeout = -1
+	if res, err := c.RestAPICall(GET, path, nil); err != nil || string(res) != "Ok!" {
+		t.Logf("Expected no time limit with a negative timeout, received %q, %v", string(res), err)
+		t.Fail()
+	}
+}
+
+func TestTransportKeptOnClient(t *testing.T) {
+	c := empty.NewClient("", "", "https://appliance.invalid")
+	if tr1, err := c.transport(); err != nil || tr1 != tr {
+		t.Logf("Expected the shared transport without settings, received %v", err)
+		t.Fail()
+	}
+
+	c.CACertPool = x509.NewCertPool()
+	tr1, err := c.transport()
+	if err != nil || tr1 == tr {
+		t.Logf("Expected a dedicated transport for a CA pool, received %v", err)
+		t.Fail()
+	}
+	if tr2, _ := c.transport(); tr2 != tr1 {
+		t.Log("Expected the client to reuse its transport")
+		t.Fail()
+	}
+
+	other := empty.NewClient("", "", "https://appliance.invalid")
+	other.CACertPool = c.CACertPool
+	if tr2, _ := other.transport(); tr2 == tr1 {
+		t.Log("Expected each client to build its own transport")
+		t.Fail()
+	}
+
+	c.CACertPool = x509.NewCertPool()
+	if tr2, _ := c.transport(); tr2 == tr1 {
+		t.Log("Expected a new transport when the CA pool changes")
+		t.Fail()
+	}
+}
@@ -0,0 +1,50 @@
+package ov
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlerts(t *testing.T) {
+	var updates []map[string]string
+	deleted := false
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/alerts":
+			assert.Equal(t, "resourceUri='/rest/server-hardware/1'", r.URL.Query().Get("filter"))
+			w.Write([]byte(`{"total":1,"count":1,"members":[{"severity":"Warning","alertState":"Active","correctiveAction":"Replace the power supply.","uri":"/rest/alerts/1"}]}`))
+		case "/rest/alerts/1":
+			switch r.Method {
+			case http.MethodPut:
+				var body map[string]string
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				updates = append(updates, body)
+				w.Write([]byte(`{"uri":"/rest/alerts/1"}`))
+			case http.MethodDelete:
+				deleted = true
+				w.WriteHeader(http.StatusNoContent)
+			}
+		}
+	})
+	defer ts.Close()
+
+	alerts, err := c.GetAlertsByResource(utils.NewNstring("/rest/server-hardware/1"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, alerts.Total)
+	alert := alerts.Members[0]
+	assert.Equal(t, "Warning", alert.Severity)
+	assert.Equal(t, "Replace the power supply.", alert.CorrectiveAction)
+
+	assert.NoError(t, c.AssignAlert(alert.URI, "administrator"))
+	assert.NoError(t, c.AcknowledgeAlert(alert.URI))
+	assert.Equal(t, []map[string]string{{"assignedToUser": "administrator"}, {"alertState": "Cleared"}}, updates)
+
+	assert.NoError(t, c.DeleteAlert(alert.URI))
+	assert.True(t, deleted)
+
+	assert.Error(t, c.AcknowledgeAlert(utils.NewNstring("")))
+}
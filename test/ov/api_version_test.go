@@ -2,6 +2,7 @@ package ov
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"testing"
 
@@ -57,3 +58,32 @@ func TestRefreshVersion(t *testing.T) {
 	}
 
 }
+
+func TestGetAPIVersionRange(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"currentVersion": 2400, "minimumVersion": 120}`))
+	})
+	defer ts.Close()
+
+	min, current, max, err := c.GetAPIVersionRange()
+	assert.NoError(t, err)
+	assert.Equal(t, 120, min)
+	assert.Equal(t, 2400, current)
+	assert.Equal(t, 2400, max)
+}
+
+func TestForAPIVersion(t *testing.T) {
+	var versions []string
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		versions = append(versions, r.Header.Get("X-API-Version"))
+		w.Write([]byte(`{"members": []}`))
+	})
+	defer ts.Close()
+
+	_, err := c.ForAPIVersion(800).GetProfiles("", "", "", "", "")
+	assert.NoError(t, err)
+	_, err = c.GetProfiles("", "", "", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"800", "2400"}, versions)
+	assert.Equal(t, 2400, c.APIVersion)
+}
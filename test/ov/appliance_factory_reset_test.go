@@ -0,0 +1,21 @@
+package ov
+
+import (
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFactoryResetRequiresConfirmation(t *testing.T) {
+	var (
+		c *ov.OVClient
+	)
+	_, c = getTestDriverU("dev")
+
+	_, err := c.FactoryReset(ov.FactoryResetRequest{Mode: ov.FACTORY_RESET_FULL})
+	assert.Error(t, err, "FactoryReset should be refused without confirmation")
+
+	_, err = c.FactoryReset(ov.FactoryResetRequest{Mode: "PARTIAL", Confirm: true})
+	assert.Error(t, err, "FactoryReset should fail with an unknown mode")
+}
@@ -0,0 +1,77 @@
+package ov
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplianceCertificateRotation(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/certificates/https":
+			w.Write([]byte(`{"commonName":"ov.example.com","alternativeName":"ov.example.com, 192.0.2.10","validUntil":"2022-03-01T00:00:00.000Z"}`))
+		case "/rest/certificates/https/certificaterequest":
+			var body map[string]string
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			if r.Method == http.MethodPost {
+				assert.Equal(t, "CertificateSigningRequest", body["type"])
+				assert.Equal(t, "ov.example.com", body["commonName"])
+				w.Write([]byte(`{"base64Data":"-----BEGIN CERTIFICATE REQUEST-----"}`))
+				return
+			}
+			assert.Equal(t, "CertificateDataV2", body["type"])
+			assert.Equal(t, "-----BEGIN CERTIFICATE-----", body["base64Data"])
+			w.Write([]byte(`{"taskState":"Running","uri":"/rest/tasks/1"}`))
+		}
+	})
+	defer ts.Close()
+
+	cert, err := c.GetApplianceCertificate()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ov.example.com", "192.0.2.10"}, cert.AlternativeNames())
+	assert.Equal(t, "2022-03-01T00:00:00.000Z", cert.ValidUntil)
+
+	csr, err := c.GenerateCSR(ov.CertificateSigningRequest{CommonName: cert.CommonName})
+	assert.NoError(t, err)
+	assert.Equal(t, "-----BEGIN CERTIFICATE REQUEST-----", csr)
+
+	task, err := c.ImportCertificate("-----BEGIN CERTIFICATE-----")
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/tasks/1", task.URI.String())
+
+	_, err = c.GenerateCSR(ov.CertificateSigningRequest{})
+	assert.Error(t, err)
+	_, err = c.ImportCertificate(" ")
+	assert.Error(t, err)
+}
+
+func TestRegenerateApplianceSelfSignedCertificate(t *testing.T) {
+	var body ov.ApplianceHttpsCertificate
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/certificates/https" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method == http.MethodPut {
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			w.Write([]byte(`{"taskState":"Running","uri":"/rest/tasks/1"}`))
+			return
+		}
+		w.Write([]byte(`{"commonName":"old.example.com","alternativeName":"old.example.com,192.0.2.10","organization":"HPE","country":"US"}`))
+	})
+	defer ts.Close()
+
+	task, err := c.RegenerateApplianceSelfSignedCertificate("new.example.com", []string{"new.example.com", "192.0.2.20"})
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/tasks/1", task.URI.String())
+	assert.Equal(t, "new.example.com", body.CommonName)
+	assert.Equal(t, []string{"new.example.com", "192.0.2.20"}, body.AlternativeNames())
+	assert.Equal(t, "HPE", body.Organization, "the organization of the current certificate is kept")
+
+	_, err = c.RegenerateApplianceSelfSignedCertificate("", nil)
+	assert.Error(t, err)
+}
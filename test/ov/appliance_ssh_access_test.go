@@ -0,0 +1,35 @@
+package ov
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetSshAccess(t *testing.T) {
+	current := ov.ApplianceSshAccess{AllowSshAccess: true, URI: "/rest/appliance/ssh-access"}
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/appliance/ssh-access", r.URL.Path)
+		if r.Method == http.MethodPut {
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"allowSshAccess":false}`, string(body))
+			json.Unmarshal(body, &current)
+		}
+		data, _ := json.Marshal(current)
+		w.Write(data)
+	})
+	defer ts.Close()
+
+	access, err := c.GetSshAccess()
+	assert.NoError(t, err)
+	assert.True(t, access.AllowSshAccess)
+
+	assert.NoError(t, c.SetSshAccess(ov.ApplianceSshAccess{AllowSshAccess: false}))
+	access, err = c.GetSshAccess()
+	assert.NoError(t, err)
+	assert.False(t, access.AllowSshAccess)
+}
@@ -0,0 +1,60 @@
+package ov
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplianceTimeandLocalValidate(t *testing.T) {
+	valid := ov.ApplianceTimeandLocal{
+		Locale:     "en_US.UTF-8",
+		Timezone:   "UTC",
+		NtpServers: []utils.Nstring{"16.110.135.123", "ntp.example.com"},
+	}
+	assert.NoError(t, valid.Validate())
+
+	badZone := valid
+	badZone.Timezone = "Mars/Olympus_Mons"
+	assert.Error(t, badZone.Validate(), "Validate should reject an unknown timezone")
+
+	badNtp := valid
+	badNtp.NtpServers = []utils.Nstring{"ntp..example.com"}
+	assert.Error(t, badNtp.Validate(), "Validate should reject a malformed ntp server")
+}
+
+func TestSetApplianceTimeAndLocale(t *testing.T) {
+	var submitted ov.TimeLocale
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /rest/appliance/configuration/time-locale":
+			w.Write([]byte(`{"locale": "en_US.UTF-8", "timezone": "UTC", "ntpServers": ["ntp.example.com"]}`))
+		case "POST /rest/appliance/configuration/time-locale":
+			json.NewDecoder(r.Body).Decode(&submitted)
+			w.Write([]byte(`{"uri": "/rest/tasks/1", "taskState": "Completed", "percentComplete": 100}`))
+		case "GET /rest/tasks/1":
+			w.Write([]byte(`{"uri": "/rest/tasks/1", "taskState": "Completed", "percentComplete": 100}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer ts.Close()
+
+	cfg, err := c.GetApplianceTimeAndLocale()
+	assert.NoError(t, err)
+	assert.Equal(t, "en_US.UTF-8", cfg.Locale)
+	assert.Equal(t, []utils.Nstring{"ntp.example.com"}, cfg.NtpServers)
+
+	assert.Error(t, c.SetApplianceTimeAndLocale(ov.TimeLocale{Locale: "en_US.UTF-8", Timezone: "UTC"}))
+	assert.Error(t, c.SetApplianceTimeAndLocale(ov.TimeLocale{NtpServers: []utils.Nstring{"ntp..example.com"}}))
+
+	cfg.Timezone = "Europe/Paris"
+	cfg.NtpServers = append(cfg.NtpServers, "10.0.0.1")
+	assert.NoError(t, c.SetApplianceTimeAndLocale(cfg))
+	assert.Equal(t, utils.Nstring("Europe/Paris"), submitted.Timezone)
+	assert.Len(t, submitted.NtpServers, 2)
+}
@@ -0,0 +1,80 @@
+package ov
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackups(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/backups":
+			if r.Method == http.MethodPost {
+				w.Header().Set("Location", "/rest/tasks/1")
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			w.Write([]byte(`{"total":1,"count":1,"members":[{"id":"b1","status":"SUCCEEDED","uri":"/rest/backups/b1"}]}`))
+		case "/rest/backups/b1":
+			w.Write([]byte(`{"id":"b1","downloadUri":"/rest/backups/archive/b1","uri":"/rest/backups/b1"}`))
+		case "/rest/backups/archive/b1":
+			w.Write([]byte("backup contents"))
+		}
+	})
+	defer ts.Close()
+
+	task, err := c.CreateBackup()
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/tasks/1", task.URI.String())
+
+	backups, err := c.GetBackups()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, backups.Total)
+	assert.Equal(t, "SUCCEEDED", backups.Members[0].Status)
+
+	var buf bytes.Buffer
+	assert.NoError(t, c.DownloadBackup(backups.Members[0].URI, &buf))
+	assert.Equal(t, "backup contents", buf.String())
+
+	assert.Error(t, c.DownloadBackup(utils.NewNstring(""), &buf))
+}
+
+func TestRestoreBackup(t *testing.T) {
+	var restore ov.Restore
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/backups/archive":
+			file, _, err := r.FormFile("file")
+			assert.NoError(t, err)
+			data, _ := ioutil.ReadAll(file)
+			assert.Equal(t, "backup contents", string(data))
+			w.Write([]byte(`{"taskState":"Running","uri":"/rest/tasks/1"}`))
+		case "/rest/tasks/1":
+			w.Write([]byte(`{"taskState":"Completed","associatedResource":{"resourceUri":"/rest/backups/b1"},"uri":"/rest/tasks/1"}`))
+		case "/rest/restores":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&restore))
+			w.Write([]byte(`{"type":"RESTORE","status":"IN_PROGRESS","uri":"/rest/restores/1"}`))
+		case "/rest/restores/1":
+			w.Write([]byte(`{"status":"IN_PROGRESS","progressStep":"RESTORING_DATABASE","uri":"/rest/restores/1"}`))
+		}
+	})
+	defer ts.Close()
+
+	task, err := c.RestoreBackup(strings.NewReader("backup contents"))
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/backups/b1", restore.UriOfBackupToRestore.String())
+	assert.Equal(t, "/rest/restores/1", task.URI.String())
+	assert.True(t, task.TaskIsDone)
+
+	status, err := c.GetRestore(task.URI)
+	assert.NoError(t, err)
+	assert.Equal(t, "RESTORING_DATABASE", status.ProgressStep)
+}
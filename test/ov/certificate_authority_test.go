@@ -0,0 +1,70 @@
+package ov
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/stretchr/testify/assert"
+)
+
+func testCACertificatePEM(t *testing.T, commonName string) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestTrustedCertificates(t *testing.T) {
+	var added ov.CertStore
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/certificates/ca" && r.Method == http.MethodPost:
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&added))
+			w.Write([]byte(`{"taskState":"Running","uri":"/rest/tasks/1"}`))
+		case r.URL.Path == "/rest/certificates/ca":
+			w.Write([]byte(`{"total":1,"count":1,"members":[{"certificateDetails":{"aliasName":"Root CA"},"uri":"/rest/certificates/ca/Root CA"}]}`))
+		case r.URL.Path == "/rest/certificates/ca/Root CA":
+			assert.Equal(t, http.MethodDelete, r.Method)
+			w.Write([]byte(`{"taskState":"Running","uri":"/rest/tasks/2"}`))
+		}
+	})
+	defer ts.Close()
+
+	bundle := testCACertificatePEM(t, "Root CA") + testCACertificatePEM(t, "Issuing CA")
+	task, err := c.AddTrustedCertificate(bundle)
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/tasks/1", task.URI.String())
+	assert.Len(t, added.Members, 2)
+	assert.Equal(t, "Root CA", added.Members[0].CertificateDetails.AliasName)
+	assert.Equal(t, "Issuing CA", added.Members[1].CertificateDetails.AliasName)
+
+	store, err := c.GetTrustedCertificates()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, store.Total)
+
+	task, err = c.RemoveTrustedCertificate(store.Members[0].CertificateDetails.AliasName)
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/tasks/2", task.URI.String())
+
+	_, err = c.AddTrustedCertificate("not a certificate")
+	assert.Error(t, err)
+}
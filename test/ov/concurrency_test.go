@@ -0,0 +1,110 @@
+package ov
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/stretchr/testify/assert"
+)
+
+// run with -race to check the client is safe to share between goroutines
+func TestConcurrentGetProfiles(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("auth") != "session" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Query().Get("filter"), "name='"), "'")
+		fmt.Fprintf(w, `{"total": 1, "count": 1, "members": [{"name": %q}]}`, name)
+	})
+	defer ts.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("profile-%d", i)
+			profiles, err := c.GetProfiles("", "", fmt.Sprintf("name='%s'", name), "", "")
+			if assert.NoError(t, err) && assert.Len(t, profiles.Members, 1) {
+				assert.Equal(t, name, profiles.Members[0].Name)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestConcurrentRefreshLogin(t *testing.T) {
+	var logins int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/login-sessions":
+			atomic.AddInt32(&logins, 1)
+			w.Write([]byte(`{"sessionID": "renewed"}`))
+		case "/rest/sessions/idle-timeout":
+			if r.Header.Get("Session-ID") != "renewed" {
+				http.NotFound(w, r)
+				return
+			}
+			w.Write([]byte(`{"idleTimeout": 1800000}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+	c := &ov.OVClient{Client: rest.Client{User: "foo", Password: "bar", Endpoint: ts.URL, APIVersion: 2400, APIKey: "expired"}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, c.RefreshLogin())
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&logins))
+	assert.Equal(t, "renewed", c.GetAuthHeaderMap()["auth"])
+}
+
+func TestConcurrentClientsLoginIndependently(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/login-sessions":
+			if r.Header.Get("X-API-Version") == "2400" {
+				close(started)
+				<-release
+			}
+			w.Write([]byte(`{"sessionID": "renewed"}`))
+		case "/rest/sessions/idle-timeout":
+			w.Write([]byte(`{"idleTimeout": 1800000}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+	defer close(release)
+	c := &ov.OVClient{Client: rest.Client{User: "foo", Password: "bar", Endpoint: ts.URL, APIVersion: 2400, APIKey: "none"}}
+	v := c.ForAPIVersion(2200)
+
+	// the login of the parent client is held by the server, the copy logs in regardless
+	go c.Login()
+	<-started
+	done := make(chan error, 1)
+	go func() { done <- v.Login() }()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the login of a client waited on the login of another client")
+	}
+}
@@ -0,0 +1,57 @@
+package ov
+
+import (
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectionBuilder(t *testing.T) {
+	conn, err := ov.NewConnectionBuilder(1, "mgmt").
+		WithNetwork("/rest/ethernet-networks/1").
+		WithBootPriority(ov.BOOT_PRIORITY_PRIMARY).
+		WithRequestedBandwidth(2500).
+		Build()
+	assert.NoError(t, err)
+	assert.Equal(t, ov.FUNCTION_TYPE_ETHERNET, conn.FunctionType, "function type defaults to the network kind")
+	assert.Equal(t, "2500", conn.RequestedMbps)
+	assert.Equal(t, "Primary", conn.Boot.Priority)
+
+	conn, err = ov.NewConnectionBuilder(2, "san-a").
+		WithNetwork("/rest/fc-networks/1").
+		WithFunctionType(ov.FUNCTION_TYPE_FIBRE_CHANNEL).
+		Build()
+	assert.NoError(t, err)
+	assert.Nil(t, conn.Boot)
+
+	_, err = ov.NewConnectionBuilder(3, "wrong").
+		WithNetwork("/rest/fc-networks/1").
+		WithFunctionType(ov.FUNCTION_TYPE_ETHERNET).
+		Build()
+	assert.Error(t, err, "function type must match the network kind")
+
+	_, err = ov.NewConnectionBuilder(4, "set").
+		WithNetwork("/rest/network-sets/1").
+		WithBootPriority(ov.BOOT_PRIORITY_SECONDARY).
+		Build()
+	assert.Error(t, err, "network sets are not bootable")
+
+	_, err = ov.NewConnectionBuilder(5, "unused").
+		WithNetwork("/rest/ethernet-networks/1").
+		WithPortID("None").
+		WithBootPriority(ov.BOOT_PRIORITY_PRIMARY).
+		Build()
+	assert.Error(t, err, "a connection without a port is not bootable")
+
+	_, err = ov.NewConnectionBuilder(6, "bad").
+		WithFunctionType("Infiniband").
+		WithBootPriority("First").
+		WithRequestedBandwidth(0).
+		Build()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Infiniband")
+		assert.Contains(t, err.Error(), "First")
+		assert.Contains(t, err.Error(), "no network provided")
+	}
+}
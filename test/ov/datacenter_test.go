@@ -0,0 +1,88 @@
+package ov
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatacenters(t *testing.T) {
+	var requests []string
+	var created ov.Datacenter
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "name matches 'DC1'", r.URL.Query().Get("filter"))
+			w.Write([]byte(`{"total": 1, "count": 1, "members": [{"name": "DC1", "uri": "/rest/datacenters/dc1",
+				"contents": [{"resourceUri": "/rest/racks/Rack-221", "x": 1000, "y": 2000, "rotation": 90}]}]}`))
+		case http.MethodPost:
+			json.NewDecoder(r.Body).Decode(&created)
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	})
+	defer ts.Close()
+
+	datacenter, err := c.GetDatacenterByName("DC1")
+	assert.NoError(t, err)
+	if assert.Len(t, datacenter.Contents, 1) {
+		assert.Equal(t, utils.NewNstring("/rest/racks/Rack-221"), datacenter.Contents[0].ResourceUri)
+		assert.Equal(t, 90, datacenter.Contents[0].Rotation)
+	}
+
+	assert.Error(t, c.CreateDatacenter(ov.Datacenter{}))
+	assert.NoError(t, c.CreateDatacenter(ov.Datacenter{Name: "DC2", Width: 5000, Depth: 5000}))
+	assert.Equal(t, "DC2", created.Name)
+	assert.NotNil(t, created.Contents)
+
+	assert.Error(t, c.UpdateDatacenter(ov.Datacenter{Name: "DC1"}))
+	assert.NoError(t, c.UpdateDatacenter(datacenter))
+	assert.NoError(t, c.DeleteDatacenter("DC1"))
+	assert.Equal(t, []string{
+		"GET /rest/datacenters",
+		"POST /rest/datacenters",
+		"PUT /rest/datacenters/dc1",
+		"GET /rest/datacenters",
+		"DELETE /rest/datacenters/dc1",
+	}, requests)
+}
+
+func TestRacks(t *testing.T) {
+	var requests []string
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"total": 1, "count": 1, "members": [{"name": "Rack-221", "uri": "/rest/racks/Rack-221", "uHeight": 42,
+				"rackMounts": [{"mountUri": "/rest/enclosures/0000000000A66101", "topUSlot": 20, "uHeight": 10, "location": "CenterFront"}]}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	defer ts.Close()
+
+	rack, err := c.GetRackByName("Rack-221")
+	assert.NoError(t, err)
+	mount, ok := rack.GetRackMount(utils.NewNstring("/rest/enclosures/0000000000A66101"))
+	assert.True(t, ok)
+	assert.Equal(t, 20, mount.TopUSlot)
+	assert.Equal(t, 10, mount.UHeight)
+	_, ok = rack.GetRackMount(utils.NewNstring("/rest/enclosures/other"))
+	assert.False(t, ok)
+
+	assert.NoError(t, c.CreateRack(ov.Rack{Name: "Rack-222"}))
+	assert.NoError(t, c.UpdateRack(rack))
+	assert.NoError(t, c.DeleteRack("Rack-221"))
+	assert.Equal(t, []string{
+		"GET /rest/racks",
+		"POST /rest/racks",
+		"PUT /rest/racks/Rack-221",
+		"GET /rest/racks",
+		"DELETE /rest/racks/Rack-221",
+	}, requests)
+}
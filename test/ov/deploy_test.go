@@ -0,0 +1,55 @@
+package ov
+
+import (
+	"os"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAssignedIdentifiers(t *testing.T) {
+	p := ov.ServerProfile{
+		SerialNumber: utils.NewNstring("VCGA1B2C3D"),
+		UUID:         utils.NewNstring("d1f5b2d0-0000-4000-8000-000000000001"),
+	}
+	p.ConnectionSettings.Connections = []ov.Connection{
+		{ID: 1, Name: "mgmt", MAC: utils.NewNstring("16:2C:5A:00:00:01")},
+		{ID: 2, WWPN: utils.NewNstring("10:00:16:2C:5A:00:00:02")},
+	}
+	ids := p.GetAssignedIdentifiers()
+	assert.Equal(t, p.SerialNumber, ids.SerialNumber)
+	assert.Equal(t, p.UUID, ids.UUID)
+	assert.Equal(t, utils.NewNstring("16:2C:5A:00:00:01"), ids.MACs["mgmt"])
+	assert.Equal(t, utils.NewNstring("10:00:16:2C:5A:00:00:02"), ids.WWPNs["2"])
+	assert.Equal(t, 1, len(ids.MACs))
+}
+
+func TestDeployServer(t *testing.T) {
+	var (
+		d *OVTest
+		c *ov.OVClient
+	)
+	if os.Getenv("ONEVIEW_TEST_ACCEPTANCE") == "true" {
+		d, c = getTestDriverA("dev")
+		if c == nil {
+			t.Fatalf("Failed to execute getTestDriver() ")
+		}
+		req := ov.DeployRequest{
+			TemplateName: d.Tc.GetTestData(d.Env, "TemplateProfile").(string),
+			ProfileName:  "deploy-test",
+		}
+		result, err := c.DeployServer(req)
+		assert.NoError(t, err, "DeployServer threw error -> %s", err)
+		assert.Equal(t, "deploy-test", result.Profile.Name)
+		assert.NoError(t, c.DeleteProfile("deploy-test"))
+	} else {
+		_, c = getTestDriverU("dev")
+		_, err := c.DeployServer(ov.DeployRequest{TemplateName: "template"})
+		assert.Error(t, err, "DeployServer should refuse a request without a profile name")
+
+		_, err = c.DeployServer(ov.DeployRequest{TemplateName: "template", ProfileName: "deploy-test"})
+		assert.Error(t, err, "DeployServer should fail without a session")
+	}
+}
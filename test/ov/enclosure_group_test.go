@@ -0,0 +1,28 @@
+package ov
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAllEnclosureGroups(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/enclosure-groups", r.URL.Path)
+		assert.Equal(t, "name:asc", r.URL.Query().Get("sort"))
+		if r.URL.Query().Get("start") == "" {
+			w.Write([]byte(`{"total":2,"count":1,"nextPageUri":"/rest/enclosure-groups?start=1&count=1","members":[
+				{"name":"eg1","interconnectBayMappings":[{"interconnectBay":1,"logicalInterconnectGroupUri":"/rest/logical-interconnect-groups/1"}]}]}`))
+			return
+		}
+		w.Write([]byte(`{"total":2,"count":1,"start":1,"members":[{"name":"eg2","interconnectBayMappings":[]}]}`))
+	})
+	defer ts.Close()
+
+	groups, err := c.GetAllEnclosureGroups("", "name:asc")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, groups.Count)
+	assert.Equal(t, "eg2", groups.Members[1].Name)
+	assert.Equal(t, "/rest/logical-interconnect-groups/1", groups.Members[0].InterconnectBayMappings[0].LogicalInterconnectGroupUri.String())
+}
@@ -0,0 +1,39 @@
+package ov
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshEnclosure(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/enclosures/e1":
+			w.Write([]byte(`{"name":"e1","uuid":"09USE62519EE","deviceBayCount":16,"interconnectBayCount":8,"deviceBays":[{"bayNumber":1},{"bayNumber":2}],"uri":"/rest/enclosures/e1"}`))
+		case "/rest/enclosures/e1/refreshState":
+			assert.Equal(t, http.MethodPut, r.Method)
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"refreshState":"RefreshPending"}`, string(body))
+			w.Write([]byte(`{"taskState":"Running","uri":"/rest/tasks/1"}`))
+		}
+	})
+	defer ts.Close()
+
+	enclosure, err := c.GetEnclosureByUri("/rest/enclosures/e1")
+	assert.NoError(t, err)
+	assert.Equal(t, "09USE62519EE", enclosure.UUID)
+	assert.Equal(t, 16, enclosure.DeviceBayCount)
+	assert.Equal(t, 8, enclosure.InterconnectBayCount)
+	assert.Equal(t, 2, len(enclosure.DeviceBays))
+
+	task, err := c.RefreshEnclosure(enclosure)
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/tasks/1", task.URI.String())
+
+	_, err = c.RefreshEnclosure(ov.Enclosure{})
+	assert.Error(t, err)
+}
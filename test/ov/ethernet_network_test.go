@@ -187,3 +187,17 @@ func TestDeleteBulkEthernetNetwork(t *testing.T) {
 	}
 
 }
+
+func TestValidateVlanIdRange(t *testing.T) {
+	assert.NoError(t, ov.ValidateVlanIdRange("1-500,600,700-710"))
+	assert.NoError(t, ov.ValidateVlanIdRange("4094"))
+	assert.Error(t, ov.ValidateVlanIdRange(""))
+	assert.Error(t, ov.ValidateVlanIdRange("0-10"))
+	assert.Error(t, ov.ValidateVlanIdRange("100-4095"))
+	assert.Error(t, ov.ValidateVlanIdRange("500-100"))
+	assert.Error(t, ov.ValidateVlanIdRange("1-500,,600"))
+
+	_, c := getTestDriverU("dev")
+	err := c.CreateBulkEthernetNetwork(ov.BulkEthernetNetwork{VlanIdRange: "10-abc", NamePrefix: "net"})
+	assert.Error(t, err)
+}
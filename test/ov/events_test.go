@@ -0,0 +1,52 @@
+package ov
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvents(t *testing.T) {
+	var posted ov.Event
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/events", r.URL.Path)
+		if r.Method == http.MethodPost {
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&posted))
+			w.Write([]byte(`{"uri":"/rest/events/2"}`))
+			return
+		}
+		w.Write([]byte(`{"total":1,"count":1,"members":[{"description":"Power supply degraded","severity":"Warning","uri":"/rest/events/1"}]}`))
+	})
+	defer ts.Close()
+
+	events, err := c.GetEvents("severity='Warning'", "created:descending")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, events.Total)
+	assert.Equal(t, "Power supply degraded", events.Members[0].Description)
+
+	assert.NoError(t, c.CreateEvent(ov.Event{Description: "Maintenance window started", Severity: "OK"}))
+	assert.Equal(t, "EventResourceV3", posted.Type)
+
+	assert.Error(t, c.CreateEvent(ov.Event{}))
+}
+
+func TestGetScmbConnectionInfo(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/certificates/client/rabbitmq/keypair/default", r.URL.Path)
+		w.Write([]byte(`{"base64SSLCertData":"cert","base64SSLKeyData":"key"}`))
+	})
+	defer ts.Close()
+
+	info, err := c.GetScmbConnectionInfo()
+	assert.NoError(t, err)
+	endpoint, _ := url.Parse(ts.URL)
+	assert.Equal(t, endpoint.Hostname(), info.Host)
+	assert.Equal(t, ov.SCMB_PORT, info.Port)
+	assert.Equal(t, "scmb", info.Exchange)
+	assert.Equal(t, "cert", info.Certificate.Base64SSLCertData)
+	assert.Equal(t, "key", info.Certificate.Base64SSLKeyData)
+}
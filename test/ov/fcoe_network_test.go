@@ -1,6 +1,7 @@
 package ov
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/HewlettPackard/oneview-golang/ov"
 	"github.com/HewlettPackard/oneview-golang/utils"
@@ -182,3 +183,12 @@ func TestDeleteBulkFCoENetwork(t *testing.T) {
 	}
 
 }
+
+func TestCreateFCoENetworkInvalidVlan(t *testing.T) {
+	_, c := getTestDriverU("dev")
+	err := c.CreateFCoENetwork(ov.FCoENetwork{Name: "fcoe-no-vlan", Type: "fcoe-networkV4"})
+	assert.Error(t, err, "CreateFCoENetwork should fail without a vlan id")
+
+	data, _ := json.Marshal(ov.FCoENetworkBulkDelete{FCoENetworkUris: []utils.Nstring{utils.NewNstring("/rest/fcoe-networks/1")}})
+	assert.JSONEq(t, `{"networkUris":["/rest/fcoe-networks/1"]}`, string(data))
+}
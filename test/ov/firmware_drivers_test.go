@@ -0,0 +1,70 @@
+package ov
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetFirmwareBaseline(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/firmware-drivers" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"total": 3, "count": 3, "members": [
+			{"name": "Service Pack for ProLiant", "baselineShortName": "SPP 2023.09", "version": "2023.09.0", "uri": "/rest/firmware-drivers/spp-2023-09"},
+			{"name": "Service Pack for ProLiant", "baselineShortName": "SPP 2024.04", "version": "2024.04.0", "uri": "/rest/firmware-drivers/spp-2024-04"},
+			{"name": "custom", "version": "1", "uri": "/rest/firmware-drivers/custom"}]}`))
+	})
+	defer ts.Close()
+
+	p := ov.ServerProfile{Name: "web01"}
+	err := c.SetFirmwareBaseline(&p, "SPP 2023.09")
+	assert.NoError(t, err)
+	assert.True(t, p.Firmware.ManageFirmware)
+	assert.Equal(t, utils.NewNstring("/rest/firmware-drivers/spp-2023-09"), p.Firmware.FirmwareBaselineUri)
+	assert.Equal(t, "FirmwareAndOSDrivers", p.Firmware.FirmwareInstallType)
+
+	_, err = c.GetFirmwareBaselineByName("Service Pack for ProLiant")
+	if assert.Error(t, err, "more than one baseline matches") {
+		assert.Contains(t, err.Error(), "matches 2 baselines")
+	}
+
+	_, err = c.GetFirmwareBaselineByName("SPP 2019.03")
+	assert.Error(t, err, "no baseline matches")
+}
+
+func TestGetFirmwareDrivers(t *testing.T) {
+	var filter string
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/firmware-drivers" {
+			http.NotFound(w, r)
+			return
+		}
+		filter = r.URL.Query().Get("filter")
+		if r.URL.Query().Get("start") == "1" {
+			w.Write([]byte(`{"total": 2, "count": 1, "start": 1, "members": [{"name": "SPP", "version": "2024.04.0", "bundleSize": 9000}]}`))
+			return
+		}
+		w.Write([]byte(`{"total": 2, "count": 1, "nextPageUri": "/rest/firmware-drivers?start=1&count=1", "members": [
+			{"name": "SPP", "version": "2023.09.0", "releaseDate": "2023-09-01", "supportedLanguages": "en", "bundleSize": 8000}]}`))
+	})
+	defer ts.Close()
+
+	drivers, err := c.GetFirmwareDrivers("name='SPP'", "version:asc")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, drivers.Total)
+	assert.Equal(t, "name='SPP'", filter, "every page keeps the filter")
+	assert.Equal(t, "2023-09-01", drivers.Members[0].ReleaseDate)
+	assert.Equal(t, "en", drivers.Members[0].SupportedLanguages)
+	assert.Equal(t, 9000, drivers.Members[1].BundleSize)
+
+	driver, err := c.GetFirmwareDriverByName("SPP")
+	assert.NoError(t, err)
+	assert.Equal(t, "2023.09.0", driver.Version)
+	assert.Equal(t, "name matches 'SPP'", filter)
+}
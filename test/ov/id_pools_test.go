@@ -0,0 +1,45 @@
+package ov
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllocateCollectIDs(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		switch r.URL.Path {
+		case "/rest/id-pools/vmac":
+			w.Write([]byte(`{"poolType":"VMAC","allocatedCount":2,"freeCount":10,"totalCount":12,"uri":"/rest/id-pools/vmac"}`))
+		case "/rest/id-pools/vmac/allocator":
+			assert.JSONEq(t, `{"count":2}`, string(body))
+			w.Write([]byte(`{"count":2,"idList":["A2:00:00:00:00:01","A2:00:00:00:00:02"]}`))
+		case "/rest/id-pools/vmac/collector":
+			var collect map[string][]string
+			json.Unmarshal(body, &collect)
+			assert.Equal(t, []string{"A2:00:00:00:00:01"}, collect["idList"])
+			w.Write(body)
+		}
+	})
+	defer ts.Close()
+
+	pool, err := c.GetIDPool(ov.ID_POOL_VMAC)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, pool.FreeCount)
+
+	ids, err := c.AllocateIDs(ov.ID_POOL_VMAC, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A2:00:00:00:00:01", "A2:00:00:00:00:02"}, ids)
+	assert.NoError(t, c.CollectIDs(ov.ID_POOL_VMAC, ids[:1]))
+
+	_, err = c.AllocateIDs("ipv6", 2)
+	assert.Error(t, err)
+	_, err = c.AllocateIDs(ov.ID_POOL_VWWN, 0)
+	assert.Error(t, err)
+	assert.Error(t, c.CollectIDs(ov.ID_POOL_VSN, nil))
+}
@@ -0,0 +1,63 @@
+package ov
+
+import (
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareInterconnectFirmware(t *testing.T) {
+	interconnect := ov.Interconnect{
+		Name:            "frame1, interconnect 3",
+		URI:             utils.NewNstring("/rest/interconnects/ic3"),
+		FirmwareVersion: "1.15",
+	}
+	firmware := ov.Firmware{
+		SppName: "SPP 2021",
+		SppUri:  utils.NewNstring("/rest/firmware-drivers/spp2021"),
+		Interconnects: []ov.FirmwareInterconnect{
+			{InterconnectUri: "/rest/interconnects/ic3", DeviceType: "VC SE 40Gb F8", InstalledFw: "1.15", DesiredFw: "1.16"},
+			{InterconnectUri: "/rest/interconnects/ic6", DeviceType: "VC SE 40Gb F8", InstalledFw: "1.16", DesiredFw: "1.16"},
+		},
+	}
+
+	status := ov.CompareInterconnectFirmware(interconnect, firmware)
+	assert.False(t, status.Compliant)
+	assert.Equal(t, "SPP 2021", status.SppName)
+	if assert.Equal(t, 1, len(status.Components)) {
+		assert.Equal(t, "1.16", status.Components[0].DesiredFw)
+		assert.False(t, status.Components[0].Compliant)
+	}
+
+	firmware.Interconnects[0].InstalledFw = "1.16"
+	assert.True(t, ov.CompareInterconnectFirmware(interconnect, firmware).Compliant)
+}
+
+func TestCompareInterconnectFirmwareNothingCompared(t *testing.T) {
+	interconnect := ov.Interconnect{
+		Name: "frame1, interconnect 3",
+		URI:  utils.NewNstring("/rest/interconnects/ic3"),
+	}
+	firmware := ov.Firmware{
+		SppName: "SPP 2021",
+		SppUri:  utils.NewNstring("/rest/firmware-drivers/spp2021"),
+		Interconnects: []ov.FirmwareInterconnect{
+			{InterconnectUri: "/rest/interconnects/ic6", DeviceType: "VC SE 40Gb F8", InstalledFw: "1.16", DesiredFw: "1.16"},
+		},
+	}
+
+	// no component of the interconnect in the baseline
+	status := ov.CompareInterconnectFirmware(interconnect, firmware)
+	assert.False(t, status.Compliant)
+	assert.Empty(t, status.Components)
+
+	// components match but the logical interconnect has no baseline
+	firmware.Interconnects[0].InterconnectUri = "/rest/interconnects/ic3"
+	assert.True(t, ov.CompareInterconnectFirmware(interconnect, firmware).Compliant)
+	firmware.SppUri = utils.NewNstring("")
+	status = ov.CompareInterconnectFirmware(interconnect, firmware)
+	assert.False(t, status.Compliant)
+	assert.Equal(t, 1, len(status.Components))
+}
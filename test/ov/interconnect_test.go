@@ -0,0 +1,59 @@
+package ov
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterconnectPortStatistics(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/interconnects/1/statistics/Q1":
+			w.Write([]byte(`{"portName":"Q1","portStatus":"Linked","commonStatistics":{"rfc1213IfInOctets":"1024","rfc1213IfOutErrors":"3","rfc1213IfInDiscards":"7"}}`))
+		case "/rest/interconnects/1/ports":
+			assert.Equal(t, http.MethodPut, r.Method)
+			var port ov.Port
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&port))
+			assert.Equal(t, "Q1", port.PortName)
+			w.Write([]byte(`{"taskState":"Running","uri":"/rest/tasks/1"}`))
+		}
+	})
+	defer ts.Close()
+
+	uri := utils.NewNstring("/rest/interconnects/1")
+	stats, err := c.GetInterconnectStatistics(uri, "Q1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Linked", stats.PortStatus)
+	assert.Equal(t, "1024", stats.CommonStatistics.RFC1213IfInOctets)
+	assert.Equal(t, "3", stats.CommonStatistics.RFC1213IfOutErrors)
+	assert.Equal(t, "7", stats.CommonStatistics.RFC1213IfInDiscards)
+
+	_, err = c.GetInterconnectStatistics(uri, "")
+	assert.Error(t, err)
+
+	task, err := c.UpdateInterconnectPort(uri, ov.Port{PortName: "Q1", Enabled: false})
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/tasks/1", task.URI.String())
+
+	_, err = c.UpdateInterconnectPort(utils.NewNstring(""), ov.Port{})
+	assert.Error(t, err)
+}
+
+func TestInterconnectPortStatisticsEscapedPortName(t *testing.T) {
+	var path string
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath()
+		w.Write([]byte(`{"portName":"1/1","portStatus":"Linked"}`))
+	})
+	defer ts.Close()
+
+	stats, err := c.GetInterconnectStatistics(utils.NewNstring("/rest/interconnects/1"), "1/1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1/1", stats.PortName)
+	assert.Equal(t, "/rest/interconnects/1/statistics/1%2F1", path, "the port name is escaped once")
+}
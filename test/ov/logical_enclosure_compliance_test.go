@@ -0,0 +1,33 @@
+package ov
+
+import (
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareLogicalEnclosureToGroup(t *testing.T) {
+	logEn := ov.LogicalEnclosure{
+		AmbientTemperatureMode: "Standard",
+		PowerMode:              "RedundantPowerSupply",
+		IpAddressingMode:       "DHCP",
+		EnclosureUris:          []utils.Nstring{utils.NewNstring("/rest/enclosures/1")},
+	}
+	group := ov.EnclosureGroup{
+		AmbientTemperatureMode: "Standard",
+		PowerMode:              "RedundantPowerFeed",
+		EnclosureCount:         3,
+	}
+
+	differences := ov.CompareLogicalEnclosureToGroup(logEn, group)
+	assert.Equal(t, []ov.SettingDifference{
+		{Setting: "powerMode", Current: "RedundantPowerSupply", Group: "RedundantPowerFeed"},
+		{Setting: "enclosureCount", Current: "1", Group: "3"},
+	}, differences)
+
+	preview := ov.LogicalEnclosureCompliancePreview{Differences: differences}
+	assert.False(t, preview.IsCompliant())
+	assert.True(t, ov.LogicalEnclosureCompliancePreview{}.IsCompliant())
+}
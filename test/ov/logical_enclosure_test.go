@@ -0,0 +1,48 @@
+package ov
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogicalEnclosureTasks(t *testing.T) {
+	var (
+		requests []string
+		patch    []ov.PatchFirmware
+	)
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodPatch {
+			json.NewDecoder(r.Body).Decode(&patch)
+		}
+		w.Write([]byte(`{"uri": "/rest/tasks/1", "taskState": "Running"}`))
+	})
+	defer ts.Close()
+
+	logEn := ov.LogicalEnclosure{Name: "LE1", URI: utils.NewNstring("/rest/logical-enclosures/1")}
+
+	_, err := c.UpdateLogicalEnclosureFromGroup(ov.LogicalEnclosure{Name: "LE1"})
+	assert.Error(t, err)
+	task, err := c.UpdateLogicalEnclosureFromGroup(logEn)
+	assert.NoError(t, err)
+	assert.Equal(t, utils.NewNstring("/rest/tasks/1"), task.URI)
+
+	firmware := ov.LogicalEnclosureFirmware{FirmwareBaselineUri: utils.NewNstring("/rest/firmware-drivers/spp"), FirmwareUpdateOn: "EnclosureOnly"}
+	task, err = c.ApplyLogicalEnclosureFirmware(logEn, firmware)
+	assert.NoError(t, err)
+	assert.Equal(t, utils.NewNstring("/rest/tasks/1"), task.URI)
+	if assert.Len(t, patch, 1) {
+		assert.Equal(t, "/firmware", patch[0].Path)
+		assert.Equal(t, firmware.FirmwareBaselineUri, patch[0].Value.FirmwareBaselineUri)
+	}
+
+	assert.Equal(t, []string{
+		"PUT /rest/logical-enclosures/1/updateFromGroup",
+		"PATCH /rest/logical-enclosures/1",
+	}, requests)
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/HewlettPackard/oneview-golang/ov"
 	"github.com/docker/machine/libmachine/log"
 	"github.com/stretchr/testify/assert"
+	"net/http"
 	"os"
 	"testing"
 )
@@ -308,3 +309,25 @@ func TestDeleteLogicalInterconnectGroup(t *testing.T) {
 	}
 
 }
+
+func TestGetAllLogicalInterconnectGroups(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/logical-interconnect-groups", r.URL.Path)
+		if r.URL.Query().Get("start") == "" {
+			w.Write([]byte(`{"total":2,"count":1,"nextPageUri":"/rest/logical-interconnect-groups?start=1&count=1","members":[
+				{"name":"lig1","internalNetworkUris":["/rest/ethernet-networks/1"],"uplinkSets":[{"name":"us1"}],
+				"interconnectMapTemplate":{"interconnectMapEntryTemplates":[{"permittedInterconnectTypeUri":"/rest/interconnect-types/1"}]}}]}`))
+			return
+		}
+		w.Write([]byte(`{"total":2,"count":1,"start":1,"members":[{"name":"lig2"}]}`))
+	})
+	defer ts.Close()
+
+	ligs, err := c.GetAllLogicalInterconnectGroups("", "name:asc")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, ligs.Count)
+	assert.Equal(t, "lig2", ligs.Members[1].Name)
+	assert.Equal(t, 1, len(ligs.Members[0].InternalNetworkUris))
+	assert.Equal(t, "us1", ligs.Members[0].UplinkSets[0].Name)
+	assert.Equal(t, 1, len(ligs.Members[0].InterconnectMapTemplate.InterconnectMapEntryTemplates))
+}
@@ -0,0 +1,148 @@
+package ov
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateLogicalInterconnectQosConfigurationValidation(t *testing.T) {
+	var c *ov.OVClient
+	if os.Getenv("ONEVIEW_TEST_ACCEPTANCE") == "true" {
+		_, c = getTestDriverA("dev")
+	} else {
+		_, c = getTestDriverU("dev")
+	}
+	if c == nil {
+		t.Fatalf("Failed to execute getTestDriver() ")
+	}
+	uri := utils.NewNstring("/rest/logical-interconnects/fake")
+
+	priority := 9
+	cfg := ov.QosConfiguration{ActiveQosConfig: &ov.ActiveQosConfig{
+		QosTrafficClassifiers: []ov.QosTrafficClassifier{
+			{QosTrafficClass: &ov.QosTrafficClass{ClassName: "Best effort", MaxBandwidth: 100, EgressDot1pValue: &priority}},
+		},
+	}}
+	_, err := c.UpdateLogicalInterconnectQosConfiguration(uri, cfg)
+	assert.Error(t, err, "egress 802.1p value above 7 should be refused")
+
+	cfg.ActiveQosConfig.QosTrafficClassifiers = []ov.QosTrafficClassifier{
+		{QosTrafficClass: &ov.QosTrafficClass{ClassName: "Medium", MaxBandwidth: 120}},
+	}
+	_, err = c.UpdateLogicalInterconnectQosConfiguration(uri, cfg)
+	assert.Error(t, err, "max bandwidth above 100 should be refused")
+
+	cfg.ActiveQosConfig.QosTrafficClassifiers = []ov.QosTrafficClassifier{
+		{
+			QosTrafficClass:          &ov.QosTrafficClass{ClassName: "Medium", MaxBandwidth: 100},
+			QosClassificationMapping: &ov.QosClassificationMapping{Dot1pClassMapping: []int{1, 8}},
+		},
+	}
+	_, err = c.UpdateLogicalInterconnectQosConfiguration(uri, cfg)
+	assert.Error(t, err, "802.1p mapping above 7 should be refused")
+}
+
+func TestGetLogicalInterconnectQosConfiguration(t *testing.T) {
+	var (
+		d *OVTest
+		c *ov.OVClient
+	)
+	if os.Getenv("ONEVIEW_TEST_ACCEPTANCE") == "true" {
+		d, c = getTestDriverA("dev")
+		if c == nil {
+			t.Fatalf("Failed to execute getTestDriver() ")
+		}
+		uri := utils.NewNstring(d.Tc.GetTestData(d.Env, "LogicalInterconnectURI").(string))
+		qos, err := c.GetLogicalInterconnectQosConfiguration(uri)
+		assert.NoError(t, err, "GetLogicalInterconnectQosConfiguration threw error -> %s", err)
+		assert.NotNil(t, qos.ActiveQosConfig)
+	} else {
+		_, c = getTestDriverU("dev")
+		_, err := c.GetLogicalInterconnectQosConfiguration(utils.NewNstring("/rest/logical-interconnects/fake"))
+		assert.Error(t, err, "GetLogicalInterconnectQosConfiguration should fail without a session")
+	}
+}
+
+func TestResetPortProtectionForLogicalInterconnect(t *testing.T) {
+	var (
+		d *OVTest
+		c *ov.OVClient
+	)
+	if os.Getenv("ONEVIEW_TEST_ACCEPTANCE") == "true" {
+		d, c = getTestDriverA("dev")
+		if c == nil {
+			t.Fatalf("Failed to execute getTestDriver() ")
+		}
+		uri := d.Tc.GetTestData(d.Env, "LogicalInterconnectURI").(string)
+		tasks, err := c.ResetPortProtectionForLogicalInterconnect(uri)
+		assert.NoError(t, err, "ResetPortProtectionForLogicalInterconnect threw error -> %s", err)
+		for _, task := range tasks {
+			assert.NoError(t, task.Wait())
+		}
+	} else {
+		_, c = getTestDriverU("dev")
+		_, err := c.ResetPortProtectionForLogicalInterconnect("/rest/logical-interconnects/fake")
+		assert.Error(t, err, "ResetPortProtectionForLogicalInterconnect should fail without a session")
+	}
+}
+
+func TestResetPortProtectionForLogicalInterconnectTasks(t *testing.T) {
+	var resets []string
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /rest/logical-interconnects/1":
+			w.Write([]byte(`{"name": "li1", "uri": "/rest/logical-interconnects/1", "interconnects": ["/rest/interconnects/1", "/rest/interconnects/2"]}`))
+		case "PUT /rest/interconnects/1/resetportprotection", "PUT /rest/interconnects/2/resetportprotection":
+			resets = append(resets, r.URL.Path)
+			w.Write([]byte(`{"uri": "/rest/tasks/` + fmt.Sprint(len(resets)) + `", "taskState": "Running"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer ts.Close()
+
+	tasks, err := c.ResetPortProtectionForLogicalInterconnect("/rest/logical-interconnects/1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/rest/interconnects/1/resetportprotection", "/rest/interconnects/2/resetportprotection"}, resets)
+	if assert.Equal(t, 2, len(tasks)) {
+		assert.Equal(t, "/rest/tasks/1", tasks[0].URI.String())
+		assert.Equal(t, "/rest/tasks/2", tasks[1].URI.String())
+	}
+}
+
+func TestUpdateLogicalInterconnectFromGroup(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/logical-interconnects":
+			assert.Equal(t, "name='li1'", r.URL.Query().Get("filter"))
+			w.Write([]byte(`{"total":1,"count":1,"members":[{"name":"li1","consistencyStatus":"NOT_CONSISTENT","uri":"/rest/logical-interconnects/1"}]}`))
+		case r.URL.Path == "/rest/logical-interconnects/1":
+			w.Write([]byte(`{"name":"li1","consistencyStatus":"NOT_CONSISTENT","uri":"/rest/logical-interconnects/1"}`))
+		case r.URL.Path == "/rest/logical-interconnects/1/compliance":
+			assert.Equal(t, http.MethodPut, r.Method)
+			w.Write([]byte(`{"taskState":"Running","uri":"/rest/tasks/1"}`))
+		}
+	})
+	defer ts.Close()
+
+	li, err := c.GetLogicalInterconnectByName("li1")
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/logical-interconnects/1", li.URI.String())
+
+	status, err := c.GetLogicalInterconnectCompliance(li)
+	assert.NoError(t, err)
+	assert.Equal(t, "NOT_CONSISTENT", status)
+
+	task, err := c.UpdateLogicalInterconnectFromGroup(li)
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/tasks/1", task.URI.String())
+
+	_, err = c.UpdateLogicalInterconnectFromGroup(ov.LogicalInterconnect{})
+	assert.Error(t, err)
+}
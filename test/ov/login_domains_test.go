@@ -0,0 +1,75 @@
+package ov
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginDomains(t *testing.T) {
+	var (
+		created ov.AuthDirectory
+		mapping ov.GroupToRoleMapping
+	)
+	validated, deleted := false, false
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/logindomains/validator":
+			validated = true
+			w.WriteHeader(http.StatusOK)
+		case "/rest/logindomains":
+			if r.Method == http.MethodPost {
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+				w.Write([]byte(`{"name":"example.com","uri":"/rest/logindomains/1"}`))
+				return
+			}
+			w.Write([]byte(`{"total":1,"count":1,"members":[{"name":"example.com","baseDN":"dc=example,dc=com","uri":"/rest/logindomains/1"}]}`))
+		case "/rest/logindomains/1":
+			assert.Equal(t, http.MethodDelete, r.Method)
+			deleted = true
+		case "/rest/logindomains/grouptorolemapping":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&mapping))
+			w.Write([]byte(`{"taskState":"Running","uri":"/rest/tasks/1"}`))
+		}
+	})
+	defer ts.Close()
+
+	dir := ov.AuthDirectory{
+		Name:             "example.com",
+		AuthProtocol:     ov.AUTH_PROTOCOL_AD,
+		BaseDN:           "dc=example,dc=com",
+		DirectoryServers: []ov.DirectoryServer{{DirectoryServerIpAddress: "ad.example.com", DirectoryServerSSLPortNumber: "636"}},
+		Credential:       &ov.DirectoryCredential{UserName: "bind", Password: "secret"},
+	}
+	assert.NoError(t, c.ValidateDirectory(dir))
+	assert.True(t, validated)
+
+	task, err := c.CreateLoginDomain(dir)
+	assert.NoError(t, err)
+	assert.True(t, task.TaskIsDone)
+	assert.Equal(t, "LoginDomainConfigVersion600", created.Type)
+	assert.Equal(t, "ad.example.com", created.DirectoryServers[0].DirectoryServerIpAddress)
+
+	domains, err := c.GetLoginDomains()
+	assert.NoError(t, err)
+	assert.Equal(t, "dc=example,dc=com", domains.Members[0].BaseDN)
+
+	task, err = c.CreateGroupToRoleMapping(ov.GroupToRoleMapping{
+		LoginDomain: "example.com",
+		EGroup:      "OneView Admins",
+		Permissions: []ov.Permission{{RoleName: "Infrastructure administrator"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/tasks/1", task.URI.String())
+	assert.Equal(t, "OneView Admins", mapping.EGroup)
+
+	assert.NoError(t, c.DeleteLoginDomain("example.com"))
+	assert.True(t, deleted)
+
+	dir.AuthProtocol = "Kerberos"
+	_, err = c.CreateLoginDomain(dir)
+	assert.Error(t, err)
+}
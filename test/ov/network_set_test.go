@@ -6,6 +6,7 @@ import (
 	"github.com/HewlettPackard/oneview-golang/utils"
 	"github.com/docker/machine/libmachine/log"
 	"github.com/stretchr/testify/assert"
+	"net/http"
 	"os"
 	"testing"
 )
@@ -155,3 +156,61 @@ func TestDeleteNetworkSet(t *testing.T) {
 	}
 
 }
+
+func TestSetNetworkSetBandwidthInvalid(t *testing.T) {
+	var (
+		c *ov.OVClient
+	)
+	_, c = getTestDriverU("test_network_set")
+	uri := utils.NewNstring("/rest/network-sets/fake")
+
+	_, err := c.SetNetworkSetBandwidth(uri, 2500, 1000)
+	assert.Error(t, err, "SetNetworkSetBandwidth should fail when typical exceeds maximum")
+
+	_, err = c.SetNetworkSetBandwidth(uri, 0, 1000)
+	assert.Error(t, err, "SetNetworkSetBandwidth should fail with a zero typical bandwidth")
+}
+
+func TestGetNetworkSetAssociatedProfilesNoUri(t *testing.T) {
+	var (
+		c *ov.OVClient
+	)
+	_, c = getTestDriverU("test_network_set")
+	_, err := c.GetNetworkSetAssociatedProfiles(utils.NewNstring(""))
+	assert.Error(t, err, "GetNetworkSetAssociatedProfiles should fail without a uri")
+}
+
+func TestGetNetworkSetAssociatedProfiles(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/server-profiles", r.URL.Path)
+		assert.Equal(t, "name:asc", r.URL.Query().Get("sort"))
+		if r.URL.Query().Get("start") == "" {
+			w.Write([]byte(`{"total":3,"count":2,"nextPageUri":"/rest/server-profiles?start=2&count=2","members":[{"name":"web01","connectionSettings":{"connections":[{"networkUri":"/rest/network-sets/1"}]}},{"name":"db01","connectionSettings":{"connections":[{"networkUri":"/rest/ethernet-networks/1"}]}}]}`))
+			return
+		}
+		w.Write([]byte(`{"total":3,"count":1,"start":2,"members":[{"name":"web02","connectionSettings":{"connections":[{"networkUri":"/rest/ethernet-networks/1"},{"networkUri":"/rest/network-sets/1"}]}}]}`))
+	})
+	defer ts.Close()
+
+	profiles, err := c.GetNetworkSetAssociatedProfiles(utils.NewNstring("/rest/network-sets/1"))
+	assert.NoError(t, err)
+	if assert.Len(t, profiles, 2) {
+		assert.Equal(t, "web01", profiles[0].Name)
+		assert.Equal(t, "web02", profiles[1].Name)
+	}
+}
+
+func TestGetNetworkSetsWithoutEthernet(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/network-sets/withoutEthernet", r.URL.Path)
+		assert.Equal(t, "name:asc", r.URL.Query().Get("sort"))
+		w.Write([]byte(`{"total":1,"count":1,"members":[{"name":"prod","nativeNetworkUri":"/rest/ethernet-networks/1","uri":"/rest/network-sets/1"}]}`))
+	})
+	defer ts.Close()
+
+	sets, err := c.GetNetworkSetsWithoutEthernet("", "name:asc")
+	assert.NoError(t, err)
+	assert.Equal(t, "prod", sets.Members[0].Name)
+	assert.Empty(t, sets.Members[0].NetworkUris)
+	assert.Equal(t, "/rest/ethernet-networks/1", sets.Members[0].NativeNetworkUri.String())
+}
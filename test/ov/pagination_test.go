@@ -0,0 +1,130 @@
+package ov
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/stretchr/testify/assert"
+)
+
+// getMockDriver returns a client logged in to a fake appliance serving handler,
+// the session idle timeout checked on every call is answered by the fake appliance
+func getMockDriver(handler http.HandlerFunc) (*httptest.Server, *ov.OVClient) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rest/sessions/idle-timeout" {
+			w.Write([]byte(`{"idleTimeout": 1800000}`))
+			return
+		}
+		handler(w, r)
+	}))
+	c := &ov.OVClient{Client: rest.Client{
+		User:       "foo",
+		Password:   "bar",
+		Domain:     "LOCAL",
+		Endpoint:   ts.URL,
+		APIVersion: 2400,
+		APIKey:     "session",
+	}}
+	return ts, c
+}
+
+func TestGetAllProfiles(t *testing.T) {
+	var filters []string
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/server-profiles" {
+			http.NotFound(w, r)
+			return
+		}
+		filters = append(filters, r.URL.Query().Get("filter"))
+		page := ov.ServerProfileList{Total: 5}
+		switch r.URL.Query().Get("start") {
+		case "":
+			page.Members = []ov.ServerProfile{{Name: "p1"}, {Name: "p2"}}
+			page.NextPageURI = "/rest/server-profiles?start=2&count=2"
+		case "2":
+			page.Start = 2
+			page.Members = []ov.ServerProfile{{Name: "p3"}, {Name: "p4"}}
+			page.NextPageURI = "https://appliance/rest/server-profiles?start=4&count=2&sort=name:asc"
+		case "4":
+			page.Start = 4
+			page.Members = []ov.ServerProfile{{Name: "p5"}}
+		}
+		page.Count = len(page.Members)
+		data, _ := json.Marshal(page)
+		w.Write(data)
+	})
+	defer ts.Close()
+
+	profiles, err := c.GetAllProfiles("status='OK'", "name:asc")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, profiles.Total)
+	assert.Equal(t, 5, profiles.Count)
+	assert.Equal(t, 5, len(profiles.Members))
+	for i, p := range profiles.Members {
+		assert.Equal(t, fmt.Sprintf("p%d", i+1), p.Name)
+	}
+	assert.True(t, profiles.NextPageURI.IsNil())
+	assert.Equal(t, []string{"status='OK'", "status='OK'", "status='OK'"}, filters, "every page keeps the filter")
+}
+
+func TestIterate(t *testing.T) {
+	var queries []string
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/scopes" {
+			http.NotFound(w, r)
+			return
+		}
+		queries = append(queries, r.URL.RawQuery)
+		page := struct {
+			ov.PageInfo
+			Members []ov.Scope `json:"members"`
+		}{}
+		page.Total = 5
+		switch r.URL.Query().Get("start") {
+		case "":
+			page.Members = []ov.Scope{{Name: "s1"}, {Name: "s2"}}
+			page.NextPageURI = "/rest/scopes?start=2&count=2"
+		case "2":
+			page.Start = 2
+			page.Members = []ov.Scope{{Name: "s3"}, {Name: "s4"}}
+			page.NextPageURI = "/rest/scopes?start=4&count=2"
+		case "4":
+			page.Start = 4
+			page.Members = []ov.Scope{{Name: "s5"}}
+		}
+		page.Count = len(page.Members)
+		data, _ := json.Marshal(page)
+		w.Write(data)
+	})
+	defer ts.Close()
+
+	var names []string
+	err := c.Iterate("/rest/scopes", map[string]interface{}{"filter": "name matches 's%'"}, func(raw json.RawMessage) error {
+		var scope ov.Scope
+		if err := json.Unmarshal(raw, &scope); err != nil {
+			return err
+		}
+		names = append(names, scope.Name)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"s1", "s2", "s3", "s4", "s5"}, names)
+	assert.Equal(t, 3, len(queries))
+	for _, q := range queries {
+		assert.Contains(t, q, "filter=", "every page keeps the filter")
+	}
+
+	stop := fmt.Errorf("stop")
+	calls := 0
+	err = c.Iterate("/rest/scopes", nil, func(raw json.RawMessage) error {
+		calls++
+		return stop
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,69 @@
+package ov
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPowerDevices(t *testing.T) {
+	var (
+		requests []string
+		info     ov.PowerDeviceConnectionInfo
+	)
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /rest/power-devices":
+			assert.Equal(t, "name='ipdu-1'", r.URL.Query().Get("filter"))
+			w.Write([]byte(`{"total": 1, "count": 1, "members": [{"name": "ipdu-1", "uri": "/rest/power-devices/1",
+				"phaseType": "SinglePhase", "state": "Monitored",
+				"powerConnections": [{"connectionUri": "/rest/enclosures/e1", "sourceConnection": "/rest/power-devices/1/outlets/3"}]}]}`))
+		case "GET /rest/power-devices/1/utilization":
+			assert.Equal(t, "AveragePower,PeakPower", r.URL.Query().Get("fields"))
+			w.Write([]byte(`{"metricList": [{"metricName": "AveragePower", "metricSamples": [[1441036118675, 420]]}]}`))
+		case "POST /rest/power-devices/discover":
+			json.NewDecoder(r.Body).Decode(&info)
+			w.Write([]byte(`{"uri": "/rest/tasks/1", "taskState": "Running"}`))
+		case "PUT /rest/power-devices/1/refreshState":
+			w.Write([]byte(`{"uri": "/rest/tasks/2", "taskState": "Running"}`))
+		case "DELETE /rest/power-devices/1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer ts.Close()
+
+	powerDevice, err := c.GetPowerDeviceByName("ipdu-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "SinglePhase", powerDevice.PhaseType)
+	assert.Equal(t, "Monitored", powerDevice.State)
+	if assert.Len(t, powerDevice.PowerConnections, 1) {
+		assert.Equal(t, utils.NewNstring("/rest/enclosures/e1"), powerDevice.PowerConnections[0].ConnectionUri)
+	}
+
+	utilization, err := c.GetPowerDeviceUtilization(powerDevice.URI, []string{"AveragePower", "PeakPower"})
+	assert.NoError(t, err)
+	if assert.Len(t, utilization.MetricList, 1) {
+		assert.Equal(t, float64(420), utilization.MetricList[0].MetricSamples[0][1])
+	}
+
+	_, err = c.AddPowerDevice(ov.PowerDeviceConnectionInfo{})
+	assert.Error(t, err)
+	task, err := c.AddPowerDevice(ov.PowerDeviceConnectionInfo{Hostname: "ipdu.example.com", Username: "admin", Password: "secret"})
+	assert.NoError(t, err)
+	assert.Equal(t, utils.NewNstring("/rest/tasks/1"), task.URI)
+	assert.Equal(t, "ipdu.example.com", info.Hostname)
+
+	task, err = c.DiscoverPowerDevice(powerDevice)
+	assert.NoError(t, err)
+	assert.Equal(t, utils.NewNstring("/rest/tasks/2"), task.URI)
+
+	assert.NoError(t, c.RemovePowerDevice("ipdu-1"))
+	assert.Contains(t, requests, "DELETE /rest/power-devices/1")
+}
@@ -1,6 +1,8 @@
 package ov
 
 import (
+	"io/ioutil"
+	"net/http"
 	"os"
 	"testing"
 
@@ -52,3 +54,36 @@ func TestPowerState(t *testing.T) {
 
 	}
 }
+
+func TestSetServerPowerState(t *testing.T) {
+	var bodies []string
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/server-hardware/1/powerState", r.URL.Path)
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.Write([]byte(`{"taskState":"Running","uri":"/rest/tasks/1"}`))
+	})
+	defer ts.Close()
+
+	sh := ov.ServerHardware{Name: "bay 1", PowerState: "On", URI: "/rest/server-hardware/1"}
+	task, err := c.SetServerPowerState(sh, "Off", "PressAndHold")
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/tasks/1", task.URI.String())
+	_, err = c.SetServerPowerState(sh, "On", "Reset")
+	assert.NoError(t, err)
+	task, err = c.SetServerPowerState(sh, "On", "")
+	assert.NoError(t, err)
+	assert.True(t, task.TaskIsDone, "server already on")
+	assert.Equal(t, []string{
+		`{"powerState":"Off","powerControl":"PressAndHold"}`,
+		`{"powerState":"On","powerControl":"Reset"}`,
+	}, bodies)
+
+	_, err = c.SetServerPowerState(sh, "On", "PressAndHold")
+	assert.Error(t, err)
+	_, err = c.SetServerPowerState(sh, "Off", "ColdBoot")
+	assert.Error(t, err)
+	_, err = c.SetServerPowerState(sh, "Standby", "")
+	assert.Error(t, err)
+	assert.Equal(t, 2, len(bodies))
+}
@@ -0,0 +1,23 @@
+package ov
+
+import (
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetAffinity(t *testing.T) {
+	var profile ov.ServerProfile
+	assert.Equal(t, ov.AFFINITY_BAY, profile.GetAffinity())
+
+	assert.NoError(t, profile.SetAffinity(ov.AFFINITY_BAY_AND_SERVER))
+	assert.Equal(t, "BayAndServer", profile.Affinity)
+	assert.Equal(t, ov.AFFINITY_BAY_AND_SERVER, profile.GetAffinity())
+
+	assert.NoError(t, profile.SetAffinity(""))
+	assert.Equal(t, "Bay", profile.Affinity)
+
+	assert.Error(t, profile.SetAffinity("Server"))
+	assert.Equal(t, "Bay", profile.Affinity)
+}
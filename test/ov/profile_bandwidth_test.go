@@ -0,0 +1,52 @@
+package ov
+
+import (
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhysicalPortId(t *testing.T) {
+	assert.Equal(t, "Mezz 3:1", ov.PhysicalPortId("Mezz 3:1-a"))
+	assert.Equal(t, "Flb 1:2", ov.PhysicalPortId("Flb 1:2"))
+	assert.Equal(t, "", ov.PhysicalPortId("Auto"))
+	assert.Equal(t, "", ov.PhysicalPortId("None"))
+	assert.Equal(t, "", ov.PhysicalPortId(""))
+}
+
+func TestGetPortMaxSpeedMbps(t *testing.T) {
+	sht := ov.ServerHardwareType{
+		Adapters: []ov.Adapter{
+			{Location: "Mezz", Slot: 3, Ports: []ov.SHTPort{{Number: 1, MaxSpeedMbps: 20000}, {Number: 2, MaxSpeedMbps: 10000}}},
+		},
+	}
+	speed, ok := sht.GetPortMaxSpeedMbps("Mezz 3:2-c")
+	assert.True(t, ok)
+	assert.Equal(t, 10000, speed)
+
+	_, ok = sht.GetPortMaxSpeedMbps("Mezz 2:1-a")
+	assert.False(t, ok)
+	_, ok = sht.GetPortMaxSpeedMbps("Auto")
+	assert.False(t, ok)
+}
+
+func TestAutoSizeBandwidthKeepsRequested(t *testing.T) {
+	var (
+		c *ov.OVClient
+	)
+	_, c = getTestDriverU("dev")
+	profile := ov.ServerProfile{
+		ConnectionSettings: ov.ConnectionSettings{
+			Connections: []ov.Connection{
+				{Name: "eth1", PortID: "Mezz 3:1-a", RequestedMbps: "2500"},
+				{Name: "fc1", PortID: "Mezz 3:2-b", FunctionType: "FibreChannel"},
+			},
+		},
+	}
+	warnings, err := profile.AutoSizeBandwidth(c)
+	assert.NoError(t, err, "AutoSizeBandwidth threw error -> %s", err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, "2500", profile.ConnectionSettings.Connections[0].RequestedMbps)
+	assert.Equal(t, "", profile.ConnectionSettings.Connections[1].RequestedMbps)
+}
@@ -0,0 +1,43 @@
+package ov
+
+import (
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetBootOrder(t *testing.T) {
+	var p ov.ServerProfile
+
+	err := p.SetBootOrder([]string{"CD", "USB", "HardDisk", "PXE"})
+	assert.NoError(t, err, "SetBootOrder should accept a legacy BIOS order -> %s", err)
+	assert.True(t, p.Boot.ManageBoot)
+
+	err = p.SetBootOrder([]string{"PXE", "PXE"})
+	assert.Error(t, err, "SetBootOrder should reject duplicate devices")
+
+	err = p.SetBootOrder([]string{"Network"})
+	assert.Error(t, err, "SetBootOrder should reject unknown devices")
+
+	err = p.SetBootMode(ov.BOOT_MODE_UEFI_OPTIMIZED, "")
+	assert.NoError(t, err, "SetBootMode threw error -> %s", err)
+	assert.Equal(t, "Auto", p.BootMode.PXEBootPolicy.String())
+	assert.Nil(t, p.Boot.Order, "SetBootMode should clear an order that is invalid for UEFI")
+
+	err = p.SetBootOrder([]string{"HardDisk", "PXE"})
+	assert.Error(t, err, "SetBootOrder should allow only one device in UEFI mode")
+
+	err = p.SetBootOrder([]string{"PXE"})
+	assert.NoError(t, err, "SetBootOrder threw error -> %s", err)
+}
+
+func TestSetBootMode(t *testing.T) {
+	var p ov.ServerProfile
+
+	assert.Error(t, p.SetBootMode("Legacy", ""), "SetBootMode should reject unknown modes")
+	assert.Error(t, p.SetBootMode(ov.BOOT_MODE_BIOS, "IPv4"), "SetBootMode should reject a pxe policy for BIOS")
+	assert.Error(t, p.SetBootMode(ov.BOOT_MODE_UEFI, "IPv5"), "SetBootMode should reject unknown pxe policies")
+	assert.NoError(t, p.SetBootMode(ov.BOOT_MODE_UEFI, "IPv4ThenIPv6"))
+	assert.True(t, *p.BootMode.ManageMode)
+}
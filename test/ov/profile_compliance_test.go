@@ -0,0 +1,87 @@
+package ov
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfileCompliancePreviewIsCompliant(t *testing.T) {
+	assert.True(t, ov.ProfileCompliancePreview{}.IsCompliant())
+	assert.False(t, ov.ProfileCompliancePreview{AutomaticUpdates: []string{"Change the boot mode."}}.IsCompliant())
+	assert.False(t, ov.ProfileCompliancePreview{ManualUpdates: []string{"Update the firmware baseline."}}.IsCompliant())
+}
+
+func TestRemediateProfile(t *testing.T) {
+	var (
+		d        *OVTest
+		c        *ov.OVClient
+		testName string
+	)
+	if os.Getenv("ONEVIEW_TEST_ACCEPTANCE") == "true" {
+		d, c = getTestDriverA("dev")
+		if c == nil {
+			t.Fatalf("Failed to execute getTestDriver() ")
+		}
+		testName = d.Tc.GetTestData(d.Env, "HostName").(string)
+
+		_, err := c.RemediateProfile(testName, true)
+		assert.NoError(t, err, "RemediateProfile threw error -> %s", err)
+
+		// a second call finds the profile compliant
+		task, err := c.RemediateProfile(testName, true)
+		assert.NoError(t, err, "RemediateProfile threw error -> %s", err)
+		assert.Nil(t, task)
+	} else {
+		_, c = getTestDriverU("dev")
+		_, err := c.RemediateProfile("fake", false)
+		assert.Error(t, err, "RemediateProfile should fail without an appliance")
+	}
+}
+
+func TestGetProfileCompliance(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/server-profiles/1":
+			w.Write([]byte(`{"name": "web01", "uri": "/rest/server-profiles/1", "serverProfileTemplateUri": "/rest/server-profile-templates/1", "templateCompliance": "NonCompliant"}`))
+		case "/rest/server-profiles/2":
+			w.Write([]byte(`{"name": "web02", "uri": "/rest/server-profiles/2"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer ts.Close()
+
+	compliance, err := c.GetProfileCompliance(ov.ServerProfile{URI: "/rest/server-profiles/1"})
+	assert.NoError(t, err)
+	assert.Equal(t, ov.COMPLIANCE_NON_COMPLIANT, compliance)
+
+	_, err = c.GetProfileCompliance(ov.ServerProfile{URI: "/rest/server-profiles/2"})
+	assert.Error(t, err, "GetProfileCompliance should fail for a profile without a template")
+}
+
+func TestRemediateProfileFromTemplate(t *testing.T) {
+	_, c := getTestDriverU("dev")
+	_, err := c.RemediateProfileFromTemplate(ov.ServerProfile{Name: "web02", URI: "/rest/server-profiles/2"})
+	assert.Error(t, err, "RemediateProfileFromTemplate should fail for a profile without a template")
+
+	var ops []ov.PatchOp
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" && r.URL.Path == "/rest/server-profiles/1" {
+			json.NewDecoder(r.Body).Decode(&ops)
+			w.Write([]byte(`{"uri": "/rest/tasks/1", "taskState": "Running"}`))
+			return
+		}
+		http.NotFound(w, r)
+	})
+	defer ts.Close()
+
+	task, err := c.RemediateProfileFromTemplate(ov.ServerProfile{URI: "/rest/server-profiles/1", ServerProfileTemplateURI: "/rest/server-profile-templates/1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/tasks/1", task.URI.String())
+	assert.Equal(t, []ov.PatchOp{{Op: "replace", Path: "/templateCompliance", Value: "Compliant"}}, ops)
+}
@@ -0,0 +1,44 @@
+package ov
+
+import (
+	"os"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUseIdentifiers(t *testing.T) {
+	p := ov.ServerProfile{MACType: ov.IDENTIFIER_VIRTUAL}
+
+	p.UsePhysicalIdentifiers()
+	assert.Equal(t, ov.IDENTIFIER_PHYSICAL, p.MACType)
+	assert.Equal(t, ov.IDENTIFIER_PHYSICAL, p.WWNType)
+	assert.Equal(t, ov.IDENTIFIER_PHYSICAL, p.SerialNumberType)
+
+	p.UseVirtualIdentifiers()
+	assert.Equal(t, ov.IDENTIFIER_VIRTUAL, p.MACType)
+	assert.Equal(t, ov.IDENTIFIER_VIRTUAL, p.WWNType)
+	assert.Equal(t, ov.IDENTIFIER_VIRTUAL, p.SerialNumberType)
+}
+
+func TestValidateIdentifierPools(t *testing.T) {
+	var c *ov.OVClient
+	if os.Getenv("ONEVIEW_TEST_ACCEPTANCE") == "true" {
+		_, c = getTestDriverA("dev")
+		if c == nil {
+			t.Fatalf("Failed to execute getTestDriver() ")
+		}
+		p := ov.ServerProfile{}
+		p.UseVirtualIdentifiers()
+		assert.NoError(t, c.ValidateIdentifierPools(p))
+	} else {
+		_, c = getTestDriverU("dev")
+		p := ov.ServerProfile{}
+		p.UsePhysicalIdentifiers()
+		assert.NoError(t, c.ValidateIdentifierPools(p), "physical identifiers need no id pools")
+
+		p.UseVirtualIdentifiers()
+		assert.Error(t, c.ValidateIdentifierPools(p), "ValidateIdentifierPools should fail without a session")
+	}
+}
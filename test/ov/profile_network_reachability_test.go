@@ -0,0 +1,88 @@
+package ov
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateNetworkSetReachability(t *testing.T) {
+	var (
+		d *OVTest
+		c *ov.OVClient
+	)
+	if os.Getenv("ONEVIEW_TEST_ACCEPTANCE") == "true" {
+		d, c = getTestDriverA("dev")
+		if c == nil {
+			t.Fatalf("Failed to execute getTestDriver() ")
+		}
+		profile, err := c.GetProfileByName(d.Tc.GetTestData(d.Env, "ServerProfileName").(string))
+		assert.NoError(t, err, "GetProfileByName threw error -> %s", err)
+		_, err = c.ValidateNetworkSetReachability(profile)
+		assert.NoError(t, err, "ValidateNetworkSetReachability threw error -> %s", err)
+	} else {
+		_, c = getTestDriverU("dev")
+		profile := ov.ServerProfile{}
+		profile.ConnectionSettings.Connections = []ov.Connection{
+			{ID: 1, NetworkURI: utils.NewNstring("/rest/ethernet-networks/net1")},
+		}
+		warnings, err := c.ValidateNetworkSetReachability(profile)
+		assert.NoError(t, err, "profiles without network set connections need no lookups")
+		assert.Empty(t, warnings)
+
+		profile.ConnectionSettings.Connections = append(profile.ConnectionSettings.Connections,
+			ov.Connection{ID: 2, NetworkURI: utils.NewNstring("/rest/network-sets/set1")})
+		_, err = c.ValidateNetworkSetReachability(profile)
+		assert.Error(t, err, "network set connections need an enclosure group")
+
+		profile.EnclosureGroupURI = utils.NewNstring("/rest/enclosure-groups/eg1")
+		_, err = c.ValidateNetworkSetReachability(profile)
+		assert.Error(t, err, "ValidateNetworkSetReachability should fail without a session")
+	}
+}
+
+func TestWarningString(t *testing.T) {
+	assert.Equal(t, "connection 3: vlan missing", ov.Warning{ConnectionID: 3, Message: "vlan missing"}.String())
+	assert.Equal(t, "vlan missing", ov.Warning{Message: "vlan missing"}.String())
+}
+
+func TestGetProfileAvailableNetworksAndStorageSystems(t *testing.T) {
+	var query url.Values
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		switch r.URL.Path {
+		case "/rest/server-profiles/available-networks":
+			w.Write([]byte(`{"ethernetNetworks": [{"name": "mgmt", "uri": "/rest/ethernet-networks/1", "vlan": 10}],
+				"fcNetworks": [{"name": "san-a", "uri": "/rest/fc-networks/1"}],
+				"networkSets": [{"name": "prod", "uri": "/rest/network-sets/1"}]}`))
+		case "/rest/server-profiles/available-storage-systems":
+			w.Write([]byte(`{"total": 1, "count": 1, "members": [{"name": "3par", "storageSystemUri": "/rest/storage-systems/1",
+				"networks": [{"name": "san-a", "uri": "/rest/fc-networks/1"}]}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer ts.Close()
+
+	networks, err := c.GetAvailableNetworks("/rest/enclosure-groups/1", "/rest/server-hardware-types/1")
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/server-hardware-types/1", query.Get("serverHardwareTypeUri"))
+	assert.Equal(t, "/rest/enclosure-groups/1", query.Get("enclosureGroupUri"))
+	assert.True(t, networks.Contains("/rest/ethernet-networks/1"))
+	assert.True(t, networks.Contains("/rest/fc-networks/1"))
+	assert.True(t, networks.Contains("/rest/network-sets/1"))
+	assert.False(t, networks.Contains("/rest/ethernet-networks/2"))
+
+	systems, err := c.GetProfileAvailableStorageSystems("/rest/enclosure-groups/1", "/rest/server-hardware-types/1")
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/server-hardware-types/1", query.Get("serverHardwareTypeUri"))
+	assert.Equal(t, "/rest/enclosure-groups/1", query.Get("enclosureGroupUri"))
+	assert.Equal(t, 1, len(systems.Members))
+	assert.Equal(t, utils.NewNstring("/rest/storage-systems/1"), systems.Members[0].StorageSystemUri)
+	assert.Equal(t, 1, len(systems.Members[0].Networks))
+}
@@ -0,0 +1,59 @@
+package ov
+
+import (
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/stretchr/testify/assert"
+)
+
+func testProfilePorts() []ov.ProfilePort {
+	ports := []ov.ProfilePort{}
+	for _, phys := range []string{"Mezz 3:1", "Mezz 3:2"} {
+		for _, f := range []string{"a", "b", "c", "d"} {
+			ports = append(ports, ov.ProfilePort{PortId: phys + "-" + f, Type: "Ethernet", MaxSpeedMbps: 20000})
+		}
+	}
+	return ports
+}
+
+func TestDistributeConnections(t *testing.T) {
+	profile := ov.ServerProfile{
+		ConnectionSettings: ov.ConnectionSettings{
+			Connections: []ov.Connection{
+				{Name: "c1", PortID: "Auto", RequestedMbps: "2500"},
+				{Name: "c2", RequestedMbps: "2500"},
+				{Name: "c3", PortID: "Mezz 3:2-a", RequestedMbps: "5000"},
+				{Name: "c4"},
+			},
+		},
+	}
+	err := profile.DistributeConnections(testProfilePorts())
+	assert.NoError(t, err, "DistributeConnections threw error -> %s", err)
+
+	ids := []string{}
+	for _, conn := range profile.ConnectionSettings.Connections {
+		ids = append(ids, conn.PortID)
+	}
+	assert.Equal(t, []string{"Mezz 3:1-a", "Mezz 3:1-b", "Mezz 3:2-a", "Mezz 3:2-b"}, ids)
+}
+
+func TestDistributeConnectionsOversubscribed(t *testing.T) {
+	profile := ov.ServerProfile{
+		ConnectionSettings: ov.ConnectionSettings{
+			Connections: []ov.Connection{
+				{Name: "c1", PortID: "Mezz 3:1-a", RequestedMbps: "15000"},
+				{Name: "c2", PortID: "Mezz 3:2-a", RequestedMbps: "15000"},
+				{Name: "c3", RequestedMbps: "10000"},
+			},
+		},
+	}
+	err := profile.DistributeConnections(testProfilePorts())
+	assert.Error(t, err, "DistributeConnections should fail when a port is oversubscribed")
+	assert.Equal(t, "", profile.ConnectionSettings.Connections[2].PortID, "DistributeConnections should leave the profile unchanged on error")
+
+	profile.ConnectionSettings.Connections = make([]ov.Connection, 9)
+	err = profile.DistributeConnections(testProfilePorts())
+	assert.Error(t, err, "DistributeConnections should fail when there are more connections than ports")
+	assert.Equal(t, make([]ov.Connection, 9), profile.ConnectionSettings.Connections, "DistributeConnections should leave the profile unchanged on error")
+}
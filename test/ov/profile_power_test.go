@@ -0,0 +1,46 @@
+package ov
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPowerProfile(t *testing.T) {
+	var request ov.PowerRequest
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/rest/server-hardware/1":
+			w.Write([]byte(`{"name": "bay1", "uri": "/rest/server-hardware/1", "powerState": "Off"}`))
+		case r.Method == "PUT" && r.URL.Path == "/rest/server-hardware/1/powerState":
+			json.NewDecoder(r.Body).Decode(&request)
+			w.Write([]byte(`{"uri": "/rest/tasks/1", "taskState": "Running"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer ts.Close()
+
+	unassigned := ov.ServerProfile{Name: "web01"}
+	_, err := c.PowerOnProfile(unassigned)
+	assert.Error(t, err, "PowerOnProfile should fail for a profile without server hardware")
+	_, err = c.GetProfilePowerState(unassigned)
+	assert.Error(t, err, "GetProfilePowerState should fail for a profile without server hardware")
+
+	p := ov.ServerProfile{Name: "web01", ServerHardwareURI: "/rest/server-hardware/1"}
+	state, err := c.GetProfilePowerState(p)
+	assert.NoError(t, err)
+	assert.Equal(t, "Off", state)
+
+	task, err := c.PowerOnProfile(p)
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/tasks/1", task.URI.String())
+	assert.Equal(t, ov.PowerRequest{PowerState: "On", PowerControl: "MomentaryPress"}, request)
+
+	task, err = c.PowerOffProfile(p)
+	assert.NoError(t, err)
+	assert.True(t, task.TaskIsDone, "nothing to do when the server is already off")
+}
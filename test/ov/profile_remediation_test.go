@@ -0,0 +1,86 @@
+package ov
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRemediationPlan(t *testing.T) {
+	enc1 := utils.NewNstring("/rest/enclosures/enc1")
+	enc2 := utils.NewNstring("/rest/enclosures/enc2")
+	template := ov.ServerProfile{Name: "template", URI: utils.NewNstring("/rest/server-profile-templates/1")}
+	profiles := []ov.ServerProfile{
+		{Name: "b", EnclosureURI: enc1, TemplateCompliance: "NonCompliant"},
+		{Name: "a", EnclosureURI: enc1, TemplateCompliance: "NonCompliant"},
+		{Name: "c", EnclosureURI: enc2, TemplateCompliance: "NonCompliant"},
+		{Name: "d", EnclosureURI: enc2, TemplateCompliance: "Compliant"},
+		{Name: "rack", ServerHardwareURI: utils.NewNstring("/rest/server-hardware/rack"), TemplateCompliance: "NonCompliant"},
+	}
+
+	plan := ov.NewRemediationPlan(template, profiles, 0)
+	assert.Equal(t, "template", plan.TemplateName)
+	assert.Equal(t, 4, plan.Count())
+	if assert.Equal(t, 2, len(plan.Waves)) {
+		names := []string{}
+		for _, p := range plan.Waves[0].Profiles {
+			names = append(names, p.Name)
+		}
+		assert.Equal(t, []string{"a", "c", "rack"}, names)
+		assert.Equal(t, 1, len(plan.Waves[1].Profiles))
+		assert.Equal(t, "b", plan.Waves[1].Profiles[0].Name)
+	}
+
+	assert.Equal(t, 0, len(ov.NewRemediationPlan(template, nil, 0).Waves))
+}
+
+func TestNewRemediationPlanMaxWaveSize(t *testing.T) {
+	template := ov.ServerProfile{Name: "template", URI: utils.NewNstring("/rest/server-profile-templates/1")}
+	profiles := []ov.ServerProfile{}
+	for i := 0; i < 10; i++ {
+		profiles = append(profiles, ov.ServerProfile{
+			Name:               fmt.Sprintf("rack%02d", i),
+			ServerHardwareURI:  utils.NewNstring(fmt.Sprintf("/rest/server-hardware/rack%02d", i)),
+			TemplateCompliance: "NonCompliant",
+		})
+	}
+
+	plan := ov.NewRemediationPlan(template, profiles, 4)
+	assert.Equal(t, 10, plan.Count())
+	if assert.Equal(t, 3, len(plan.Waves), "10 rack servers in waves of at most 4") {
+		assert.Equal(t, 4, len(plan.Waves[0].Profiles))
+		assert.Equal(t, 4, len(plan.Waves[1].Profiles))
+		assert.Equal(t, 2, len(plan.Waves[2].Profiles))
+	}
+	assert.Equal(t, 1, len(ov.NewRemediationPlan(template, profiles, 0).Waves), "no limit without a max wave size")
+}
+
+func TestPlanFleetRemediation(t *testing.T) {
+	var filters []string
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/server-profile-templates":
+			w.Write([]byte(`{"total":1,"count":1,"members":[{"name":"template","uri":"/rest/server-profile-templates/1"}]}`))
+		case "/rest/server-profiles":
+			filters = append(filters, r.URL.Query().Get("filter"))
+			if r.URL.Query().Get("start") == "" {
+				w.Write([]byte(`{"total":3,"count":2,"nextPageUri":"/rest/server-profiles?start=2&count=2","members":[{"name":"a","enclosureUri":"/rest/enclosures/enc1","templateCompliance":"NonCompliant"},{"name":"b","enclosureUri":"/rest/enclosures/enc1","templateCompliance":"NonCompliant"}]}`))
+				return
+			}
+			w.Write([]byte(`{"total":3,"count":1,"start":2,"members":[{"name":"c","enclosureUri":"/rest/enclosures/enc2","templateCompliance":"NonCompliant"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer ts.Close()
+
+	plan, err := c.PlanFleetRemediation("template", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, plan.Count())
+	assert.Len(t, plan.Waves, 2, "two profiles of enc1 need two waves")
+	assert.Equal(t, []string{"serverProfileTemplateUri='/rest/server-profile-templates/1'", "serverProfileTemplateUri='/rest/server-profile-templates/1'"}, filters, "every page keeps the filter")
+}
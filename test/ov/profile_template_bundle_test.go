@@ -0,0 +1,115 @@
+package ov
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfileTemplateBundleJSON(t *testing.T) {
+	bundle := ov.Bundle{
+		Template:            ov.ServerProfile{Name: "web", ServerHardwareTypeURI: utils.NewNstring("/rest/server-hardware-types/sht1")},
+		EthernetNetworks:    []ov.EthernetNetwork{{Name: "prod", VlanId: 100, URI: utils.NewNstring("/rest/ethernet-networks/n1")}},
+		ServerHardwareTypes: map[utils.Nstring]string{"/rest/server-hardware-types/sht1": "SY 480 Gen10 1"},
+	}
+	data, err := json.Marshal(bundle)
+	assert.NoError(t, err)
+
+	var decoded ov.Bundle
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "web", decoded.Template.Name)
+	assert.Equal(t, 100, decoded.EthernetNetworks[0].VlanId)
+	assert.Equal(t, "SY 480 Gen10 1", decoded.ServerHardwareTypes["/rest/server-hardware-types/sht1"])
+}
+
+func TestImportProfileTemplateBundle(t *testing.T) {
+	var (
+		d *OVTest
+		c *ov.OVClient
+	)
+	if os.Getenv("ONEVIEW_TEST_ACCEPTANCE") == "true" {
+		d, c = getTestDriverA("dev")
+		if c == nil {
+			t.Fatalf("Failed to execute getTestDriver() ")
+		}
+		bundle, err := c.ExportProfileTemplateBundle(d.Tc.GetTestData(d.Env, "TemplateProfile").(string))
+		assert.NoError(t, err, "ExportProfileTemplateBundle threw error -> %s", err)
+
+		// importing on the source appliance resolves every dependency to the existing resource
+		report, err := c.ImportProfileTemplateBundle(bundle)
+		assert.NoError(t, err, "ImportProfileTemplateBundle threw error -> %s", err)
+		for _, dep := range report.Dependencies {
+			assert.Equal(t, ov.BUNDLE_EXISTING, dep.Action, "%s %s", dep.Category, dep.Name)
+			assert.Equal(t, dep.SourceUri, dep.TargetUri)
+		}
+	} else {
+		_, c = getTestDriverU("dev")
+		_, err := c.ImportProfileTemplateBundle(ov.Bundle{})
+		assert.Error(t, err, "ImportProfileTemplateBundle should refuse a bundle without a template")
+
+		bundle := ov.Bundle{
+			Template:         ov.ServerProfile{Name: "web"},
+			EthernetNetworks: []ov.EthernetNetwork{{Name: "prod", URI: utils.NewNstring("/rest/ethernet-networks/n1")}},
+		}
+		report, err := c.ImportProfileTemplateBundle(bundle)
+		assert.Error(t, err, "ImportProfileTemplateBundle should fail without a session")
+		if assert.Equal(t, 1, len(report.Dependencies)) {
+			assert.Equal(t, ov.BUNDLE_FAILED, report.Dependencies[0].Action)
+			assert.Equal(t, "prod", report.Dependencies[0].Name)
+		}
+
+		_, err = c.ExportProfileTemplateBundle("web")
+		assert.Error(t, err, "ExportProfileTemplateBundle should fail without a session")
+	}
+}
+
+func TestImportProfileTemplateBundleRetry(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/interconnect-types":
+			w.Write([]byte(`{"total":1,"count":1,"members":[{"name":"VC SE 40Gb F8 Module","uri":"/rest/interconnect-types/target"}]}`))
+		case "/rest/ethernet-networks":
+			w.Write([]byte(`{"total":1,"count":1,"members":[{"name":"prod","uri":"/rest/ethernet-networks/target"}]}`))
+		case "/rest/logical-interconnect-groups":
+			if r.Method == http.MethodPost {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"message":"The logical interconnect group could not be created."}`))
+				return
+			}
+			w.Write([]byte(`{"total":0,"count":0,"members":[]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer ts.Close()
+
+	bundle := ov.Bundle{
+		Template:          ov.ServerProfile{Name: "web"},
+		EthernetNetworks:  []ov.EthernetNetwork{{Name: "prod", URI: utils.NewNstring("/rest/ethernet-networks/source")}},
+		InterconnectTypes: map[utils.Nstring]string{"/rest/interconnect-types/source": "VC SE 40Gb F8 Module"},
+		LogicalInterconnectGroups: []ov.LogicalInterconnectGroup{{
+			Name: "lig",
+			Type: "logical-interconnect-groupV8",
+			InterconnectMapTemplate: &ov.InterconnectMapTemplate{InterconnectMapEntryTemplates: []ov.InterconnectMapEntryTemplate{
+				{PermittedInterconnectTypeUri: utils.NewNstring("/rest/interconnect-types/source")},
+			}},
+			UplinkSets: []ov.UplinkSets{{Name: "up", NetworkUris: []utils.Nstring{"/rest/ethernet-networks/source"}}},
+		}},
+	}
+
+	// a failed create leaves the bundle as it was, so the import can be retried
+	for i := 0; i < 2; i++ {
+		_, err := c.ImportProfileTemplateBundle(bundle)
+		if assert.Error(t, err) {
+			assert.NotContains(t, err.Error(), "is not available")
+		}
+	}
+	lig := bundle.LogicalInterconnectGroups[0]
+	assert.Equal(t, "/rest/interconnect-types/source", lig.InterconnectMapTemplate.InterconnectMapEntryTemplates[0].PermittedInterconnectTypeUri.String())
+	assert.Equal(t, []utils.Nstring{"/rest/ethernet-networks/source"}, lig.UplinkSets[0].NetworkUris)
+}
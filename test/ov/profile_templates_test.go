@@ -1,11 +1,13 @@
 package ov
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/HewlettPackard/oneview-golang/ov"
 	"github.com/HewlettPackard/oneview-golang/utils"
 	"github.com/docker/machine/libmachine/log"
 	"github.com/stretchr/testify/assert"
+	"net/http"
 	"os"
 	"testing"
 )
@@ -138,3 +140,104 @@ func TestDeleteProfileTemplate(t *testing.T) {
 	}
 
 }
+
+func TestCreateProfileFromTemplateURI(t *testing.T) {
+	_, c := getTestDriverU("test_server_profile_template")
+	err := c.CreateProfileFromTemplateURI("footest", "", ov.ServerHardware{})
+	assert.Error(t, err, "CreateProfileFromTemplateURI should refuse an empty template URI")
+
+	var submitted ov.ServerProfile
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/rest/server-profile-templates/1/new-profile":
+			w.Write([]byte(`{"type": "ServerProfileV12", "serverHardwareTypeUri": "/rest/server-hardware-types/1",
+				"connectionSettings": {"connections": [{"id": 1, "name": "mgmt", "networkUri": "/rest/ethernet-networks/1"}]},
+				"localStorage": {"controllers": [{"deviceSlot": "Embedded", "mode": "RAID"}]}}`))
+		case r.URL.Path == "/rest/server-profiles/available-targets":
+			w.Write([]byte(`{"targets": [{"serverHardwareUri": "/rest/server-hardware/1"}]}`))
+		case r.URL.Path == "/rest/server-hardware/1":
+			w.Write([]byte(`{"name": "bay1", "uri": "/rest/server-hardware/1", "powerState": "Off"}`))
+		case r.Method == "POST" && r.URL.Path == "/rest/server-profiles":
+			json.NewDecoder(r.Body).Decode(&submitted)
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer ts.Close()
+
+	err = c.CreateProfileFromTemplateURI("footest", "/rest/server-profile-templates/1", ov.ServerHardware{URI: "/rest/server-hardware/1"})
+	assert.Error(t, err)
+	assert.Equal(t, "footest", submitted.Name)
+	assert.Equal(t, utils.NewNstring("/rest/server-hardware/1"), submitted.ServerHardwareURI)
+	assert.Equal(t, utils.NewNstring("/rest/server-profile-templates/1"), submitted.ServerProfileTemplateURI)
+	assert.Equal(t, 1, len(submitted.ConnectionSettings.Connections), "connections are inherited from the template")
+	assert.Equal(t, 1, len(submitted.LocalStorage.Controllers), "local storage is inherited from the template")
+}
+
+func TestGetNewProfileFromTemplate(t *testing.T) {
+	_, c := getTestDriverU("test_server_profile_template")
+	_, err := c.GetNewProfileFromTemplate("")
+	assert.Error(t, err, "GetNewProfileFromTemplate should refuse an empty template URI")
+
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/server-profile-templates/1/new-profile" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"type": "ServerProfileV12", "serverProfileTemplateUri": "/rest/server-profile-templates/1",
+			"connectionSettings": {"connections": [{"id": 1, "name": "mgmt"}]}}`))
+	})
+	defer ts.Close()
+
+	profile, err := c.GetNewProfileFromTemplate("/rest/server-profile-templates/1")
+	assert.NoError(t, err)
+	assert.Equal(t, "ServerProfileV12", profile.Type)
+	assert.True(t, profile.URI.IsNil(), "the derived profile is not saved")
+	assert.Equal(t, 1, len(profile.ConnectionSettings.Connections))
+
+	_, err = c.GetNewProfileFromTemplate("/rest/server-profile-templates/deleted")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "does not exist")
+}
+
+func TestCreateAndUpdateServerProfileTemplate(t *testing.T) {
+	var (
+		submitted ov.ServerProfileTemplate
+		ifMatch   string
+	)
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/rest/server-profile-templates",
+			r.Method == "PUT" && r.URL.Path == "/rest/server-profile-templates/1":
+			ifMatch = r.Header.Get("If-Match")
+			json.NewDecoder(r.Body).Decode(&submitted)
+			w.Write([]byte(`{"uri": "/rest/tasks/1", "taskState": "Running"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer ts.Close()
+
+	template := ov.ServerProfileTemplate{
+		Name:               "web",
+		Type:               "ServerProfileTemplateV8",
+		ConnectionSettings: ov.ConnectionSettings{ManageConnections: true, Connections: []ov.Connection{{ID: 1, Name: "mgmt", NetworkURI: "/rest/ethernet-networks/1"}}},
+		LocalStorage:       ov.LocalStorageOptions{ManageLocalStorage: true, Initialize: true},
+	}
+	task, err := c.CreateServerProfileTemplate(template)
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/tasks/1", task.URI.String())
+	assert.Equal(t, template.ConnectionSettings, submitted.ConnectionSettings, "connections round trip")
+	assert.Equal(t, template.LocalStorage, submitted.LocalStorage, "local storage round trip")
+
+	_, err = c.UpdateServerProfileTemplate(template)
+	assert.Error(t, err, "UpdateServerProfileTemplate should refuse a template without a URI")
+
+	template.URI = "/rest/server-profile-templates/1"
+	template.ETAG = "2"
+	_, err = c.UpdateServerProfileTemplate(template)
+	assert.NoError(t, err)
+	assert.Equal(t, "2", ifMatch)
+	assert.Equal(t, template.ConnectionSettings, submitted.ConnectionSettings)
+}
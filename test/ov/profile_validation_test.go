@@ -0,0 +1,133 @@
+package ov
+
+import (
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateProfileDefinition(t *testing.T) {
+	p := ov.ServerProfile{Name: "web-1"}
+	p.ConnectionSettings.Connections = []ov.Connection{
+		{ID: 1, Name: "mgmt", FunctionType: "Ethernet", NetworkURI: utils.NewNstring("/rest/ethernet-networks/mgmt")},
+		{ID: 2, Name: "san-a", FunctionType: "FibreChannel", NetworkURI: utils.NewNstring("/rest/fc-networks/a")},
+	}
+	assert.Empty(t, ov.ValidateProfileDefinition(p))
+
+	p.Boot.ManageBoot = true
+	p.BootMode.Mode = ov.BOOT_MODE_UEFI
+	p.Boot.Order = []string{"HardDisk", "PXE"}
+	p.ConnectionSettings.Connections = append(p.ConnectionSettings.Connections,
+		ov.Connection{ID: 2, Name: "MGMT", MacType: "UserDefined", FunctionType: "Ethernet"})
+	p.SanStorage.ManageSanStorage = true
+	p.SanStorage.HostOSType = "VMware (ESXi)"
+	p.SanStorage.VolumeAttachments = []ov.VolumeAttachment{
+		{ID: 1, StoragePaths: []ov.StoragePath{{ConnectionID: 1}, {ConnectionID: 7}}},
+	}
+	p.Firmware.ManageFirmware = true
+
+	issues := ov.ValidateProfileDefinition(p)
+	assert.True(t, ov.HasValidationErrors(issues))
+	checks := map[string]int{}
+	for _, issue := range issues {
+		assert.Equal(t, ov.VALIDATION_SEVERITY_ERROR, issue.Severity)
+		checks[issue.Check]++
+	}
+	assert.Equal(t, 1, checks["boot"])
+	assert.Equal(t, 3, checks["connections"])
+	assert.Equal(t, 1, checks["identifiers"])
+	assert.Equal(t, 2, checks["san"])
+	assert.Equal(t, 1, checks["firmware"])
+}
+
+func TestValidateProfile(t *testing.T) {
+	var (
+		d *OVTest
+		c *ov.OVClient
+	)
+	if os.Getenv("ONEVIEW_TEST_ACCEPTANCE") == "true" {
+		d, c = getTestDriverA("dev")
+		if c == nil {
+			t.Fatalf("Failed to execute getTestDriver() ")
+		}
+		profile, err := c.GetProfileByName(d.Tc.GetTestData(d.Env, "ServerProfileName").(string))
+		assert.NoError(t, err, "GetProfileByName threw error -> %s", err)
+		issues, err := c.ValidateProfile(profile)
+		assert.NoError(t, err, "ValidateProfile threw error -> %s", err)
+		assert.False(t, ov.HasValidationErrors(issues), "existing profile has validation errors %v", issues)
+	} else {
+		_, c = getTestDriverU("dev")
+		p := ov.ServerProfile{ServerHardwareTypeURI: utils.NewNstring("/rest/server-hardware-types/fake")}
+		_, err := c.ValidateProfile(p)
+		assert.Error(t, err, "ValidateProfile should fail when the server hardware type can not be read")
+
+		p = ov.ServerProfile{InitialScopeUris: []utils.Nstring{utils.NewNstring("/rest/scopes/fake")}}
+		issues, err := c.ValidateProfile(p)
+		assert.NoError(t, err)
+		assert.True(t, ov.HasValidationErrors(issues))
+	}
+}
+
+func TestValidateProfileType(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+	defer ts.Close()
+
+	typeIssues := func(p ov.ServerProfile) []ov.ValidationIssue {
+		issues, err := c.ValidateProfile(p)
+		assert.NoError(t, err)
+		var found []ov.ValidationIssue
+		for _, issue := range issues {
+			if issue.Check == "type" {
+				found = append(found, issue)
+			}
+		}
+		return found
+	}
+
+	issues := typeIssues(ov.ServerProfile{Name: "profile", Type: "ServerProfileV11"})
+	if assert.Equal(t, 1, len(issues)) {
+		assert.Equal(t, ov.VALIDATION_SEVERITY_ERROR, issues[0].Severity)
+		assert.Contains(t, issues[0].Message, "ServerProfileV12")
+	}
+	assert.Equal(t, 1, len(typeIssues(ov.ServerProfile{Name: "profile"})))
+	assert.Empty(t, typeIssues(ov.ServerProfile{Name: "profile", Type: "ServerProfileV12"}))
+
+	c.APIVersion = 1200
+	assert.Empty(t, typeIssues(ov.ServerProfile{Name: "profile", Type: "ServerProfileV11"}))
+}
+
+func TestValidateProfileSubmission(t *testing.T) {
+	p := ov.ServerProfile{
+		Name:                  "web01",
+		ServerHardwareTypeURI: "/rest/server-hardware-types/1",
+		EnclosureGroupURI:     "/rest/enclosure-groups/1",
+		ConnectionSettings: ov.ConnectionSettings{Connections: []ov.Connection{
+			{ID: 1, Name: "mgmt", NetworkURI: "/rest/ethernet-networks/1"},
+		}},
+	}
+	assert.NoError(t, ov.ValidateProfileSubmission(p))
+
+	p.Name = ""
+	p.EnclosureGroupURI = "/rest/enclosures/1"
+	p.EnclosureBay = 3
+	p.SerialNumberType = "Virtual"
+	p.SerialNumber = "2M25090RMW"
+	p.ConnectionSettings.Connections[0].NetworkURI = "/rest/scopes/1"
+	err := ov.ValidateProfileSubmission(p)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "profile has no name")
+		assert.Contains(t, err.Error(), "/rest/enclosures/1 is not a /rest/enclosure-groups/ uri")
+		assert.Contains(t, err.Error(), "enclosure bay without an enclosure")
+		assert.Contains(t, err.Error(), "serial number type Virtual")
+		assert.Contains(t, err.Error(), "connection 1 network /rest/scopes/1")
+	}
+
+	err = ov.ValidateProfileSubmission(ov.ServerProfile{Name: "web02"})
+	assert.Error(t, err, "a profile needs server hardware or a server hardware type")
+}
@@ -1,9 +1,14 @@
 package ov
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/HewlettPackard/oneview-golang/ov"
 	"github.com/docker/machine/libmachine/log"
@@ -78,6 +83,54 @@ func TestCreateProfileFromTemplate(t *testing.T) {
 
 }
 
+func TestCreateProfileFromTemplateWithOptions(t *testing.T) {
+	var (
+		requests  []string
+		poweredOn bool
+	)
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /rest/server-profile-templates/1":
+			w.Write([]byte(`{"name": "web", "type": "ServerProfileTemplateV8", "uri": "/rest/server-profile-templates/1"}`))
+		case "GET /rest/server-profiles/available-targets":
+			w.Write([]byte(`{"targets": [{"serverHardwareUri": "/rest/server-hardware/1"}]}`))
+		case "GET /rest/server-hardware/1":
+			state := "Off"
+			if poweredOn {
+				state = "On"
+			}
+			w.Write([]byte(`{"name": "bay1", "uri": "/rest/server-hardware/1", "powerState": "` + state + `"}`))
+		case "POST /rest/server-profiles":
+			w.Write([]byte(`{"uri": "/rest/tasks/1", "taskState": "Running"}`))
+		case "PUT /rest/server-hardware/1/powerState":
+			poweredOn = true
+			w.Write([]byte(`{"uri": "/rest/tasks/2", "taskState": "Running"}`))
+		case "GET /rest/tasks/1", "GET /rest/tasks/2":
+			w.Write([]byte(`{"uri": "` + r.URL.Path + `", "taskState": "Completed"}`))
+		case "GET /rest/server-profiles":
+			w.Write([]byte(`{"total": 1, "count": 1, "members": [{"name": "web01", "uri": "/rest/server-profiles/1"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer ts.Close()
+
+	template := ov.ServerProfile{Name: "web", URI: "/rest/server-profile-templates/1"}
+	blade := ov.ServerHardware{Name: "bay1", URI: "/rest/server-hardware/1"}
+	profile, err := c.CreateProfileFromTemplateWithOptions("web01", template, blade, ov.CreateOptions{PowerOnAfterCreate: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "web01", profile.Name)
+	assert.Contains(t, requests, "POST /rest/server-profiles")
+	assert.Contains(t, requests, "PUT /rest/server-hardware/1/powerState")
+	assert.True(t, poweredOn)
+
+	requests = nil
+	_, err = c.CreateProfileFromTemplateWithOptions("web02", template, ov.ServerHardware{}, ov.CreateOptions{PowerOnAfterCreate: true})
+	assert.Error(t, err, "CreateProfileFromTemplateWithOptions should refuse to power on without a server hardware")
+	assert.Empty(t, requests, "no profile should be created when it can not be powered on")
+}
+
 // TestSubmitNewProfile functionality
 func TestSubmitNewProfile(t *testing.T) {
 	var (
@@ -288,3 +341,298 @@ func TestDeleteProfile(t *testing.T) {
 	}
 
 }
+
+func TestNewProfileDescription(t *testing.T) {
+	template := ov.ServerProfile{Name: "template", ServerProfileDescription: "desc"}
+
+	first := ov.ServerProfile{Name: "name1", Description: ov.NewProfileDescription(template, "name1", "")}
+	assert.Equal(t, "desc name1", first.Description)
+
+	// cloning the template again does not carry the previous name
+	assert.Equal(t, "desc name2", ov.NewProfileDescription(template, "name2", ""))
+
+	// cloning a clone does not compound names
+	second := ov.ServerProfile{Name: "name2", Description: ov.NewProfileDescription(first, "name2", "")}
+	assert.Equal(t, "desc name2", second.Description)
+	assert.Equal(t, "desc name3", ov.NewProfileDescription(second, "name3", ""))
+
+	// no description on the template
+	assert.Equal(t, "name1", ov.NewProfileDescription(ov.ServerProfile{Name: "template"}, "name1", ""))
+
+	first.Description = ov.NewProfileDescription(template, "name1", " - ")
+	assert.Equal(t, "desc - name1", first.Description)
+	assert.Equal(t, "desc - name2", ov.NewProfileDescription(first, "name2", " - "))
+}
+
+func TestNewProfileFromTemplateDescriptionSeparator(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name": "template", "uri": "/rest/server-profiles/template"}`))
+	})
+	defer ts.Close()
+	c.ProfileDescriptionSeparator = " - "
+
+	template := ov.ServerProfile{Name: "template", URI: "/rest/server-profiles/template", ServerProfileDescription: "desc"}
+	profile, err := c.NewProfileFromTemplate("name1", template, ov.ServerHardware{URI: "/rest/server-hardware/1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "desc - name1", profile.Description)
+}
+
+func TestCreateProfileWithRollback(t *testing.T) {
+	var c *ov.OVClient
+	if os.Getenv("ONEVIEW_TEST_ACCEPTANCE") == "true" {
+		_, c = getTestDriverA("dev")
+		if c == nil {
+			t.Fatalf("Failed to execute getTestDriver() ")
+		}
+	} else {
+		_, c = getTestDriverU("dev")
+	}
+	_, err := c.CreateProfileWithRollback(ov.ServerProfile{})
+	assert.Error(t, err, "CreateProfileWithRollback should refuse a profile without a name")
+
+	if os.Getenv("ONEVIEW_TEST_ACCEPTANCE") != "true" {
+		_, err = c.CreateProfileWithRollback(ov.ServerProfile{Name: "footest"})
+		assert.Error(t, err, "CreateProfileWithRollback should fail without a session")
+	}
+}
+
+func TestRefreshProfileFromServer(t *testing.T) {
+	var (
+		d *OVTest
+		c *ov.OVClient
+	)
+	if os.Getenv("ONEVIEW_TEST_ACCEPTANCE") == "true" {
+		d, c = getTestDriverA("dev")
+		if c == nil {
+			t.Fatalf("Failed to execute getTestDriver() ")
+		}
+		profile, err := c.GetProfileByName(d.Tc.GetTestData(d.Env, "ServerProfileName").(string))
+		assert.NoError(t, err, "GetProfileByName threw error -> %s", err)
+		refreshed, err := c.RefreshProfileFromServer(profile.URI.String())
+		assert.NoError(t, err, "RefreshProfileFromServer threw error -> %s", err)
+		assert.Equal(t, profile.URI, refreshed.URI)
+	} else {
+		_, c = getTestDriverU("dev")
+		_, err := c.RefreshProfileFromServer("/rest/server-profiles/fake")
+		assert.Error(t, err, "RefreshProfileFromServer should fail without a session")
+	}
+}
+
+func TestGetProfileByNameWithContext(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	})
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := c.GetProfileByNameWithContext(ctx, "footest")
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.True(t, time.Since(start) < 2*time.Second, "request should stop once the context is done")
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	err = c.SubmitNewProfileWithContext(ctx, ov.ServerProfile{Name: "footest"})
+	assert.Equal(t, context.Canceled, err)
+
+	p := ov.ServerProfile{Name: "footest", URI: "/rest/server-profiles/1"}
+	_, err = c.UpdateProfileWithContext(ctx, p)
+	assert.Equal(t, context.Canceled, err)
+	_, err = c.PatchProfileWithContext(ctx, p.URI, []ov.PatchOp{{Op: "replace", Path: "/templateCompliance", Value: "Compliant"}})
+	assert.Equal(t, context.Canceled, err)
+	err = c.CreateProfileFromTemplateWithContext(ctx, "footest", ov.ServerProfile{URI: "/rest/server-profile-templates/1"}, ov.ServerHardware{})
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestUpdateProfile(t *testing.T) {
+	_, c := getTestDriverU("dev")
+	_, err := c.UpdateProfile(ov.ServerProfile{Name: "footest"})
+	assert.Error(t, err, "UpdateProfile should refuse a profile without a URI")
+
+	var ifMatch string
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/rest/server-profiles/1" {
+			http.NotFound(w, r)
+			return
+		}
+		ifMatch = r.Header.Get("If-Match")
+		w.WriteHeader(http.StatusPreconditionFailed)
+		w.Write([]byte(`{"message": "The resource was modified by another request."}`))
+	})
+	defer ts.Close()
+
+	_, err = c.UpdateProfile(ov.ServerProfile{Name: "footest", URI: "/rest/server-profiles/1", ETAG: "1441036118675/8"})
+	assert.True(t, errors.Is(err, ov.ErrStaleResource), "UpdateProfile should surface a stale eTag, got %v", err)
+	assert.Equal(t, "1441036118675/8", ifMatch)
+}
+
+func TestRefreshETag(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name": "latest", "uri": "/rest/server-profiles/1", "eTag": "1441036118675/9"}`))
+	})
+	defer ts.Close()
+
+	p := ov.ServerProfile{Name: "footest", URI: "/rest/server-profiles/1", ETAG: "1441036118675/8"}
+	err := c.RefreshETag(&p)
+	assert.NoError(t, err)
+	assert.Equal(t, "1441036118675/9", p.ETAG)
+	assert.Equal(t, "footest", p.Name, "RefreshETag should only update the eTag")
+
+	err = c.RefreshETag(&ov.ServerProfile{})
+	assert.Error(t, err, "RefreshETag should refuse a profile without a URI")
+}
+
+func TestPatchProfile(t *testing.T) {
+	_, c := getTestDriverU("dev")
+	_, err := c.PatchProfile("", []ov.PatchOp{{Op: "replace", Path: "/description", Value: "web"}})
+	assert.Error(t, err, "PatchProfile should refuse an empty URI")
+	_, err = c.PatchProfile("/rest/server-profiles/1", []ov.PatchOp{{Op: "move", Path: "/description"}})
+	assert.Error(t, err, "PatchProfile should refuse an unsupported operation")
+	_, err = c.PatchProfile("/rest/server-profiles/1", []ov.PatchOp{{Op: "replace", Path: "description"}})
+	assert.Error(t, err, "PatchProfile should refuse a path without a leading slash")
+
+	var contentType string
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message": "The path /description cannot be patched."}`))
+	})
+	defer ts.Close()
+
+	_, err = c.PatchProfile("/rest/server-profiles/1", []ov.PatchOp{{Op: "replace", Path: "/description", Value: "web"}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "The path /description cannot be patched.")
+	assert.Equal(t, "application/json-patch+json", contentType)
+}
+
+func TestReassignProfile(t *testing.T) {
+	var requests []string
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /rest/server-hardware/2":
+			w.Write([]byte(`{"name": "spare", "uri": "/rest/server-hardware/2", "serverHardwareTypeUri": "/rest/server-hardware-types/2", "powerState": "On"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer ts.Close()
+	p := ov.ServerProfile{Name: "web01", ServerHardwareTypeURI: "/rest/server-hardware-types/1", ServerHardwareURI: "/rest/server-hardware/1"}
+
+	_, err := c.ReassignProfile(p, ov.ServerHardware{})
+	assert.Error(t, err, "ReassignProfile should refuse a target without a URI")
+
+	// the hardware type is checked on the target read from the appliance, not on the stale copy given
+	_, err = c.ReassignProfile(p, ov.ServerHardware{Name: "spare", URI: "/rest/server-hardware/2", ServerHardwareTypeURI: "/rest/server-hardware-types/1"})
+	assert.True(t, errors.Is(err, ov.ErrIncompatibleHardware), "ReassignProfile should refuse a different hardware type, got %v", err)
+	assert.Equal(t, []string{"GET /rest/server-hardware/2"}, requests)
+
+	_, err = c.ReassignProfile(p, ov.ServerHardware{Name: "bay1", URI: "/rest/server-hardware/1", ServerHardwareTypeURI: "/rest/server-hardware-types/1"})
+	assert.Error(t, err, "ReassignProfile should refuse the hardware already assigned")
+}
+
+func TestReassignProfileSourcePowerOffFails(t *testing.T) {
+	var requests []string
+	failTarget := false
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /rest/server-hardware/1":
+			w.Write([]byte(`{"name": "bay1", "uri": "/rest/server-hardware/1", "powerState": "On"}`))
+		case "GET /rest/server-hardware/2":
+			w.Write([]byte(`{"name": "spare", "uri": "/rest/server-hardware/2", "serverHardwareTypeUri": "/rest/server-hardware-types/1", "powerState": "On"}`))
+		case "PUT /rest/server-hardware/1/powerState":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"message": "The server hardware is not responding."}`))
+		case "PUT /rest/server-hardware/2/powerState":
+			if failTarget {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"message": "The server hardware is not responding."}`))
+				return
+			}
+			w.Write([]byte(`{"uri": "/rest/tasks/1", "taskState": "Running"}`))
+		case "PUT /rest/server-profiles/1":
+			w.Write([]byte(`{"uri": "/rest/tasks/2", "taskState": "Running"}`))
+		case "GET /rest/tasks/1", "GET /rest/tasks/2":
+			w.Write([]byte(`{"uri": "` + r.URL.Path + `", "taskState": "Completed"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer ts.Close()
+
+	p := ov.ServerProfile{Name: "web01", URI: "/rest/server-profiles/1", ServerHardwareTypeURI: "/rest/server-hardware-types/1", ServerHardwareURI: "/rest/server-hardware/1"}
+	target := ov.ServerHardware{Name: "spare", URI: "/rest/server-hardware/2", ServerHardwareTypeURI: "/rest/server-hardware-types/1"}
+	task, err := c.ReassignProfile(p, target)
+	assert.NoError(t, err, "ReassignProfile should go on when the source cannot be powered off")
+	if assert.NotNil(t, task) {
+		assert.Equal(t, "/rest/tasks/2", task.URI.String())
+	}
+	assert.Contains(t, requests, "PUT /rest/server-hardware/1/powerState")
+	assert.Contains(t, requests, "PUT /rest/server-profiles/1")
+
+	requests, failTarget = nil, true
+	_, err = c.ReassignProfile(p, target)
+	assert.Error(t, err, "ReassignProfile should stop when the target cannot be powered off")
+	assert.NotContains(t, requests, "PUT /rest/server-profiles/1")
+}
+
+func TestGetProfilesByScope(t *testing.T) {
+	var query string
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/server-profiles" {
+			http.NotFound(w, r)
+			return
+		}
+		query = r.URL.Query().Get("query")
+		if query == "scope:'/rest/scopes/empty'" {
+			w.Write([]byte(`{"total": 0, "count": 0, "members": []}`))
+			return
+		}
+		w.Write([]byte(`{"total": 2, "count": 2, "members": [{"name": "web01"}, {"name": "web02"}]}`))
+	})
+	defer ts.Close()
+
+	_, err := c.GetProfilesByScope("/rest/ethernet-networks/1", "")
+	assert.Error(t, err, "GetProfilesByScope should refuse a URI that is not a scope")
+
+	profiles, err := c.GetProfilesByScope("/rest/scopes/1", "name:asc")
+	assert.NoError(t, err)
+	assert.Equal(t, "scope:'/rest/scopes/1'", query)
+	assert.Equal(t, 2, len(profiles.Members))
+
+	profiles, err = c.GetProfilesByScope("/rest/scopes/empty", "")
+	assert.NoError(t, err, "an empty scope is not an error")
+	assert.Equal(t, 0, len(profiles.Members))
+}
+
+func TestGetProfileAvailableTargets(t *testing.T) {
+	var query url.Values
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/server-profiles/available-targets" {
+			http.NotFound(w, r)
+			return
+		}
+		query = r.URL.Query()
+		w.Write([]byte(`{"type": "AvailableTargetsV2", "targets": [
+			{"enclosureBay": 1, "enclosureGroupUri": "/rest/enclosure-groups/1", "serverHardwareUri": "/rest/server-hardware/1"},
+			{"enclosureBay": 2, "enclosureGroupUri": "/rest/enclosure-groups/2"}]}`))
+	})
+	defer ts.Close()
+
+	targets, err := c.GetProfileAvailableTargets("/rest/server-profile-templates/1", "/rest/enclosure-groups/1", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/server-profile-templates/1", query.Get("profileTemplateUri"))
+	assert.Equal(t, "/rest/enclosure-groups/1", query.Get("enclosureGroupUri"))
+	assert.Equal(t, "", query.Get("serverHardwareTypeUri"))
+	assert.Equal(t, 1, len(targets.Members), "targets outside the enclosure group are left out")
+	assert.Equal(t, "/rest/server-hardware/1", targets.Members[0].ServerHardwareUri)
+
+	targets, err = c.GetProfileAvailableTargets("", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(targets.Members))
+}
@@ -0,0 +1,37 @@
+package ov
+
+import (
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultEnclosureGroupType(t *testing.T) {
+	assert.Equal(t, "EnclosureGroupV200", ov.DefaultEnclosureGroupType(0))
+	assert.Equal(t, "EnclosureGroupV200", ov.DefaultEnclosureGroupType(200))
+	assert.Equal(t, "EnclosureGroupV300", ov.DefaultEnclosureGroupType(300))
+	assert.Equal(t, "EnclosureGroupV400", ov.DefaultEnclosureGroupType(500))
+	assert.Equal(t, "EnclosureGroupV7", ov.DefaultEnclosureGroupType(800))
+	assert.Equal(t, "EnclosureGroupV8", ov.DefaultEnclosureGroupType(2400))
+}
+
+func TestDefaultLIGType(t *testing.T) {
+	assert.Equal(t, "logical-interconnect-groupV3", ov.DefaultLIGType(120))
+	assert.Equal(t, "logical-interconnect-groupV300", ov.DefaultLIGType(500))
+	assert.Equal(t, "logical-interconnect-groupV4", ov.DefaultLIGType(600))
+	assert.Equal(t, "logical-interconnect-groupV7", ov.DefaultLIGType(1200))
+	assert.Equal(t, "logical-interconnect-groupV8", ov.DefaultLIGType(2400))
+}
+
+func TestDefaultServerProfileType(t *testing.T) {
+	assert.Equal(t, "ServerProfileV5", ov.DefaultServerProfileType(200))
+	assert.Equal(t, "ServerProfileV6", ov.DefaultServerProfileType(300))
+	assert.Equal(t, "ServerProfileV7", ov.DefaultServerProfileType(500))
+	assert.Equal(t, "ServerProfileV8", ov.DefaultServerProfileType(600))
+	assert.Equal(t, "ServerProfileV9", ov.DefaultServerProfileType(800))
+	assert.Equal(t, "ServerProfileV10", ov.DefaultServerProfileType(1000))
+	assert.Equal(t, "ServerProfileV11", ov.DefaultServerProfileType(1200))
+	assert.Equal(t, "ServerProfileV12", ov.DefaultServerProfileType(1600))
+	assert.Equal(t, "ServerProfileV12", ov.DefaultServerProfileType(2400))
+}
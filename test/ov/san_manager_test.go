@@ -0,0 +1,83 @@
+package ov
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanManagers(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/fc-sans/device-managers":
+			assert.Equal(t, "name='bna1'", r.URL.Query().Get("filter"))
+			w.Write([]byte(`{"total":1,"count":1,"members":[{"name":"bna1","uri":"/rest/fc-sans/device-managers/1"}]}`))
+		case "/rest/fc-sans/device-managers/1":
+			assert.Equal(t, http.MethodPut, r.Method)
+			var body map[string]string
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "RefreshPending", body["refreshState"])
+			w.Write([]byte(`{"taskState":"Running","uri":"/rest/tasks/2"}`))
+		case "/rest/fc-sans/providers/1/device-managers":
+			assert.Equal(t, http.MethodPost, r.Method)
+			var body ov.SanManager
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "Host", body.ConnectionInfo[0].Name)
+			w.Write([]byte(`{"taskState":"Running","uri":"/rest/tasks/1"}`))
+		case "/rest/fc-sans/managed-sans":
+			w.Write([]byte(`{"total":1,"count":1,"members":[{"name":"SAN1_0","zoningState":"Automated","zoningPolicy":{"zoningType":"SingleInitiatorAllTargets"},"uri":"/rest/fc-sans/managed-sans/1"}]}`))
+		}
+	})
+	defer ts.Close()
+
+	task, err := c.AddSanManager(utils.NewNstring("/rest/fc-sans/providers/1"), []ov.SanConnectionInfo{{Name: "Host", Value: "172.18.15.1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/tasks/1", task.URI.String())
+
+	_, err = c.AddSanManager(utils.NewNstring("/rest/fc-sans/providers/1"), nil)
+	assert.Error(t, err)
+
+	sanManager, err := c.GetSanManagerByName("bna1")
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/fc-sans/device-managers/1", sanManager.URI.String())
+
+	task, err = c.RefreshSanManager(sanManager)
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/tasks/2", task.URI.String())
+
+	san, err := c.GetManagedSanByName("SAN1_0")
+	assert.NoError(t, err)
+	assert.Equal(t, "Automated", san.ZoningState)
+	assert.Equal(t, "SingleInitiatorAllTargets", san.ZoningPolicy.ZoningType)
+}
+
+func TestGetManagedSanZones(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/fc-sans/managed-sans/1/endpoints", r.URL.Path)
+		w.Write([]byte(`{"total":3,"count":3,"members":[
+			{"wwn":"10:00:00:00:00:00:00:01","alias":"host1","fabricName":"SAN1_0","zones":["zone1"]},
+			{"wwn":"20:00:00:00:00:00:00:01","alias":"array1","fabricName":"SAN1_0","zones":["zone1","zone2"]},
+			{"wwn":"10:00:00:00:00:00:00:02","fabricName":"SAN1_0"}]}`))
+	})
+	defer ts.Close()
+
+	san := utils.NewNstring("/rest/fc-sans/managed-sans/1")
+	endpoints, err := c.GetManagedSanEndpoints(san)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, endpoints.Total)
+	assert.Equal(t, "host1", endpoints.Members[0].Alias)
+	assert.Equal(t, "SAN1_0", endpoints.Members[0].FabricName)
+
+	zones, err := c.GetManagedSanZones(san)
+	assert.NoError(t, err)
+	assert.Len(t, zones, 2)
+	assert.Len(t, zones["zone1"], 2)
+	assert.Equal(t, "20:00:00:00:00:00:00:01", zones["zone2"][0].WWN)
+
+	_, err = c.GetManagedSanEndpoints(utils.NewNstring(""))
+	assert.Error(t, err)
+}
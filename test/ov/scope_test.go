@@ -0,0 +1,248 @@
+package ov
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateScopeInvalidResourceUris(t *testing.T) {
+	var (
+		c *ov.OVClient
+	)
+	_, c = getTestDriverU("dev")
+	scope := ov.Scope{
+		Name: "invalid-resources",
+		Type: "ScopeV3",
+		AddedResourceUris: []utils.Nstring{
+			utils.NewNstring("/rest/ethernet-network/typo"),
+			utils.NewNstring("/rest/ethernet-networks"),
+		},
+	}
+	err := c.CreateScope(scope)
+	assert.Error(t, err, "CreateScope should fail with resources that can not be assigned to a scope")
+	if err != nil {
+		assert.Contains(t, err.Error(), "/rest/ethernet-network/typo")
+		assert.Contains(t, err.Error(), "/rest/ethernet-networks is not")
+	}
+}
+
+func TestValidateScopeResourceUrisNotFound(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/ethernet-networks/existing":
+			w.Write([]byte(`{"type": "ethernet-networkV4", "uri": "/rest/ethernet-networks/existing"}`))
+		case "/rest/ethernet-networks/unavailable":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"errorCode": "SERVICE_UNAVAILABLE", "message": "try again"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer ts.Close()
+
+	err := c.ValidateScopeResourceUris([]utils.Nstring{
+		utils.NewNstring("/rest/ethernet-networks/existing"),
+		utils.NewNstring("/rest/ethernet-networks/deleted"),
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "/rest/ethernet-networks/deleted could not be found")
+		assert.NotContains(t, err.Error(), "/rest/ethernet-networks/existing")
+	}
+
+	err = c.ValidateScopeResourceUris([]utils.Nstring{utils.NewNstring("/rest/ethernet-networks/unavailable")})
+	if assert.Error(t, err) {
+		assert.NotContains(t, err.Error(), "could not be found")
+	}
+}
+
+func TestScopeAssignableResourceTypesForVersion(t *testing.T) {
+	assert.Empty(t, ov.ScopeAssignableResourceTypesForVersion(200))
+
+	types := ov.ScopeAssignableResourceTypesForVersion(300)
+	assert.Contains(t, types, "ethernet-networks")
+	assert.NotContains(t, types, "server-profiles")
+
+	types = ov.ScopeAssignableResourceTypesForVersion(2400)
+	assert.Contains(t, types, "server-profiles")
+	assert.Contains(t, types, "hypervisor-managers")
+	assert.Equal(t, len(ov.ScopeAssignableResourceTypes), len(types))
+}
+
+func TestValidateResourceScopes(t *testing.T) {
+	var c *ov.OVClient
+	_, c = getTestDriverU("dev")
+	assert.NoError(t, c.ValidateResourceScopes(nil))
+
+	net := ov.EthernetNetwork{
+		Name:             "scoped-network",
+		InitialScopeUris: []utils.Nstring{utils.NewNstring("/rest/scope/typo")},
+	}
+	err := c.CreateEthernetNetwork(net)
+	assert.Error(t, err, "CreateEthernetNetwork should fail with a scope that does not resolve")
+	if err != nil {
+		assert.Contains(t, err.Error(), "/rest/scope/typo")
+	}
+}
+
+func TestValidateResourceScopesNotFound(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/scopes/existing":
+			w.Write([]byte(`{"type": "ScopeV3", "uri": "/rest/scopes/existing"}`))
+		case "/rest/scopes/unavailable":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"errorCode": "SERVICE_UNAVAILABLE", "message": "try again"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer ts.Close()
+
+	err := c.ValidateResourceScopes([]utils.Nstring{
+		utils.NewNstring("/rest/scopes/existing"),
+		utils.NewNstring("/rest/scopes/deleted"),
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "scopes not found: /rest/scopes/deleted")
+		assert.NotContains(t, err.Error(), "/rest/scopes/existing")
+	}
+
+	err = c.ValidateResourceScopes([]utils.Nstring{utils.NewNstring("/rest/scopes/unavailable")})
+	if assert.Error(t, err) {
+		assert.NotContains(t, err.Error(), "scopes not found")
+	}
+}
+
+func TestAddRemoveResourceToScope(t *testing.T) {
+	var patches []string
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPatch:
+			assert.Equal(t, "/rest/scopes/1/resource-assignments", r.URL.Path)
+			assert.Equal(t, "application/json; charset=utf-8", r.Header.Get("Content-Type"))
+			body, _ := ioutil.ReadAll(r.Body)
+			patches = append(patches, string(body))
+			w.Write([]byte(`{"uri":"/rest/scopes/1"}`))
+		case r.URL.Path == "/rest/scopes/resources/rest/ethernet-networks/in":
+			w.Write([]byte(`{"resourceUri":"/rest/ethernet-networks/in","scopeUris":["/rest/scopes/1"]}`))
+		case r.URL.Path == "/rest/scopes/resources/rest/ethernet-networks/out":
+			w.Write([]byte(`{"resourceUri":"/rest/ethernet-networks/out","scopeUris":[]}`))
+		}
+	})
+	defer ts.Close()
+
+	scope := utils.NewNstring("/rest/scopes/1")
+	in := utils.NewNstring("/rest/ethernet-networks/in")
+	out := utils.NewNstring("/rest/ethernet-networks/out")
+
+	assert.NoError(t, c.AddResourceToScope(scope, out))
+	assert.NoError(t, c.RemoveResourceFromScope(scope, in))
+	assert.Equal(t, 2, len(patches))
+	assert.JSONEq(t, `{"addedResourceUris":["/rest/ethernet-networks/out"]}`, patches[0])
+	assert.JSONEq(t, `{"removedResourceUris":["/rest/ethernet-networks/in"]}`, patches[1])
+
+	err := c.AddResourceToScope(scope, in)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "already in scope")
+	err = c.RemoveResourceFromScope(scope, out)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "is not in scope")
+	assert.Error(t, c.AddResourceToScope("/rest/scope/typo", out))
+	assert.Error(t, c.AddResourceToScope(scope, "/rest/ethernet-networks"))
+	assert.Equal(t, 2, len(patches))
+}
+
+func TestAddResourcesToScope(t *testing.T) {
+	var batches [][]utils.Nstring
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		var assignments ov.ScopeResourceAssignments
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.NoError(t, json.Unmarshal(body, &assignments))
+		batches = append(batches, assignments.AddedResourceUris)
+		if len(batches) == 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errorCode":"INVALID_RESOURCE","message":"rejected"}`))
+			return
+		}
+		w.Write([]byte(`{"uri":"/rest/scopes/1"}`))
+	})
+	defer ts.Close()
+
+	c.ScopeAssignmentBatchSize = 2
+	var uris []utils.Nstring
+	for i := 0; i < 5; i++ {
+		uris = append(uris, utils.NewNstring(fmt.Sprintf("/rest/ethernet-networks/%d", i)))
+	}
+
+	err := c.AddResourcesToScope(utils.NewNstring("/rest/scopes/1"), uris)
+	assert.Equal(t, 3, len(batches))
+	assert.Equal(t, []utils.Nstring{uris[0], uris[1]}, batches[0])
+	assert.Equal(t, []utils.Nstring{uris[4]}, batches[2])
+	var assignErr *ov.ScopeAssignmentError
+	if assert.True(t, errors.As(err, &assignErr)) {
+		assert.Equal(t, []utils.Nstring{uris[0], uris[1], uris[4]}, assignErr.Succeeded)
+		assert.Equal(t, []utils.Nstring{uris[2], uris[3]}, assignErr.Failed)
+	}
+}
+
+func TestGetScopesWithOptions(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, "name='prod'", q.Get("filter"))
+		assert.Equal(t, "name:asc", q.Get("sort"))
+		assert.Equal(t, "10", q.Get("count"))
+		assert.Equal(t, "20", q.Get("start"))
+		w.Write([]byte(`{"total":1,"count":1,"members":[{"name":"prod","uri":"/rest/scopes/1"}]}`))
+	})
+	defer ts.Close()
+
+	scopes, err := c.GetScopesWithOptions(ov.ScopeQueryOptions{Filter: "name='prod'", Sort: "name:asc", Count: 10, Start: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, "prod", scopes.Members[0].Name)
+
+	_, err = c.GetScopesWithOptions(ov.ScopeQueryOptions{Count: -1})
+	assert.Error(t, err)
+	_, err = c.GetScopesWithOptions(ov.ScopeQueryOptions{Start: -1})
+	assert.Error(t, err)
+	_, err = c.GetScopes("ten", "", "", "", "")
+	assert.Error(t, err)
+}
+
+func TestGetAllScopes(t *testing.T) {
+	var filters []string
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/scopes" {
+			http.NotFound(w, r)
+			return
+		}
+		filters = append(filters, r.URL.Query().Get("filter"))
+		switch r.URL.Query().Get("start") {
+		case "":
+			assert.Equal(t, "2", r.URL.Query().Get("count"))
+			w.Write([]byte(`{"total":3,"count":2,"nextPageUri":"/rest/scopes?start=2&count=2",
+				"members":[{"name":"dev","uri":"/rest/scopes/1"},{"name":"prod","uri":"/rest/scopes/2"}]}`))
+		case "2":
+			w.Write([]byte(`{"total":3,"count":1,"start":2,"members":[{"name":"test","uri":"/rest/scopes/3"}]}`))
+		}
+	})
+	defer ts.Close()
+
+	scopes, err := c.GetAllScopes(ov.ScopeQueryOptions{Filter: "name matches '%'", Sort: "name:asc", Count: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, scopes.Total)
+	if assert.Equal(t, 3, len(scopes.Members)) {
+		assert.Equal(t, "test", scopes.Members[2].Name)
+	}
+	assert.Equal(t, []string{"name matches '%'", "name matches '%'"}, filters, "every page keeps the filter")
+
+	_, err = c.GetAllScopes(ov.ScopeQueryOptions{Count: -1})
+	assert.Error(t, err)
+}
@@ -0,0 +1,38 @@
+package ov
+
+import (
+	"os"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerHardwareEnvironmentalConfiguration(t *testing.T) {
+	var (
+		d       *OVTest
+		c       *ov.OVClient
+		testURI utils.Nstring
+	)
+	if os.Getenv("ONEVIEW_TEST_ACCEPTANCE") == "true" {
+		d, c = getTestDriverA("dev")
+		if c == nil {
+			t.Fatalf("Failed to execute getTestDriver() ")
+		}
+		testURI = utils.NewNstring(d.Tc.GetTestData(d.Env, "ServerHardwareURI").(string))
+
+		envConfig, err := c.GetServerHardwareEnvironmentalConfiguration(testURI)
+		assert.NoError(t, err, "GetServerHardwareEnvironmentalConfiguration threw error -> %s", err)
+
+		updated, err := c.SetServerHardwareEnvironmentalConfiguration(testURI, envConfig)
+		assert.NoError(t, err, "SetServerHardwareEnvironmentalConfiguration threw error -> %s", err)
+		assert.Equal(t, envConfig.CalibratedMaxPower, updated.CalibratedMaxPower)
+	} else {
+		_, c = getTestDriverU("dev")
+		_, err := c.GetServerHardwareEnvironmentalConfiguration(utils.NewNstring(""))
+		assert.Error(t, err, "GetServerHardwareEnvironmentalConfiguration should fail without a uri")
+		_, err = c.SetServerHardwareEnvironmentalConfiguration(utils.NewNstring("/rest/server-hardware/fake"), ov.ServerHardwareEnvironmentalConfiguration{})
+		assert.Error(t, err, "SetServerHardwareEnvironmentalConfiguration should fail without a calibrated max power")
+	}
+}
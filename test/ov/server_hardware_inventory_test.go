@@ -0,0 +1,84 @@
+package ov
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalServerHardwareInventory(t *testing.T) {
+	records := []ov.ServerHardwareInventoryRecord{
+		{Name: "enc1, bay 1", SerialNumber: "SN1", Model: "Synergy 480 Gen10", ServerHardwareType: "SY 480 Gen10 1", Enclosure: "enc1", Bay: 1, PowerState: "On", ServerProfile: "web-1"},
+		{Name: "rack-dl360", SerialNumber: "SN2", Model: "ProLiant DL360 Gen10", ServerHardwareType: "DL360 Gen10 1", PowerState: "Off"},
+	}
+
+	data, err := ov.MarshalServerHardwareInventory(records, "csv")
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Equal(t, 3, len(lines))
+	assert.Equal(t, "name,serialNumber,model,serverHardwareType,enclosure,bay,powerState,serverProfile", lines[0])
+	assert.Equal(t, "\"enc1, bay 1\",SN1,Synergy 480 Gen10,SY 480 Gen10 1,enc1,1,On,web-1", lines[1])
+	assert.Equal(t, "rack-dl360,SN2,ProLiant DL360 Gen10,DL360 Gen10 1,,,Off,", lines[2])
+
+	data, err = ov.MarshalServerHardwareInventory(records, "JSON")
+	assert.NoError(t, err)
+	var decoded []ov.ServerHardwareInventoryRecord
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, records, decoded)
+
+	data, err = ov.MarshalServerHardwareInventory(nil, "json")
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", string(data))
+
+	_, err = ov.MarshalServerHardwareInventory(records, "xml")
+	assert.Error(t, err)
+}
+
+func TestExportServerHardwareInventory(t *testing.T) {
+	var c *ov.OVClient
+	if os.Getenv("ONEVIEW_TEST_ACCEPTANCE") == "true" {
+		_, c = getTestDriverA("dev")
+		if c == nil {
+			t.Fatalf("Failed to execute getTestDriver() ")
+		}
+		data, err := c.ExportServerHardwareInventory("csv")
+		assert.NoError(t, err, "ExportServerHardwareInventory threw error -> %s", err)
+		assert.True(t, strings.HasPrefix(string(data), "name,"))
+	} else {
+		_, c = getTestDriverU("dev")
+		_, err := c.ExportServerHardwareInventory("xml")
+		assert.Error(t, err, "ExportServerHardwareInventory should refuse unknown formats")
+		_, err = c.ExportServerHardwareInventory("json")
+		assert.Error(t, err, "ExportServerHardwareInventory should fail without a session")
+	}
+}
+
+func TestGetServerHardwareInventoryProfilePages(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/server-hardware":
+			w.Write([]byte(`{"total": 1, "count": 1, "members": [{"name": "rack1", "serverProfileUri": "/rest/server-profiles/3"}]}`))
+		case "/rest/server-profiles":
+			if r.URL.Query().Get("start") == "" {
+				w.Write([]byte(`{"total": 3, "count": 2, "nextPageUri": "/rest/server-profiles?start=2&count=2",
+					"members": [{"name": "p1", "uri": "/rest/server-profiles/1"}, {"name": "p2", "uri": "/rest/server-profiles/2"}]}`))
+				return
+			}
+			w.Write([]byte(`{"total": 3, "count": 1, "start": 2, "members": [{"name": "p3", "uri": "/rest/server-profiles/3"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer ts.Close()
+
+	records, err := c.GetServerHardwareInventory()
+	assert.NoError(t, err)
+	if assert.Equal(t, 1, len(records)) {
+		assert.Equal(t, "p3", records[0].ServerProfile, "the profile on the last page should be resolved")
+	}
+}
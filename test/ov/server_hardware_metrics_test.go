@@ -0,0 +1,64 @@
+package ov
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamServerHardwareMetrics(t *testing.T) {
+	var (
+		c *ov.OVClient
+	)
+	_, c = getTestDriverU("dev")
+
+	_, err := c.StreamServerHardwareMetrics(context.Background(), []string{""}, nil, time.Minute)
+	assert.Error(t, err, "StreamServerHardwareMetrics should fail without a server hardware uri")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	samples, err := c.StreamServerHardwareMetrics(ctx, []string{"/rest/server-hardware/fake"}, []string{"CpuUtilization"}, time.Minute)
+	assert.NoError(t, err, "StreamServerHardwareMetrics threw error -> %s", err)
+
+	// without an appliance the poll reports an error sample
+	sample := <-samples
+	assert.Error(t, sample.Err)
+	cancel()
+
+	select {
+	case _, ok := <-samples:
+		assert.False(t, ok, "the channel should be closed once the context is canceled")
+	case <-time.After(5 * time.Second):
+		t.Fatalf("the channel was not closed after the context was canceled")
+	}
+}
+
+func TestServerHardwareListAndUtilization(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/server-hardware":
+			assert.Equal(t, "name:asc", r.URL.Query().Get("sort"), "the sort is kept when fetching every member")
+			w.Write([]byte(`{"total":1,"count":1,"members":[{"name":"bay 1","mpModel":"iLO5","powerState":"On","status":"OK","uri":"/rest/server-hardware/1"}]}`))
+		case "/rest/server-hardware/1/utilization":
+			assert.Equal(t, "CpuUtilization", r.URL.Query().Get("fields"))
+			w.Write([]byte(`{"metricList":[{"metricName":"CpuUtilization","metricSamples":[[1441036118675,12]]}]}`))
+		}
+	})
+	defer ts.Close()
+
+	list, err := c.GetServerHardwareList(nil, "name:asc", "", "", "")
+	assert.NoError(t, err)
+	hardware := list.Members[0]
+	assert.Equal(t, "iLO5", hardware.MpModel)
+	assert.Equal(t, "OK", hardware.Status)
+
+	utilization, err := hardware.GetUtilization([]string{"CpuUtilization"})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(12), utilization.MetricList[0].MetricSamples[0][1])
+
+	_, err = ov.ServerHardware{}.GetUtilization(nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,90 @@
+package ov
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReserveServerHardware(t *testing.T) {
+	var (
+		d       *OVTest
+		c       *ov.OVClient
+		testURI string
+	)
+	if os.Getenv("ONEVIEW_TEST_ACCEPTANCE") == "true" {
+		d, c = getTestDriverA("dev")
+		if c == nil {
+			t.Fatalf("Failed to execute getTestDriver() ")
+		}
+		testURI = d.Tc.GetTestData(d.Env, "ServerHardwareURI").(string)
+
+		err := c.ReserveServerHardware(testURI, "acceptance-a")
+		if err != nil {
+			t.Skipf("Server hardware cannot be reserved, skipping: %s", err)
+		}
+		err = c.ReserveServerHardware(testURI, "acceptance-b")
+		assert.Error(t, err, "ReserveServerHardware should fail for a second reservation id")
+
+		owner, err := c.GetServerHardwareReservation(testURI)
+		assert.NoError(t, err, "GetServerHardwareReservation threw error -> %s", err)
+		assert.Equal(t, "acceptance-a", owner)
+
+		err = c.ReleaseServerHardware(testURI)
+		assert.NoError(t, err, "ReleaseServerHardware threw error -> %s", err)
+	} else {
+		_, c = getTestDriverU("dev")
+		err := c.ReserveServerHardware("", "unit")
+		assert.Error(t, err, "ReserveServerHardware should fail without a uri")
+		err = c.ReserveServerHardware("/rest/server-hardware/fake", "")
+		assert.Error(t, err, "ReserveServerHardware should fail without a reservation id")
+		err = c.ReleaseServerHardware("")
+		assert.Error(t, err, "ReleaseServerHardware should fail without a uri")
+	}
+}
+
+func TestReserveServerHardwarePerClient(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		labels []ov.Label
+	)
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.URL.Path == "/rest/server-hardware/1":
+			w.Write([]byte(`{"name": "bay1", "uri": "/rest/server-hardware/1"}`))
+		case strings.HasPrefix(r.URL.Path, "/rest/labels/resources") && r.Method == http.MethodPut:
+			var assigned ov.AssignedLabel
+			json.NewDecoder(r.Body).Decode(&assigned)
+			labels = assigned.Labels
+			json.NewEncoder(w).Encode(assigned)
+		case strings.HasPrefix(r.URL.Path, "/rest/labels/resources"):
+			json.NewEncoder(w).Encode(ov.AssignedLabel{Labels: labels})
+		default:
+			http.NotFound(w, r)
+		}
+	}
+	tsA, a := getMockDriver(handler)
+	defer tsA.Close()
+	tsB, b := getMockDriver(handler)
+	defer tsB.Close()
+
+	assert.NoError(t, a.ReserveServerHardware("/rest/server-hardware/1", "a"))
+	assert.Error(t, b.ReserveServerHardware("/rest/server-hardware/1", "b"), "the label reserves the hardware for every client")
+
+	// released by another process, the registry of a does not hold b back
+	mu.Lock()
+	labels = nil
+	mu.Unlock()
+	assert.NoError(t, b.ReserveServerHardware("/rest/server-hardware/1", "b"))
+	owner, err := b.GetServerHardwareReservation("/rest/server-hardware/1")
+	assert.NoError(t, err)
+	assert.Equal(t, "b", owner)
+}
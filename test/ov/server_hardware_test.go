@@ -222,3 +222,62 @@ func TestDeleteServerHardware(t *testing.T) {
 		assert.Error(t, err, fmt.Sprintf("All ok, no error, caught as expected: %s,%+v\n", err, testSH))
 	}
 }
+
+func TestSetServerHardwareOneTimeBoot(t *testing.T) {
+	var (
+		d       *OVTest
+		c       *ov.OVClient
+		testURI string
+	)
+	if os.Getenv("ONEVIEW_TEST_ACCEPTANCE") == "true" {
+		d, c = getTestDriverA("dev")
+		if c == nil {
+			t.Fatalf("Failed to execute getTestDriver() ")
+		}
+		testURI = d.Tc.GetTestData(d.Env, "ServerHardwareURI").(string)
+
+		task, err := c.SetServerHardwareOneTimeBoot(testURI, "PXE")
+		assert.NoError(t, err, "SetServerHardwareOneTimeBoot threw error -> %s", err)
+		err = task.Wait()
+		assert.NoError(t, err, "SetServerHardwareOneTimeBoot task failed -> %s", err)
+
+		task, err = c.SetServerHardwareOneTimeBoot(testURI, "Normal")
+		assert.NoError(t, err, "SetServerHardwareOneTimeBoot threw error -> %s", err)
+		err = task.Wait()
+		assert.NoError(t, err, "SetServerHardwareOneTimeBoot task failed -> %s", err)
+	} else {
+		_, c = getTestDriverU("dev")
+		_, err := c.SetServerHardwareOneTimeBoot("/rest/server-hardware/fake", "Tape")
+		assert.Error(t, err, "SetServerHardwareOneTimeBoot should fail with an unknown device")
+	}
+}
+
+func TestSetServerHardwareMaintenanceMode(t *testing.T) {
+	var (
+		d       *OVTest
+		c       *ov.OVClient
+		testURI string
+	)
+	if os.Getenv("ONEVIEW_TEST_ACCEPTANCE") == "true" {
+		d, c = getTestDriverA("dev")
+		if c == nil {
+			t.Fatalf("Failed to execute getTestDriver() ")
+		}
+		testURI = d.Tc.GetTestData(d.Env, "ServerHardwareURI").(string)
+
+		for _, enabled := range []bool{true, false} {
+			task, err := c.SetServerHardwareMaintenanceMode(testURI, enabled)
+			assert.NoError(t, err, "SetServerHardwareMaintenanceMode threw error -> %s", err)
+			if task != nil {
+				err = task.Wait()
+				assert.NoError(t, err, "SetServerHardwareMaintenanceMode task failed -> %s", err)
+			}
+		}
+	} else {
+		_, c = getTestDriverU("dev")
+		_, err := c.SetServerHardwareMaintenanceMode("", true)
+		assert.Error(t, err, "SetServerHardwareMaintenanceMode should fail without a uri")
+		_, err = c.SetServerHardwareMaintenanceMode("/rest/server-hardware/fake", true)
+		assert.Error(t, err, "SetServerHardwareMaintenanceMode should fail without a session")
+	}
+}
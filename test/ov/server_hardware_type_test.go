@@ -58,3 +58,31 @@ func TestGetServerHardwareTypes(t *testing.T) {
 		assert.Error(t, err, fmt.Sprintf("ALL ok, no error, caught as expected: %s,%+v\n", err, data))
 	}
 }
+
+func TestValidateBiosSettings(t *testing.T) {
+	sht := ov.ServerHardwareType{
+		Name: "DL360 Gen10",
+		BiosSettings: []ov.BiosSetting{
+			{ID: "WorkloadProfile", Name: "Workload Profile", Options: []ov.Option{{ID: "GeneralPowerEfficientCompute"}, {ID: "Virtualization-MaxPerformance"}}},
+			{ID: "PowerOnDelay", Name: "Power-On Delay", LowerBound: 0, UpperBound: 120},
+			{ID: "ServerAssetTag", Name: "Server Asset Tag"},
+		},
+	}
+	assert.NoError(t, ov.ValidateBiosSettings(sht, []ov.BiosSettings{
+		{ID: "WorkloadProfile", Value: "Virtualization-MaxPerformance"},
+		{ID: "PowerOnDelay", Value: "30"},
+		{ID: "ServerAssetTag", Value: "rack-12"},
+	}))
+
+	err := ov.ValidateBiosSettings(sht, []ov.BiosSettings{
+		{ID: "WorkloadProfile", Value: "Fastest"},
+		{ID: "PowerOnDelay", Value: "600"},
+		{ID: "NoSuchSetting", Value: "Enabled"},
+	})
+	assert.Error(t, err)
+	if err != nil {
+		assert.Contains(t, err.Error(), "Fastest")
+		assert.Contains(t, err.Error(), "between 0 and 120")
+		assert.Contains(t, err.Error(), `"NoSuchSetting" is not available for server hardware type DL360 Gen10`)
+	}
+}
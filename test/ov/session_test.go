@@ -0,0 +1,126 @@
+package ov
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/rest"
+	"github.com/stretchr/testify/assert"
+)
+
+// getSessionDriver returns a client for a fake appliance accepting the session id valid,
+// checks and logins are counted
+func getSessionDriver(valid *string, checks, logins *int) (*httptest.Server, *ov.OVClient) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/login-sessions":
+			*logins++
+			*valid = "renewed"
+			w.Write([]byte(`{"sessionID": "renewed"}`))
+		case "/rest/sessions/idle-timeout":
+			*checks++
+			if r.Header.Get("Session-ID") != *valid {
+				http.NotFound(w, r)
+				return
+			}
+			w.Write([]byte(`{"idleTimeout": 1800000}`))
+		default:
+			if r.Header.Get("auth") != *valid {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"members": []}`))
+		}
+	}))
+	c := &ov.OVClient{Client: rest.Client{User: "foo", Password: "bar", Endpoint: ts.URL, APIVersion: 2400, APIKey: "session"}}
+	return ts, c
+}
+
+func TestRefreshLoginReusesSession(t *testing.T) {
+	valid, checks, logins := "session", 0, 0
+	ts, c := getSessionDriver(&valid, &checks, &logins)
+	defer ts.Close()
+
+	for i := 0; i < 3; i++ {
+		_, err := c.GetProfiles("", "", "", "", "")
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, checks)
+	assert.Equal(t, 0, logins)
+	token, expiry := c.GetSessionToken()
+	assert.Equal(t, "session", token)
+	assert.WithinDuration(t, time.Now().Add(30*time.Minute), expiry, time.Minute)
+
+	// the appliance drops the session, the 401 has the next call check and renew it
+	valid = "other"
+	_, err := c.GetProfiles("", "", "", "", "")
+	assert.True(t, rest.IsUnauthorized(err))
+	_, err = c.GetProfiles("", "", "", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, logins)
+	token, _ = c.GetSessionToken()
+	assert.Equal(t, "renewed", token)
+}
+
+func TestSetSessionToken(t *testing.T) {
+	valid, checks, logins := "saved", 0, 0
+	ts, c := getSessionDriver(&valid, &checks, &logins)
+	defer ts.Close()
+
+	c.SetSessionToken("saved", time.Now().Add(time.Hour))
+	_, err := c.GetProfiles("", "", "", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, checks)
+	assert.Equal(t, 0, logins)
+
+	// a session close to its expiry is checked first
+	c.SetSessionToken("saved", time.Now().Add(time.Second))
+	_, err = c.GetProfiles("", "", "", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, checks)
+	assert.Equal(t, 0, logins)
+}
+
+func TestLoginLogout(t *testing.T) {
+	var loggedIn string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/login-sessions" && r.Method == http.MethodPost:
+			loggedIn = "new"
+			w.Write([]byte(`{"sessionID": "new"}`))
+		case r.URL.Path == "/rest/login-sessions" && r.Method == http.MethodDelete:
+			if r.Header.Get("auth") != loggedIn {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			loggedIn = ""
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/rest/sessions/idle-timeout":
+			w.Write([]byte(`{"idleTimeout": 1800000}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+	c := &ov.OVClient{Client: rest.Client{User: "foo", Password: "bar", Endpoint: ts.URL, APIVersion: 2400, APIKey: "none"}}
+
+	assert.NoError(t, c.Login())
+	token, expiry := c.GetSessionToken()
+	assert.Equal(t, "new", token)
+	assert.False(t, expiry.IsZero())
+
+	assert.NoError(t, c.Logout())
+	assert.Equal(t, "", loggedIn)
+	token, _ = c.GetSessionToken()
+	assert.Equal(t, "none", token)
+	assert.NoError(t, c.Logout())
+
+	// a session dropped by the appliance is logged out
+	c.SetSessionToken("dropped", time.Time{})
+	assert.NoError(t, c.Logout())
+	token, _ = c.GetSessionToken()
+	assert.Equal(t, "none", token)
+}
@@ -0,0 +1,42 @@
+package ov
+
+import (
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSNMPv3UserValidate(t *testing.T) {
+	user := ov.SNMPv3User{UserName: "nms", SecurityLevel: ov.SNMP_SECURITY_NONE}
+	assert.NoError(t, user.Validate())
+
+	user.SecurityLevel = ov.SNMP_SECURITY_AUTH
+	assert.Error(t, user.Validate())
+	user.AuthenticationProtocol, user.AuthenticationPassphrase = "SHA512", "authpass"
+	assert.NoError(t, user.Validate())
+
+	user.SecurityLevel = ov.SNMP_SECURITY_AUTH_PRIVACY
+	assert.Error(t, user.Validate())
+	user.PrivacyProtocol, user.PrivacyPassphrase = "AES128", "privpass"
+	assert.NoError(t, user.Validate())
+
+	user.SecurityLevel = "Privacy"
+	assert.Error(t, user.Validate())
+	assert.Error(t, ov.SNMPv3User{}.Validate())
+}
+
+func TestSnmpConfigurationValidate(t *testing.T) {
+	var config *ov.SnmpConfiguration
+	assert.NoError(t, config.Validate())
+
+	config = &ov.SnmpConfiguration{SnmpUsers: []ov.Snmpv3User{{SnmpV3UserName: "nms", V3AuthProtocol: "SHA", V3PrivacyProtocol: "AES"}}}
+	assert.NoError(t, config.Validate())
+	config.SnmpUsers = append(config.SnmpUsers, ov.Snmpv3User{SnmpV3UserName: "noauth", V3PrivacyProtocol: "AES"})
+	assert.Error(t, config.Validate())
+
+	c := &ov.OVClient{}
+	assert.Error(t, c.CreateLogicalInterconnectGroup(ov.LogicalInterconnectGroup{Name: "lig", SnmpConfiguration: config}))
+	_, err := c.CreateSNMPv3Users(ov.SNMPv3User{UserName: "nms", SecurityLevel: ov.SNMP_SECURITY_AUTH})
+	assert.Error(t, err)
+}
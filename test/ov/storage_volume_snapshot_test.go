@@ -0,0 +1,48 @@
+package ov
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageVolumeSnapshots(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/storage-volumes/1/snapshots":
+			if r.Method == http.MethodPost {
+				var snap ov.Snapshot
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&snap))
+				assert.Equal(t, "snap2", snap.Name)
+				w.Write([]byte(`{"taskState":"Running","uri":"/rest/tasks/1"}`))
+				return
+			}
+			w.Write([]byte(`{"total":1,"count":1,"members":[{"name":"snap1","uri":"/rest/storage-volumes/1/snapshots/1"}]}`))
+		case "/rest/storage-volumes/1/snapshots/1":
+			assert.Equal(t, http.MethodDelete, r.Method)
+			w.Write([]byte(`{"taskState":"Running","uri":"/rest/tasks/2"}`))
+		}
+	})
+	defer ts.Close()
+
+	volume := utils.NewNstring("/rest/storage-volumes/1")
+	snapshots, err := c.GetStorageVolumeSnapshots(volume)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, snapshots.Total)
+	assert.Equal(t, "snap1", snapshots.Members[0].Name)
+
+	task, err := c.CreateStorageVolumeSnapshot(volume, ov.Snapshot{Name: "snap2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/tasks/1", task.URI.String())
+
+	task, err = c.DeleteStorageVolumeSnapshot(snapshots.Members[0].URI)
+	assert.NoError(t, err)
+	assert.Equal(t, "/rest/tasks/2", task.URI.String())
+
+	_, err = c.CreateStorageVolumeSnapshot(utils.NewNstring(""), ov.Snapshot{})
+	assert.Error(t, err)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/HewlettPackard/oneview-golang/utils"
 	"github.com/docker/machine/libmachine/log"
 	"github.com/stretchr/testify/assert"
+	"net/http"
 	"os"
 	"testing"
 )
@@ -160,3 +161,30 @@ func TestDeleteStorageVolume(t *testing.T) {
 		assert.Error(t, err, fmt.Sprintf("ALL ok, no error, caught as expected: %s,%+v\n", err, testSVol))
 	}
 }
+
+func TestDeleteStorageVolumeWithOptions(t *testing.T) {
+	for _, exportOnly := range []bool{true, false} {
+		deleted := false
+		ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/rest/storage-volumes":
+				w.Write([]byte(`{"total":1,"count":1,"members":[{"name":"vol1","uri":"/rest/storage-volumes/1"}]}`))
+			case "/rest/storage-volumes/1":
+				assert.Equal(t, http.MethodDelete, r.Method)
+				if exportOnly {
+					assert.Equal(t, "true", r.URL.Query().Get("suppressDeviceUpdates"))
+				} else {
+					assert.Equal(t, "", r.URL.Query().Get("suppressDeviceUpdates"))
+				}
+				deleted = true
+				w.Write([]byte(`{"taskState":"Running","uri":"/rest/tasks/1"}`))
+			case "/rest/tasks/1":
+				w.Write([]byte(`{"taskState":"Completed","uri":"/rest/tasks/1"}`))
+			}
+		})
+
+		assert.NoError(t, c.DeleteStorageVolumeWithOptions("vol1", exportOnly))
+		assert.True(t, deleted)
+		ts.Close()
+	}
+}
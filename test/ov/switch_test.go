@@ -0,0 +1,58 @@
+package ov
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSwitches(t *testing.T) {
+	var (
+		c *ov.OVClient
+	)
+	if os.Getenv("ONEVIEW_TEST_ACCEPTANCE") == "true" {
+		_, c = getTestDriverA("dev")
+		if c == nil {
+			t.Fatalf("Failed to execute getTestDriver() ")
+		}
+		switches, err := c.GetSwitches("", "", "", "name:asc")
+		assert.NoError(t, err, "GetSwitches threw error -> %s, %+v\n", err, switches)
+
+		if len(switches.Members) > 0 {
+			sw, err := c.GetSwitchByName(switches.Members[0].Name)
+			assert.NoError(t, err, "GetSwitchByName threw error -> %s", err)
+			assert.Equal(t, switches.Members[0].URI, sw.URI)
+
+			_, err = c.GetSwitchStatistics(sw.URI)
+			assert.NoError(t, err, "GetSwitchStatistics threw error -> %s", err)
+		}
+	} else {
+		_, c = getTestDriverU("dev")
+		data, err := c.GetSwitches("", "", "", "")
+		assert.Error(t, err, fmt.Sprintf("ALL ok, no error, caught as expected: %s,%+v\n", err, data))
+
+		_, err = c.GetSwitchStatistics(utils.NewNstring(""))
+		assert.Error(t, err, "GetSwitchStatistics should fail without a uri")
+		_, err = c.GetSwitchPortStatistics(utils.NewNstring("/rest/switches/fake"), "")
+		assert.Error(t, err, "GetSwitchPortStatistics should fail without a port name")
+	}
+}
+
+func TestSwitchPortStatisticsEscapedPortName(t *testing.T) {
+	var path string
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath()
+		w.Write([]byte(`{"portName":"1/1","portStatus":"Linked"}`))
+	})
+	defer ts.Close()
+
+	stats, err := c.GetSwitchPortStatistics(utils.NewNstring("/rest/switches/1"), "1/1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1/1", stats.PortName)
+	assert.Equal(t, "/rest/switches/1/statistics/1%2F1", path, "the port name is escaped once")
+}
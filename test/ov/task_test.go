@@ -4,8 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/utils"
 	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
 	"testing"
+	"time"
 )
 
 // test unmarshalling a json payload that has progress
@@ -47,3 +51,151 @@ func TestProgressUpdatesTaskJsonUnmarshal(t *testing.T) {
 	err := json.Unmarshal([]byte(test_json_data), &task)
 	assert.NoError(t, err, fmt.Sprintf("Failed to unmarshal task object: %s, %+v\n", err, task))
 }
+
+func TestIsTaskError(t *testing.T) {
+	var err error = &ov.TaskFailedError{TaskErrors: []ov.TaskError{{
+		ErrorCode:          "MacTypeDiffGlobalMacType",
+		Message:            "When macType is not user defined, mac type should be same as the global Mac assignment Virtual.",
+		RecommendedActions: []string{"Verify parameters and try again."},
+	}}}
+	assert.True(t, ov.IsTaskError(err))
+	assert.Contains(t, err.Error(), "Verify parameters and try again.")
+	assert.False(t, ov.IsTaskError(fmt.Errorf("plain error")))
+}
+
+func TestTaskWaitWithTimeout(t *testing.T) {
+	var polls int
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		if polls < 3 {
+			fmt.Fprintf(w, `{"taskState":"Running","computedPercentComplete":%d,"uri":"/rest/tasks/1"}`, polls*40)
+			return
+		}
+		w.Write([]byte(`{"taskState":"Completed","computedPercentComplete":100,"uri":"/rest/tasks/1"}`))
+	})
+	defer ts.Close()
+
+	task := (&ov.Task{}).NewProfileTask(c)
+	task.URI = "/rest/tasks/1"
+	task.PollInterval = 10 * time.Millisecond
+	progress := task.Progress()
+	var seen []int
+	done := make(chan struct{})
+	go func() {
+		for p := range progress {
+			seen = append(seen, p)
+		}
+		close(done)
+	}()
+	assert.NoError(t, task.WaitWithTimeout(time.Second))
+	<-done
+	assert.True(t, task.TaskIsDone)
+	assert.Equal(t, 3, polls)
+	assert.Equal(t, 100, seen[len(seen)-1])
+
+	// asking for progress after the wait finished gets a closed channel
+	select {
+	case _, ok := <-task.Progress():
+		assert.False(t, ok, "Progress should be closed once the task is done")
+	case <-time.After(time.Second):
+		t.Error("Progress should not block once the task is done")
+	}
+	assert.NoError(t, task.Wait())
+
+	// a task that never completes
+	ts, c = getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"taskState":"Running","computedPercentComplete":10,"uri":"/rest/tasks/2"}`))
+	})
+	defer ts.Close()
+	task = (&ov.Task{}).NewProfileTask(c)
+	task.URI = "/rest/tasks/2"
+	task.PollInterval = 10 * time.Millisecond
+	assert.Equal(t, ov.ErrTaskTimeout, task.WaitWithTimeout(50*time.Millisecond))
+	assert.False(t, task.TaskIsDone)
+}
+
+func TestTaskErrorDetails(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("filter") {
+		case "parentTaskUri='/rest/tasks/1'":
+			w.Write([]byte(`{"members":[{"uri":"/rest/tasks/2","taskState":"Error","taskErrors":[{"errorCode":"ChildError","message":"child failed"}]}]}`))
+		default:
+			w.Write([]byte(`{"members":[]}`))
+		}
+	})
+	defer ts.Close()
+
+	task := (&ov.Task{}).NewProfileTask(c)
+	task.URI = "/rest/tasks/1"
+	task.TaskErrors = []ov.TaskError{{
+		ErrorCode:          "ProfileError",
+		Message:            "profile failed",
+		RecommendedActions: []string{"Verify parameters and try again."},
+		NestedErrors:       []ov.TaskError{{ErrorCode: "NestedError", Message: "nested failed"}},
+	}}
+	details := task.TaskErrorDetails()
+	assert.Equal(t, 3, len(details))
+	assert.Equal(t, "ProfileError", details[0].ErrorCode)
+	assert.Equal(t, []string{"Verify parameters and try again."}, details[0].RecommendedActions)
+	assert.Nil(t, details[0].NestedErrors)
+	assert.Equal(t, "NestedError", details[1].ErrorCode)
+	assert.Equal(t, "ChildError", details[2].ErrorCode)
+}
+
+func TestGetTasksByResource(t *testing.T) {
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/tasks", r.URL.Path)
+		assert.Equal(t, "associatedResource.resourceUri='/rest/server-profiles/1'", r.URL.Query().Get("filter"))
+		assert.Equal(t, "created:descending", r.URL.Query().Get("sort"))
+		w.Write([]byte(`{"total":2,"count":2,"members":[
+			{"name":"Update","owner":"admin","taskState":"Running","percentComplete":40,"completedSteps":2,"uri":"/rest/tasks/2"},
+			{"name":"Create","owner":"admin","taskState":"Error","percentComplete":100,"completedSteps":5,"uri":"/rest/tasks/1"}]}`))
+	})
+	defer ts.Close()
+
+	tasks, err := c.GetTasksByResource(utils.NewNstring("/rest/server-profiles/1"))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, tasks.Count)
+	assert.Equal(t, "admin", tasks.Members[0].Owner)
+	assert.Equal(t, "Running", tasks.Members[0].TaskState)
+	assert.Equal(t, 40, tasks.Members[0].PercentComplete)
+	assert.Equal(t, 5, tasks.Members[1].CompletedSteps)
+
+	_, err = c.GetTasksByResource("")
+	assert.Error(t, err)
+}
+
+func TestCancelTask(t *testing.T) {
+	var patched bool
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPatch:
+			patched = true
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `[{"op":"replace","path":"/taskState","value":"Cancelling"}]`, string(body))
+			w.Write([]byte(`{"taskState":"Cancelling","isCancellable":true,"uri":"/rest/tasks/1"}`))
+		case r.URL.Path == "/rest/tasks/1":
+			w.Write([]byte(`{"taskState":"Running","isCancellable":true,"uri":"/rest/tasks/1"}`))
+		case r.URL.Path == "/rest/tasks/2":
+			w.Write([]byte(`{"taskState":"Completed","isCancellable":true,"uri":"/rest/tasks/2"}`))
+		case r.URL.Path == "/rest/tasks/3":
+			w.Write([]byte(`{"taskState":"Running","isCancellable":false,"uri":"/rest/tasks/3"}`))
+		}
+	})
+	defer ts.Close()
+
+	task := &ov.Task{URI: "/rest/tasks/1"}
+	assert.NoError(t, c.CancelTask(task))
+	assert.True(t, patched)
+	assert.Equal(t, "Cancelling", task.TaskState)
+
+	patched = false
+	task = &ov.Task{URI: "/rest/tasks/2"}
+	assert.NoError(t, c.CancelTask(task))
+	assert.False(t, patched)
+	assert.True(t, task.TaskIsDone)
+
+	task = &ov.Task{URI: "/rest/tasks/3"}
+	assert.Error(t, c.CancelTask(task))
+	assert.False(t, patched)
+}
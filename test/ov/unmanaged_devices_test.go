@@ -0,0 +1,54 @@
+package ov
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmanagedDevices(t *testing.T) {
+	var (
+		requests []string
+		created  ov.UnmanagedDevice
+	)
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /rest/unmanaged-devices":
+			w.Write([]byte(`{"total": 1, "count": 1, "members": [{"name": "core-switch", "uri": "/rest/unmanaged-devices/1",
+				"model": "Procurve 4200VL", "height": 2, "maxPwrConsumed": 600, "mac": "00:11:22:33:44:55"}]}`))
+		case "POST /rest/unmanaged-devices":
+			json.NewDecoder(r.Body).Decode(&created)
+			w.WriteHeader(http.StatusCreated)
+		case "DELETE /rest/unmanaged-devices":
+			w.Write([]byte(`{"uri": "/rest/tasks/1", "taskState": "Completed", "percentComplete": 100}`))
+		case "GET /rest/tasks/1":
+			w.Write([]byte(`{"uri": "/rest/tasks/1", "taskState": "Completed", "percentComplete": 100}`))
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	})
+	defer ts.Close()
+
+	device, err := c.GetUnmanagedDeviceByName("core-switch")
+	assert.NoError(t, err)
+	assert.Equal(t, "Procurve 4200VL", device.Model)
+	assert.Equal(t, 2, device.Height)
+	assert.Equal(t, 600, device.MaxPower)
+	assert.Equal(t, "00:11:22:33:44:55", device.Mac)
+
+	assert.NoError(t, c.CreateUnmanagedDevice(ov.UnmanagedDevice{Name: "patch-panel", Height: 1, MaxPower: 0}))
+	assert.Equal(t, "patch-panel", created.Name)
+	assert.Error(t, c.UpdateUnmanagedDevice(ov.UnmanagedDevice{Name: "patch-panel"}))
+	assert.NoError(t, c.UpdateUnmanagedDevice(device))
+	assert.NoError(t, c.DeleteUnmanagedDevice("core-switch"))
+
+	assert.Error(t, c.DeleteAllUnmanagedDevices(""))
+	assert.NoError(t, c.DeleteAllUnmanagedDevices("model='Procurve 4200VL'"))
+	assert.Contains(t, requests, "PUT /rest/unmanaged-devices/1?")
+	assert.Contains(t, requests, "DELETE /rest/unmanaged-devices/1?")
+	assert.Contains(t, requests, "DELETE /rest/unmanaged-devices?filter=model%3D%27Procurve+4200VL%27")
+}
@@ -0,0 +1,59 @@
+package ov
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/HewlettPackard/oneview-golang/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateUplinkSetNetworks(t *testing.T) {
+	var lists int
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/ethernet-networks" {
+			http.NotFound(w, r)
+			return
+		}
+		lists++
+		filter := r.URL.Query().Get("filter")
+		var members []string
+		if strings.Contains(filter, "uri='/rest/ethernet-networks/tagged'") {
+			members = append(members, `{"name":"tagged","ethernetNetworkType":"Tagged","uri":"/rest/ethernet-networks/tagged"}`)
+		}
+		if strings.Contains(filter, "uri='/rest/ethernet-networks/untagged'") {
+			members = append(members, `{"name":"untagged","ethernetNetworkType":"Untagged","uri":"/rest/ethernet-networks/untagged"}`)
+		}
+		w.Write([]byte(fmt.Sprintf(`{"total":%d,"count":%d,"members":[%s]}`, len(members), len(members), strings.Join(members, ","))))
+	})
+	defer ts.Close()
+
+	tagged := utils.NewNstring("/rest/ethernet-networks/tagged")
+	untagged := utils.NewNstring("/rest/ethernet-networks/untagged")
+
+	assert.NoError(t, c.ValidateUplinkSetNetworks(ov.UplinkSet{NetworkType: "Ethernet", EthernetNetworkType: "Tagged", NetworkURIs: []utils.Nstring{tagged}}))
+	assert.NoError(t, c.ValidateUplinkSetNetworks(ov.UplinkSet{NetworkType: "Ethernet", EthernetNetworkType: "Untagged", NetworkURIs: []utils.Nstring{untagged}}))
+
+	lists = 0
+	missing := utils.NewNstring("/rest/ethernet-networks/deleted")
+	err := c.ValidateUplinkSetNetworks(ov.UplinkSet{NetworkType: "Ethernet", EthernetNetworkType: "Tagged", NetworkURIs: []utils.Nstring{tagged, untagged, missing}})
+	assert.Error(t, err)
+	if err != nil {
+		assert.Contains(t, err.Error(), "network untagged is Untagged")
+		assert.Contains(t, err.Error(), "/rest/ethernet-networks/deleted could not be found")
+		assert.NotContains(t, err.Error(), "network tagged")
+	}
+	assert.Equal(t, 1, lists, "the networks should be read in one list request")
+
+	err = c.ValidateUplinkSetNetworks(ov.UplinkSet{EthernetNetworkType: "Untagged", NetworkURIs: []utils.Nstring{untagged, untagged}})
+	assert.Error(t, err)
+	if err != nil {
+		assert.Contains(t, err.Error(), "single network")
+	}
+
+	err = c.CreateUplinkSet(ov.UplinkSet{Name: "us1", NetworkType: "Ethernet", EthernetNetworkType: "Untagged", NetworkURIs: []utils.Nstring{tagged}})
+	assert.Error(t, err, "CreateUplinkSet should not submit mismatched networks")
+}
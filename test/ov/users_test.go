@@ -0,0 +1,64 @@
+package ov
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/HewlettPackard/oneview-golang/ov"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsers(t *testing.T) {
+	var created ov.User
+	deleted := false
+	ts, c := getMockDriver(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/users":
+			if r.Method == http.MethodPost {
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+				w.Write([]byte(`{"userName":"svc-deploy","uri":"/rest/users/svc-deploy"}`))
+				return
+			}
+			w.Write([]byte(`{"total":1,"count":1,"members":[{"userName":"svc-deploy","enabled":true,"permissions":[{"roleName":"Server administrator","scopeUri":"/rest/scopes/1"}]}]}`))
+		case "/rest/users/svc-deploy":
+			if r.Method == http.MethodDelete {
+				deleted = true
+			}
+			w.Write([]byte(`{"userName":"svc-deploy","uri":"/rest/users/svc-deploy"}`))
+		case "/rest/users/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/rest/roles":
+			w.Write([]byte(`{"total":2,"count":2,"members":[{"roleName":"Infrastructure administrator"},{"roleName":"Server administrator"}]}`))
+		}
+	})
+	defer ts.Close()
+
+	user := ov.User{
+		UserName:    "svc-deploy",
+		Password:    "secret",
+		Enabled:     true,
+		Permissions: []ov.Permission{{RoleName: "Server administrator", ScopeUri: "/rest/scopes/1"}},
+	}
+	assert.NoError(t, c.CreateUser(user))
+	assert.Equal(t, "UserAndPermissions", created.Type)
+	assert.Equal(t, "/rest/scopes/1", created.Permissions[0].ScopeUri.String())
+
+	user.Permissions = nil
+	assert.Error(t, c.CreateUser(user))
+
+	users, err := c.GetUsers()
+	assert.NoError(t, err)
+	assert.Equal(t, "Server administrator", users.Members[0].Permissions[0].RoleName)
+
+	missing, err := c.GetUserByName("missing")
+	assert.NoError(t, err)
+	assert.Equal(t, "", missing.UserName)
+
+	assert.NoError(t, c.DeleteUser("svc-deploy"))
+	assert.True(t, deleted)
+
+	roles, err := c.GetRoles()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, roles.Total)
+}
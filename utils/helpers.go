@@ -1,6 +1,12 @@
 package utils
 
-import "strings"
+import (
+	"net"
+	"regexp"
+	"strings"
+)
+
+var reHostname = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*\.?$`)
 
 // Sanatize ...
 func Sanatize(s string) string {
@@ -20,3 +26,12 @@ func IsEmpty(s string) bool {
 func GetBoolPointer(value bool) *bool {
 	return &value
 }
+
+// IsValidHost returns true when s is an IP address or a well-formed hostname
+// this is a syntax check only, no name resolution is attempted
+func IsValidHost(s string) bool {
+	if net.ParseIP(s) != nil {
+		return true
+	}
+	return len(s) <= 253 && reHostname.MatchString(s)
+}
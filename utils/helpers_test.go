@@ -17,6 +17,18 @@ var emptytests = map[string]bool{
 	"🤘🏻":     false,
 }
 
+var hosttests = map[string]bool{
+	"16.110.135.123":  true,
+	"fe80::1":         true,
+	"ntp.example.com": true,
+	"ntp1":            true,
+	"":                false,
+	"ntp..example":    false,
+	"-ntp.example":    false,
+	"ntp example.com": false,
+	"1.2.3.4:123":     false,
+}
+
 func TestStringTrimming(t *testing.T) {
 	for original, expected := range trimtests {
 		if result := Sanatize(original); result != expected {
@@ -37,3 +49,12 @@ func TestEmpties(t *testing.T) {
 		}
 	}
 }
+
+func TestIsValidHost(t *testing.T) {
+	for value, expected := range hosttests {
+		if valid := IsValidHost(value); valid != expected {
+			t.Logf("Host %q expected valid to be %v, got %v instead", value, expected, valid)
+			t.Fail()
+		}
+	}
+}